@@ -0,0 +1,101 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+)
+
+// AcceleratorTable is an accelerator table created at runtime. Add it to a
+// MessageLoop with AddAccelerators to have its key combinations sent as
+// WM_COMMAND.
+type AcceleratorTable struct {
+	haccel HACCEL
+	accels []ACCEL
+}
+
+// NewAcceleratorTable creates an accelerator table from accels.
+func NewAcceleratorTable(accels []ACCEL) (*AcceleratorTable, error) {
+	if len(accels) == 0 {
+		return nil, errors.New("empty accelerator table")
+	}
+
+	haccel := CreateAcceleratorTable(&accels[0], int32(len(accels)))
+	if haccel == 0 {
+		return nil, errors.New("CreateAcceleratorTable failed")
+	}
+
+	return &AcceleratorTable{
+		haccel: haccel,
+		accels: append([]ACCEL(nil), accels...),
+	}, nil
+}
+
+// ParseAccelerators creates an accelerator table from a map of shortcuts
+// in the syntax of ParseShortcut to command ids.
+func ParseAccelerators(shortcuts map[string]uint16) (*AcceleratorTable, error) {
+	accels := make([]ACCEL, 0, len(shortcuts))
+
+	for s, cmd := range shortcuts {
+		sc, err := ParseShortcut(s)
+		if err != nil {
+			return nil, err
+		}
+
+		accel, err := sc.Accel(cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		accels = append(accels, accel)
+	}
+
+	return NewAcceleratorTable(accels)
+}
+
+// Handle returns the native accelerator table.
+func (t *AcceleratorTable) Handle() HACCEL {
+	return t.haccel
+}
+
+// Accelerators returns the entries of t.
+func (t *AcceleratorTable) Accelerators() []ACCEL {
+	return append([]ACCEL(nil), t.accels...)
+}
+
+// Shortcut returns the first shortcut for the command id cmd, e.g. to
+// display it in a tooltip.
+func (t *AcceleratorTable) Shortcut(cmd uint16) (Shortcut, bool) {
+	for _, a := range t.accels {
+		if a.Cmd == cmd && a.FVirt&FVIRTKEY != 0 {
+			return ShortcutFromAccel(a), true
+		}
+	}
+
+	return Shortcut{}, false
+}
+
+// Translate sends the command of the accelerator matching msg to hwnd and
+// reports whether there was one.
+func (t *AcceleratorTable) Translate(hwnd HWND, msg *MSG) bool {
+	return TranslateAccelerator(hwnd, t.haccel, msg)
+}
+
+// Destroy destroys the accelerator table. Remove it from message loops
+// first.
+func (t *AcceleratorTable) Destroy() error {
+	if t.haccel == 0 {
+		return nil
+	}
+
+	if !DestroyAcceleratorTable(t.haccel) {
+		return errors.New("DestroyAcceleratorTable failed")
+	}
+	t.haccel = 0
+
+	return nil
+}
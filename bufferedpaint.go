@@ -0,0 +1,201 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+)
+
+// BufferedPaint implements flicker free WM_PAINT handling for a window by
+// drawing into an off-screen bitmap and copying the invalidated part of it to
+// the screen.
+//
+// The back buffer is kept across WM_PAINT messages and only recreated when
+// the client area changes size. When the uxtheme buffered paint API is
+// available and requested, it is used instead and manages its own buffers.
+//
+// Windows using BufferedPaint should return 1 from WM_ERASEBKGND, since the
+// paint callback is expected to draw the whole update rectangle.
+type BufferedPaint struct {
+	hwnd      HWND
+	useTheme  bool
+	memDC     HDC
+	bitmap    HBITMAP
+	oldBitmap HGDIOBJ
+	size      SIZE
+}
+
+// BufferedPaintFunc draws the part of the window given by rcPaint, in client
+// coordinates, onto hdc.
+type BufferedPaintFunc func(hdc HDC, rcPaint *RECT) error
+
+// NewBufferedPaint returns a BufferedPaint for hwnd. If useTheme is true and
+// uxtheme.dll exports BeginBufferedPaint, painting goes through it.
+//
+// It must be called on the thread that owns hwnd.
+func NewBufferedPaint(hwnd HWND, useTheme bool) *BufferedPaint {
+	bp := &BufferedPaint{hwnd: hwnd}
+
+	if useTheme && beginBufferedPaint.Find() == nil && SUCCEEDED(BufferedPaintInit()) {
+		bp.useTheme = true
+	}
+
+	return bp
+}
+
+// UsesTheme reports whether the uxtheme buffered paint API is in use.
+func (bp *BufferedPaint) UsesTheme() bool {
+	return bp.useTheme
+}
+
+// Paint handles WM_PAINT. It calls BeginPaint, lets paint draw the update
+// rectangle into the back buffer, copies it to the window and calls EndPaint.
+func (bp *BufferedPaint) Paint(paint BufferedPaintFunc) error {
+	var ps PAINTSTRUCT
+
+	hdc := BeginPaint(bp.hwnd, &ps)
+	if hdc == 0 {
+		return errors.New("BeginPaint failed")
+	}
+	defer EndPaint(bp.hwnd, &ps)
+
+	if ps.RcPaint.Right <= ps.RcPaint.Left || ps.RcPaint.Bottom <= ps.RcPaint.Top {
+		return nil
+	}
+
+	if bp.useTheme {
+		return bp.paintThemed(hdc, &ps.RcPaint, paint)
+	}
+
+	return bp.PaintDC(hdc, &ps.RcPaint, paint)
+}
+
+func (bp *BufferedPaint) paintThemed(hdc HDC, rcPaint *RECT, paint BufferedPaintFunc) error {
+	var memDC HDC
+
+	hpb := BeginBufferedPaint(hdc, rcPaint, BPBF_COMPATIBLEBITMAP, nil, &memDC)
+	if hpb == 0 {
+		// Buffered paint can fail, e.g. for huge rectangles, so draw directly.
+		return paint(hdc, rcPaint)
+	}
+
+	err := paint(memDC, rcPaint)
+
+	EndBufferedPaint(hpb, err == nil)
+
+	return err
+}
+
+// PaintDC draws rcPaint through the back buffer onto hdc. It can be used
+// instead of Paint when the caller already has a DC, e.g. for WM_PRINTCLIENT.
+func (bp *BufferedPaint) PaintDC(hdc HDC, rcPaint *RECT, paint BufferedPaintFunc) error {
+	if err := bp.ensureBuffer(hdc); err != nil {
+		return err
+	}
+
+	saved := SaveDC(bp.memDC)
+	if saved == 0 {
+		return errors.New("SaveDC failed")
+	}
+
+	IntersectClipRect(bp.memDC, rcPaint.Left, rcPaint.Top, rcPaint.Right, rcPaint.Bottom)
+
+	err := paint(bp.memDC, rcPaint)
+
+	// Undoes any pen, brush, font or clipping changes made by paint.
+	RestoreDC(bp.memDC, saved)
+
+	if err != nil {
+		return err
+	}
+
+	if !BitBlt(
+		hdc,
+		rcPaint.Left,
+		rcPaint.Top,
+		rcPaint.Right-rcPaint.Left,
+		rcPaint.Bottom-rcPaint.Top,
+		bp.memDC,
+		rcPaint.Left,
+		rcPaint.Top,
+		SRCCOPY) {
+		return errors.New("BitBlt failed")
+	}
+
+	return nil
+}
+
+func (bp *BufferedPaint) ensureBuffer(hdc HDC) error {
+	var rc RECT
+	if !GetClientRect(bp.hwnd, &rc) {
+		return errors.New("GetClientRect failed")
+	}
+
+	size := SIZE{rc.Right - rc.Left, rc.Bottom - rc.Top}
+	if size.CX < 1 {
+		size.CX = 1
+	}
+	if size.CY < 1 {
+		size.CY = 1
+	}
+
+	if bp.memDC != 0 && size == bp.size {
+		return nil
+	}
+
+	bp.releaseBuffer()
+
+	if bp.memDC = CreateCompatibleDC(hdc); bp.memDC == 0 {
+		return errors.New("CreateCompatibleDC failed")
+	}
+
+	if bp.bitmap = CreateCompatibleBitmap(hdc, size.CX, size.CY); bp.bitmap == 0 {
+		bp.releaseBuffer()
+		return errors.New("CreateCompatibleBitmap failed")
+	}
+
+	bp.oldBitmap = SelectObject(bp.memDC, HGDIOBJ(bp.bitmap))
+	bp.size = size
+
+	return nil
+}
+
+func (bp *BufferedPaint) releaseBuffer() {
+	if bp.memDC != 0 {
+		if bp.oldBitmap != 0 {
+			SelectObject(bp.memDC, bp.oldBitmap)
+			bp.oldBitmap = 0
+		}
+
+		DeleteDC(bp.memDC)
+		bp.memDC = 0
+	}
+
+	if bp.bitmap != 0 {
+		DeleteObject(HGDIOBJ(bp.bitmap))
+		bp.bitmap = 0
+	}
+
+	bp.size = SIZE{}
+}
+
+// Invalidate drops the cached back buffer. Calling it from WM_SIZE frees
+// memory early; Paint notices size changes on its own.
+func (bp *BufferedPaint) Invalidate() {
+	bp.releaseBuffer()
+}
+
+// Dispose releases the back buffer and, if used, uninitializes the uxtheme
+// buffered paint API for the calling thread.
+func (bp *BufferedPaint) Dispose() {
+	bp.releaseBuffer()
+
+	if bp.useTheme {
+		BufferedPaintUnInit()
+		bp.useTheme = false
+	}
+}
@@ -0,0 +1,379 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"image"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrClipboardBusy              = errors.New("clipboard is opened by another window")
+	ErrClipboardFormatUnavailable = errors.New("clipboard format is not available")
+)
+
+// ClipboardRetries and ClipboardRetryDelay control how often and how long
+// OpenClipboardRetry retries while another window has the clipboard open.
+// Other applications usually hold it open only briefly.
+var (
+	ClipboardRetries    = 10
+	ClipboardRetryDelay = 10 * time.Millisecond
+)
+
+// Clipboard is the open clipboard. It must be closed on the thread that
+// opened it, and as soon as possible, since no other window can use the
+// clipboard meanwhile.
+type Clipboard struct {
+	owner HWND
+}
+
+// OpenClipboardRetry opens the clipboard for owner, retrying while another
+// window has it open. To set data, owner should be a window of the calling
+// thread; with 0, Empty makes the clipboard ownerless.
+func OpenClipboardRetry(owner HWND) (*Clipboard, error) {
+	for i := 0; ; i++ {
+		if OpenClipboard(owner) {
+			return &Clipboard{owner: owner}, nil
+		}
+
+		if i >= ClipboardRetries {
+			return nil, ErrClipboardBusy
+		}
+
+		time.Sleep(ClipboardRetryDelay)
+	}
+}
+
+// Close closes the clipboard.
+func (c *Clipboard) Close() error {
+	if !CloseClipboard() {
+		return errors.New("CloseClipboard failed")
+	}
+
+	return nil
+}
+
+// Empty clears the clipboard and makes the owner of c its owner. Call it
+// before setting new data.
+func (c *Clipboard) Empty() error {
+	if !EmptyClipboard() {
+		return errors.New("EmptyClipboard failed")
+	}
+
+	return nil
+}
+
+// Formats returns the formats currently on the clipboard, in the order they
+// were put there.
+func (c *Clipboard) Formats() []uint32 {
+	var formats []uint32
+
+	for format := EnumClipboardFormats(0); format != 0; format = EnumClipboardFormats(format) {
+		formats = append(formats, format)
+	}
+
+	return formats
+}
+
+// Has reports whether data in format is available, possibly by
+// synthesizing it from another format.
+func (c *Clipboard) Has(format uint32) bool {
+	return IsClipboardFormatAvailable(format)
+}
+
+// Get returns a copy of the data in format. It only works for formats
+// stored in global memory, which are all except the GDI object formats such
+// as CF_BITMAP.
+func (c *Clipboard) Get(format uint32) ([]byte, error) {
+	if !IsClipboardFormatAvailable(format) {
+		return nil, ErrClipboardFormatUnavailable
+	}
+
+	hMem := HGLOBAL(GetClipboardData(format))
+	if hMem == 0 {
+		return nil, errors.New("GetClipboardData failed")
+	}
+
+	return globalBytes(hMem)
+}
+
+// globalBytes returns a copy of the global memory object hMem.
+func globalBytes(hMem HGLOBAL) ([]byte, error) {
+	size := GlobalSize(hMem)
+
+	p := GlobalLock(hMem)
+	if p == nil {
+		return nil, errors.New("GlobalLock failed")
+	}
+	defer GlobalUnlock(hMem)
+
+	data := make([]byte, size)
+	if size > 0 {
+		copy(data, (*[1 << 30]byte)(p)[:size:size])
+	}
+
+	return data, nil
+}
+
+// Set puts a copy of data on the clipboard in format.
+func (c *Clipboard) Set(format uint32, data []byte) error {
+	hMem, err := globalAllocBytes(data)
+	if err != nil {
+		return err
+	}
+
+	// On success, the system owns the memory.
+	if SetClipboardData(format, HANDLE(hMem)) == 0 {
+		GlobalFree(hMem)
+		return errors.New("SetClipboardData failed")
+	}
+
+	return nil
+}
+
+// globalAllocBytes returns a moveable global memory object holding a copy
+// of data.
+func globalAllocBytes(data []byte) (HGLOBAL, error) {
+	hMem := GlobalAlloc(GMEM_MOVEABLE, uintptr(len(data)))
+	if hMem == 0 {
+		return 0, errors.New("GlobalAlloc failed")
+	}
+
+	p := GlobalLock(hMem)
+	if p == nil {
+		GlobalFree(hMem)
+		return 0, errors.New("GlobalLock failed")
+	}
+
+	if len(data) > 0 {
+		copy((*[1 << 30]byte)(p)[:len(data):len(data)], data)
+	}
+
+	GlobalUnlock(hMem)
+
+	return hMem, nil
+}
+
+// Text returns the CF_UNICODETEXT data.
+func (c *Clipboard) Text() (string, error) {
+	data, err := c.Get(CF_UNICODETEXT)
+	if err != nil {
+		return "", err
+	}
+
+	return DecodeUnicodeText(data), nil
+}
+
+// SetText puts s on the clipboard as CF_UNICODETEXT.
+func (c *Clipboard) SetText(s string) error {
+	return c.Set(CF_UNICODETEXT, EncodeUnicodeText(s))
+}
+
+// HTML returns the "HTML Format" data.
+func (c *Clipboard) HTML() (*HTMLClip, error) {
+	format, err := ClipboardFormat("HTML Format")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.Get(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeHTMLFormat(data)
+}
+
+// SetHTML puts the HTML fragment on the clipboard as "HTML Format".
+// Applications that do not understand HTML need a CF_UNICODETEXT version
+// as well.
+func (c *Clipboard) SetHTML(fragment, sourceURL string) error {
+	format, err := ClipboardFormat("HTML Format")
+	if err != nil {
+		return err
+	}
+
+	return c.Set(format, EncodeHTMLFormat(fragment, sourceURL))
+}
+
+// Files returns the file list of the CF_HDROP data.
+func (c *Clipboard) Files() ([]string, error) {
+	data, err := c.Get(CF_HDROP)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeDropFiles(data)
+}
+
+// SetFiles puts paths on the clipboard as CF_HDROP.
+func (c *Clipboard) SetFiles(paths []string) error {
+	return c.Set(CF_HDROP, EncodeDropFiles(paths))
+}
+
+// Image returns the clipboard image, preferring CF_DIBV5 for its alpha
+// channel.
+func (c *Clipboard) Image() (*image.NRGBA, error) {
+	format := uint32(CF_DIBV5)
+	if !IsClipboardFormatAvailable(format) {
+		format = CF_DIB
+	}
+
+	data, err := c.Get(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeDIB(data)
+}
+
+// SetImage puts img on the clipboard as CF_DIBV5. The system synthesizes
+// CF_DIB and CF_BITMAP from it for applications that request them.
+func (c *Clipboard) SetImage(img image.Image) error {
+	return c.Set(CF_DIBV5, EncodeDIBV5(img))
+}
+
+// ClipboardText opens the clipboard and returns its CF_UNICODETEXT data.
+func ClipboardText() (string, error) {
+	c, err := OpenClipboardRetry(0)
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+
+	return c.Text()
+}
+
+// SetClipboardText opens the clipboard and replaces its contents with s.
+func SetClipboardText(s string) error {
+	c, err := OpenClipboardRetry(0)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err := c.Empty(); err != nil {
+		return err
+	}
+
+	return c.SetText(s)
+}
+
+// ClipboardFormat registers the clipboard format name, or returns its id
+// if it is already registered.
+func ClipboardFormat(name string) (uint32, error) {
+	name16, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+
+	format := RegisterClipboardFormat(name16)
+	if format == 0 {
+		return 0, errors.New("RegisterClipboardFormat failed")
+	}
+
+	return format, nil
+}
+
+// ClipboardFormatName returns the name of a registered clipboard format, or
+// an empty string for predefined formats.
+func ClipboardFormatName(format uint32) string {
+	var buf [256]uint16
+
+	n := GetClipboardFormatName(format, &buf[0], int32(len(buf)))
+	if n <= 0 {
+		return ""
+	}
+
+	return syscall.UTF16ToString(buf[:n])
+}
+
+// ClipboardWatcher reports clipboard changes. It runs a message-only window
+// on its own thread that listens for WM_CLIPBOARDUPDATE.
+type ClipboardWatcher struct {
+	// C receives the clipboard sequence number after each change. Changes
+	// are dropped while C is full, so the latest number may be older than
+	// the current one; compare with GetClipboardSequenceNumber if needed.
+	C <-chan uint32
+
+	loop      *MessageLoop
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// WatchClipboard starts watching the clipboard. Call Close to stop.
+func WatchClipboard() (*ClipboardWatcher, error) {
+	c := make(chan uint32, 1)
+	started := make(chan error, 1)
+
+	cw := &ClipboardWatcher{
+		C:    c,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(cw.done)
+		defer close(c)
+
+		loop, err := NewMessageLoop()
+		if err != nil {
+			started <- err
+			return
+		}
+		defer loop.Dispose()
+
+		w, err := NewMessageWindow()
+		if err != nil {
+			started <- err
+			return
+		}
+		defer w.Destroy()
+
+		w.Handle(WM_CLIPBOARDUPDATE, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+			select {
+			case c <- GetClipboardSequenceNumber():
+			default:
+			}
+			return 0, true
+		})
+
+		if !AddClipboardFormatListener(w.HWND()) {
+			started <- errors.New("AddClipboardFormatListener failed")
+			return
+		}
+		defer RemoveClipboardFormatListener(w.HWND())
+
+		cw.loop = loop
+		started <- nil
+
+		loop.Run()
+	}()
+
+	if err := <-started; err != nil {
+		<-cw.done
+		return nil, err
+	}
+
+	return cw, nil
+}
+
+// Close stops watching and closes C.
+func (cw *ClipboardWatcher) Close() error {
+	var err error
+
+	cw.closeOnce.Do(func() {
+		err = cw.loop.Quit(0)
+		if err == nil {
+			<-cw.done
+		}
+	})
+
+	return err
+}
@@ -0,0 +1,526 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// The encoders and decoders in this file work on the contents of clipboard
+// memory blocks and do not call into Windows.
+
+// Bitmap compression constants
+const (
+	BI_RGB       = 0
+	BI_RLE8      = 1
+	BI_RLE4      = 2
+	BI_BITFIELDS = 3
+	BI_JPEG      = 4
+	BI_PNG       = 5
+)
+
+// Logical color space types and intents
+const (
+	LCS_CALIBRATED_RGB      = 0x00000000
+	LCS_sRGB                = 0x73524742
+	LCS_WINDOWS_COLOR_SPACE = 0x57696E20
+	LCS_GM_BUSINESS         = 0x00000001
+	LCS_GM_GRAPHICS         = 0x00000002
+	LCS_GM_IMAGES           = 0x00000004
+	LCS_GM_ABS_COLORIMETRIC = 0x00000008
+)
+
+var (
+	ErrInvalidClipboardData = errors.New("invalid clipboard data")
+	ErrUnsupportedDIB       = errors.New("unsupported DIB format")
+)
+
+// EncodeUnicodeText encodes s as CF_UNICODETEXT: NUL terminated UTF-16LE.
+// Line breaks are not converted.
+func EncodeUnicodeText(s string) []byte {
+	units := utf16.Encode([]rune(s))
+
+	buf := make([]byte, 2*len(units)+2)
+	for i, u := range units {
+		binary.LittleEndian.PutUint16(buf[2*i:], u)
+	}
+
+	return buf
+}
+
+// DecodeUnicodeText decodes CF_UNICODETEXT data up to the first NUL.
+func DecodeUnicodeText(data []byte) string {
+	units := make([]uint16, 0, len(data)/2)
+
+	for i := 0; i+1 < len(data); i += 2 {
+		u := binary.LittleEndian.Uint16(data[i:])
+		if u == 0 {
+			break
+		}
+		units = append(units, u)
+	}
+
+	return string(utf16.Decode(units))
+}
+
+// HTMLClip is the content of the "HTML Format" clipboard format.
+type HTMLClip struct {
+	Version   string
+	HTML      string // the whole HTML document
+	Fragment  string // the selected part of HTML
+	SourceURL string
+}
+
+const (
+	htmlStartFragment = "<!--StartFragment-->"
+	htmlEndFragment   = "<!--EndFragment-->"
+)
+
+// EncodeHTMLFormat encodes the HTML fragment as "HTML Format" data. The
+// fragment is wrapped in a minimal document and the header offsets are
+// computed in bytes of the UTF-8 encoding, as the format requires.
+func EncodeHTMLFormat(fragment, sourceURL string) []byte {
+	const header = "Version:0.9\r\n" +
+		"StartHTML:%010d\r\n" +
+		"EndHTML:%010d\r\n" +
+		"StartFragment:%010d\r\n" +
+		"EndFragment:%010d\r\n"
+
+	var source string
+	if sourceURL != "" {
+		source = "SourceURL:" + sourceURL + "\r\n"
+	}
+
+	prefix := "<html>\r\n<body>\r\n" + htmlStartFragment
+	suffix := htmlEndFragment + "\r\n</body>\r\n</html>"
+
+	// All offsets have a fixed width, so the header length does not depend
+	// on their values.
+	startHTML := len(fmt.Sprintf(header, 0, 0, 0, 0)) + len(source)
+	startFragment := startHTML + len(prefix)
+	endFragment := startFragment + len(fragment)
+	endHTML := endFragment + len(suffix)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, header, startHTML, endHTML, startFragment, endFragment)
+	buf.WriteString(source)
+	buf.WriteString(prefix)
+	buf.WriteString(fragment)
+	buf.WriteString(suffix)
+	buf.WriteByte(0)
+
+	return buf.Bytes()
+}
+
+// DecodeHTMLFormat decodes "HTML Format" data. If the fragment offsets are
+// missing or invalid, the fragment is located by its comment markers.
+func DecodeHTMLFormat(data []byte) (*HTMLClip, error) {
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		data = data[:i]
+	}
+
+	clip := new(HTMLClip)
+	offsets := map[string]int{
+		"StartHTML":     -1,
+		"EndHTML":       -1,
+		"StartFragment": -1,
+		"EndFragment":   -1,
+	}
+
+	// The header ends at the first tag.
+	headerEnd := bytes.IndexByte(data, '<')
+	if headerEnd < 0 {
+		headerEnd = len(data)
+	}
+
+	for _, line := range strings.Split(string(data[:headerEnd]), "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			continue
+		}
+		key, value := line[:i], line[i+1:]
+
+		switch key {
+		case "Version":
+			clip.Version = value
+
+		case "SourceURL":
+			clip.SourceURL = value
+
+		default:
+			if _, ok := offsets[key]; ok {
+				if n, err := strconv.Atoi(value); err == nil {
+					offsets[key] = n
+				}
+			}
+		}
+	}
+
+	if clip.Version == "" {
+		return nil, ErrInvalidClipboardData
+	}
+
+	valid := func(start, end int) bool {
+		return start >= 0 && start <= end && end <= len(data)
+	}
+
+	if start, end := offsets["StartHTML"], offsets["EndHTML"]; valid(start, end) {
+		clip.HTML = string(data[start:end])
+	} else {
+		clip.HTML = string(data[headerEnd:])
+	}
+
+	if start, end := offsets["StartFragment"], offsets["EndFragment"]; valid(start, end) {
+		clip.Fragment = string(data[start:end])
+	} else {
+		s := string(data)
+		start := strings.Index(s, htmlStartFragment)
+		end := strings.LastIndex(s, htmlEndFragment)
+		if start < 0 || end < start+len(htmlStartFragment) {
+			return nil, ErrInvalidClipboardData
+		}
+		clip.Fragment = s[start+len(htmlStartFragment) : end]
+	}
+
+	return clip, nil
+}
+
+const sizeofDROPFILES = 20
+
+// EncodeDropFiles encodes paths as CF_HDROP data: a DROPFILES header
+// followed by a double NUL terminated list of wide strings.
+func EncodeDropFiles(paths []string) []byte {
+	buf := make([]byte, sizeofDROPFILES, sizeofDROPFILES+64*len(paths))
+
+	binary.LittleEndian.PutUint32(buf[0:], sizeofDROPFILES) // pFiles
+	binary.LittleEndian.PutUint32(buf[16:], 1)              // fWide
+
+	for _, path := range paths {
+		for _, u := range utf16.Encode([]rune(path)) {
+			buf = append(buf, byte(u), byte(u>>8))
+		}
+		buf = append(buf, 0, 0)
+	}
+
+	return append(buf, 0, 0)
+}
+
+// DecodeDropFiles decodes CF_HDROP data with either wide or ANSI file
+// names. ANSI names are decoded as Latin-1.
+func DecodeDropFiles(data []byte) ([]string, error) {
+	if len(data) < sizeofDROPFILES {
+		return nil, ErrInvalidClipboardData
+	}
+
+	offset := binary.LittleEndian.Uint32(data[0:])
+	wide := binary.LittleEndian.Uint32(data[16:]) != 0
+
+	if offset < sizeofDROPFILES || uint64(offset) > uint64(len(data)) {
+		return nil, ErrInvalidClipboardData
+	}
+	data = data[offset:]
+
+	var paths []string
+
+	if wide {
+		var units []uint16
+		for i := 0; i+1 < len(data); i += 2 {
+			u := binary.LittleEndian.Uint16(data[i:])
+			if u != 0 {
+				units = append(units, u)
+				continue
+			}
+			if len(units) == 0 {
+				return paths, nil
+			}
+			paths = append(paths, string(utf16.Decode(units)))
+			units = units[:0]
+		}
+	} else {
+		var runes []rune
+		for _, b := range data {
+			if b != 0 {
+				runes = append(runes, rune(b))
+				continue
+			}
+			if len(runes) == 0 {
+				return paths, nil
+			}
+			paths = append(paths, string(runes))
+			runes = runes[:0]
+		}
+	}
+
+	// The list was not terminated.
+	return nil, ErrInvalidClipboardData
+}
+
+const (
+	sizeofBITMAPINFOHEADER = 40
+	sizeofBITMAPV5HEADER   = 124
+)
+
+// maxDIBPixels limits the size of decoded bitmaps so that the size of their
+// pixel data fits in an int on 32-bit platforms.
+const maxDIBPixels = (1<<31 - 1) / 4
+
+// EncodeDIBV5 encodes img as CF_DIBV5 data: a BITMAPV5HEADER followed by
+// bottom-up 32 bpp pixels with straight alpha in the sRGB color space.
+func EncodeDIBV5(img image.Image) []byte {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	buf := make([]byte, sizeofBITMAPV5HEADER+4*w*h)
+	le := binary.LittleEndian
+
+	le.PutUint32(buf[0:], sizeofBITMAPV5HEADER) // bV5Size
+	le.PutUint32(buf[4:], uint32(int32(w)))     // bV5Width
+	le.PutUint32(buf[8:], uint32(int32(h)))     // bV5Height
+	le.PutUint16(buf[12:], 1)                   // bV5Planes
+	le.PutUint16(buf[14:], 32)                  // bV5BitCount
+	le.PutUint32(buf[16:], BI_BITFIELDS)        // bV5Compression
+	le.PutUint32(buf[20:], uint32(4*w*h))       // bV5SizeImage
+	le.PutUint32(buf[40:], 0x00FF0000)          // bV5RedMask
+	le.PutUint32(buf[44:], 0x0000FF00)          // bV5GreenMask
+	le.PutUint32(buf[48:], 0x000000FF)          // bV5BlueMask
+	le.PutUint32(buf[52:], 0xFF000000)          // bV5AlphaMask
+	le.PutUint32(buf[56:], LCS_sRGB)            // bV5CSType
+	le.PutUint32(buf[108:], LCS_GM_IMAGES)      // bV5Intent
+
+	putDIBPixels(buf[sizeofBITMAPV5HEADER:], img)
+
+	return buf
+}
+
+// EncodeDIB encodes img as CF_DIB data: a BITMAPINFOHEADER followed by
+// bottom-up 32 bpp pixels. Most readers of CF_DIB ignore the alpha in the
+// fourth byte, so data targets that support it should get CF_DIBV5 too.
+func EncodeDIB(img image.Image) []byte {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	buf := make([]byte, sizeofBITMAPINFOHEADER+4*w*h)
+	le := binary.LittleEndian
+
+	le.PutUint32(buf[0:], sizeofBITMAPINFOHEADER) // biSize
+	le.PutUint32(buf[4:], uint32(int32(w)))       // biWidth
+	le.PutUint32(buf[8:], uint32(int32(h)))       // biHeight
+	le.PutUint16(buf[12:], 1)                     // biPlanes
+	le.PutUint16(buf[14:], 32)                    // biBitCount
+	le.PutUint32(buf[16:], BI_RGB)                // biCompression
+	le.PutUint32(buf[20:], uint32(4*w*h))         // biSizeImage
+
+	putDIBPixels(buf[sizeofBITMAPINFOHEADER:], img)
+
+	return buf
+}
+
+// putDIBPixels stores img in pix as bottom-up BGRA rows.
+func putDIBPixels(pix []byte, img image.Image) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	for y := 0; y < h; y++ {
+		row := pix[4*w*(h-1-y):]
+		for x := 0; x < w; x++ {
+			c := color.NRGBAModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA)
+			row[4*x+0] = c.B
+			row[4*x+1] = c.G
+			row[4*x+2] = c.R
+			row[4*x+3] = c.A
+		}
+	}
+}
+
+// DecodeDIB decodes CF_DIB or CF_DIBV5 data. It supports uncompressed 1, 4,
+// 8, 24 and 32 bpp bitmaps and 16 or 32 bpp BI_BITFIELDS bitmaps. 32 bpp
+// bitmaps without any alpha are treated as opaque.
+func DecodeDIB(data []byte) (*image.NRGBA, error) {
+	le := binary.LittleEndian
+
+	if len(data) < sizeofBITMAPINFOHEADER {
+		return nil, ErrInvalidClipboardData
+	}
+
+	headerSize := int(le.Uint32(data[0:]))
+	switch headerSize {
+	case 40, 52, 56, 108, 124:
+	default:
+		return nil, ErrUnsupportedDIB
+	}
+	if len(data) < headerSize {
+		return nil, ErrInvalidClipboardData
+	}
+
+	width := int(int32(le.Uint32(data[4:])))
+	height := int(int32(le.Uint32(data[8:])))
+	bitCount := int(le.Uint16(data[14:]))
+	compression := le.Uint32(data[16:])
+	clrUsed := int(le.Uint32(data[32:]))
+
+	topDown := height < 0
+	if topDown {
+		height = -height
+	}
+	if width <= 0 || height == 0 || width > 1<<16 || height > 1<<16 || int64(width)*int64(height) > maxDIBPixels {
+		return nil, ErrInvalidClipboardData
+	}
+
+	offset := headerSize
+
+	var masks [4]uint32
+	switch compression {
+	case BI_RGB:
+		switch bitCount {
+		case 1, 4, 8, 24, 32:
+		default:
+			return nil, ErrUnsupportedDIB
+		}
+
+	case BI_BITFIELDS:
+		if bitCount != 16 && bitCount != 32 {
+			return nil, ErrUnsupportedDIB
+		}
+
+		// With a BITMAPINFOHEADER the color masks follow the header,
+		// newer headers include them.
+		if headerSize == sizeofBITMAPINFOHEADER {
+			if len(data) < offset+12 {
+				return nil, ErrInvalidClipboardData
+			}
+			for i := 0; i < 3; i++ {
+				masks[i] = le.Uint32(data[offset+4*i:])
+			}
+			offset += 12
+		} else {
+			n := 3
+			if headerSize >= 56 {
+				n = 4
+			}
+			for i := 0; i < n; i++ {
+				masks[i] = le.Uint32(data[40+4*i:])
+			}
+		}
+
+	default:
+		return nil, ErrUnsupportedDIB
+	}
+
+	var palette []color.NRGBA
+	if bitCount <= 8 {
+		n := clrUsed
+		if n == 0 || n > 1<<uint(bitCount) {
+			n = 1 << uint(bitCount)
+		}
+		if len(data) < offset+4*n {
+			return nil, ErrInvalidClipboardData
+		}
+
+		palette = make([]color.NRGBA, n)
+		for i := range palette {
+			q := data[offset+4*i:]
+			palette[i] = color.NRGBA{R: q[2], G: q[1], B: q[0], A: 0xFF}
+		}
+		offset += 4 * n
+	}
+
+	stride := ((width*bitCount + 31) / 32) * 4
+	if int64(len(data)-offset) < int64(stride)*int64(height) {
+		return nil, ErrInvalidClipboardData
+	}
+	pix := data[offset:]
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	hasAlpha := false
+
+	for y := 0; y < height; y++ {
+		srcY := height - 1 - y
+		if topDown {
+			srcY = y
+		}
+		row := pix[stride*srcY : stride*(srcY+1)]
+		dst := img.Pix[img.Stride*y:]
+
+		for x := 0; x < width; x++ {
+			var c color.NRGBA
+
+			switch {
+			case bitCount <= 8:
+				bit := x * bitCount
+				idx := int(row[bit/8]>>uint(8-bitCount-bit%8)) & (1<<uint(bitCount) - 1)
+				if idx < len(palette) {
+					c = palette[idx]
+				}
+
+			case bitCount == 24:
+				c = color.NRGBA{R: row[3*x+2], G: row[3*x+1], B: row[3*x], A: 0xFF}
+
+			case compression == BI_RGB:
+				c = color.NRGBA{R: row[4*x+2], G: row[4*x+1], B: row[4*x], A: row[4*x+3]}
+
+			default:
+				var v uint32
+				if bitCount == 16 {
+					v = uint32(le.Uint16(row[2*x:]))
+				} else {
+					v = le.Uint32(row[4*x:])
+				}
+				c = color.NRGBA{
+					R: maskedComponent(v, masks[0]),
+					G: maskedComponent(v, masks[1]),
+					B: maskedComponent(v, masks[2]),
+					A: 0xFF,
+				}
+				if masks[3] != 0 {
+					c.A = maskedComponent(v, masks[3])
+				}
+			}
+
+			if bitCount == 32 && c.A != 0 {
+				hasAlpha = true
+			}
+
+			copy(dst[4*x:], []uint8{c.R, c.G, c.B, c.A})
+		}
+	}
+
+	if bitCount == 32 && !hasAlpha {
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xFF
+		}
+	}
+
+	return img, nil
+}
+
+// maskedComponent extracts the color component selected by mask from v and
+// scales it to 8 bits.
+func maskedComponent(v, mask uint32) uint8 {
+	if mask == 0 {
+		return 0
+	}
+
+	shift := uint(0)
+	for mask&1 == 0 {
+		mask >>= 1
+		shift++
+	}
+
+	max := uint64(mask)
+	c := uint64((v >> shift) & mask)
+
+	return uint8((c*255 + max/2) / max)
+}
@@ -0,0 +1,368 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnicodeText(t *testing.T) {
+	for _, s := range []string{"", "hello", "line 1\r\nline 2", "\u00E9\u20AC\U0001F600"} {
+		data := EncodeUnicodeText(s)
+
+		if len(data)%2 != 0 || !bytes.HasSuffix(data, []byte{0, 0}) {
+			t.Errorf("EncodeUnicodeText(%q) = %v, not NUL terminated UTF-16", s, data)
+		}
+
+		if got := DecodeUnicodeText(data); got != s {
+			t.Errorf("DecodeUnicodeText(EncodeUnicodeText(%q)) = %q", s, got)
+		}
+	}
+
+	// Data after the first NUL and an odd trailing byte are ignored.
+	if got := DecodeUnicodeText([]byte{'a', 0, 0, 0, 'b', 0}); got != "a" {
+		t.Errorf("DecodeUnicodeText after NUL = %q, want %q", got, "a")
+	}
+	if got := DecodeUnicodeText([]byte{'a', 0, 'b'}); got != "a" {
+		t.Errorf("DecodeUnicodeText with odd length = %q, want %q", got, "a")
+	}
+}
+
+// htmlOffsets returns the offsets in the header of "HTML Format" data.
+func htmlOffsets(t *testing.T, data []byte) (startHTML, endHTML, startFragment, endFragment int) {
+	header := string(data[:bytes.IndexByte(data, '<')])
+
+	for _, f := range []struct {
+		key string
+		v   *int
+	}{
+		{"StartHTML:", &startHTML},
+		{"EndHTML:", &endHTML},
+		{"StartFragment:", &startFragment},
+		{"EndFragment:", &endFragment},
+	} {
+		i := strings.Index(header, f.key)
+		if i < 0 {
+			t.Fatalf("header %q lacks %s", header, f.key)
+		}
+		fmt.Sscanf(header[i+len(f.key):], "%d", f.v)
+	}
+
+	return
+}
+
+func TestHTMLFormat(t *testing.T) {
+	for _, sourceURL := range []string{"", "https://example.com/\u00E4"} {
+		fragment := "<b>h\u00E9llo</b> \u20AC \U0001F600"
+
+		data := EncodeHTMLFormat(fragment, sourceURL)
+
+		if data[len(data)-1] != 0 {
+			t.Errorf("data is not NUL terminated")
+		}
+
+		// The offsets count bytes of UTF-8, not characters.
+		startHTML, endHTML, startFragment, endFragment := htmlOffsets(t, data)
+		if got := string(data[startFragment:endFragment]); got != fragment {
+			t.Errorf("fragment at offsets = %q, want %q", got, fragment)
+		}
+		if html := string(data[startHTML:endHTML]); !strings.HasPrefix(html, "<html>") || !strings.HasSuffix(html, "</html>") {
+			t.Errorf("HTML at offsets = %q", html)
+		}
+
+		clip, err := DecodeHTMLFormat(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &HTMLClip{
+			Version:   "0.9",
+			HTML:      string(data[startHTML:endHTML]),
+			Fragment:  fragment,
+			SourceURL: sourceURL,
+		}
+		if !reflect.DeepEqual(clip, want) {
+			t.Errorf("DecodeHTMLFormat = %+v, want %+v", clip, want)
+		}
+	}
+}
+
+func TestDecodeHTMLFormatInvalidOffsets(t *testing.T) {
+	fragment := "<i>\u00FC</i>"
+	valid := string(EncodeHTMLFormat(fragment, ""))
+	_, _, startFragment, endFragment := htmlOffsets(t, []byte(valid))
+
+	setOffset := func(data, key string, old, new int) string {
+		return strings.Replace(data, fmt.Sprintf("%s:%010d", key, old), fmt.Sprintf("%s:%010d", key, new), 1)
+	}
+
+	// Offsets that are out of range or reversed fall back to the comment
+	// markers.
+	for _, data := range []string{
+		setOffset(valid, "EndFragment", endFragment, len(valid)+100),
+		setOffset(valid, "StartFragment", startFragment, endFragment+1),
+		strings.Replace(valid, fmt.Sprintf("StartFragment:%010d", startFragment), "StartFragment:-000000001", 1),
+		strings.Replace(valid, fmt.Sprintf("EndFragment:%010d", endFragment), "EndFragment:xyz", 1),
+	} {
+		clip, err := DecodeHTMLFormat([]byte(data))
+		if err != nil {
+			t.Errorf("DecodeHTMLFormat(%q): %v", data, err)
+			continue
+		}
+		if clip.Fragment != fragment {
+			t.Errorf("DecodeHTMLFormat(%q).Fragment = %q, want %q", data, clip.Fragment, fragment)
+		}
+	}
+
+	noMarkers := strings.Replace(setOffset(valid, "EndFragment", endFragment, len(valid)+100), htmlStartFragment, "", 1)
+
+	for _, data := range []string{
+		"",
+		"<html></html>",
+		strings.Replace(valid, "Version:", "Versio:", 1),
+		noMarkers,
+	} {
+		if _, err := DecodeHTMLFormat([]byte(data)); err != ErrInvalidClipboardData {
+			t.Errorf("DecodeHTMLFormat(%q): err = %v, want ErrInvalidClipboardData", data, err)
+		}
+	}
+}
+
+func TestDropFiles(t *testing.T) {
+	paths := []string{`C:\a.txt`, `D:\\u00C4rger\\U0001F600.png`, `\\server\share\file`}
+
+	data := EncodeDropFiles(paths)
+
+	if off := binary.LittleEndian.Uint32(data[0:]); off != sizeofDROPFILES {
+		t.Errorf("pFiles = %d, want %d", off, sizeofDROPFILES)
+	}
+	if !bytes.HasSuffix(data, []byte{0, 0, 0, 0}) {
+		t.Errorf("list is not double NUL terminated")
+	}
+
+	got, err := DecodeDropFiles(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, paths) {
+		t.Errorf("DecodeDropFiles = %q, want %q", got, paths)
+	}
+
+	if got, err := DecodeDropFiles(EncodeDropFiles(nil)); err != nil || len(got) != 0 {
+		t.Errorf("DecodeDropFiles of an empty list = %q, %v", got, err)
+	}
+}
+
+func TestDecodeDropFilesANSI(t *testing.T) {
+	data := make([]byte, sizeofDROPFILES+4)
+	binary.LittleEndian.PutUint32(data, sizeofDROPFILES+4)
+	data = append(data, "C:\\x\x00D:\\\xE9\x00\x00"...)
+
+	got, err := DecodeDropFiles(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{`C:\x`, "D:\\\u00E9"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeDropFiles = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeDropFilesInvalid(t *testing.T) {
+	valid := EncodeDropFiles([]string{"a", "b"})
+
+	withOffset := func(offset uint32) []byte {
+		b := append([]byte(nil), valid...)
+		binary.LittleEndian.PutUint32(b, offset)
+		return b
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"truncated header", valid[:sizeofDROPFILES-1]},
+		{"header only", valid[:sizeofDROPFILES]},
+		{"unterminated list", valid[:len(valid)-2]},
+		{"unterminated name", valid[:len(valid)-4]},
+		{"pFiles inside header", withOffset(4)},
+		{"pFiles beyond data", withOffset(uint32(len(valid)) + 1)},
+		{"huge pFiles", withOffset(0xFFFFFFFF)},
+	}
+
+	for _, tt := range tests {
+		if _, err := DecodeDropFiles(tt.data); err != ErrInvalidClipboardData {
+			t.Errorf("%s: err = %v, want ErrInvalidClipboardData", tt.name, err)
+		}
+	}
+}
+
+func TestDIBRoundTrip(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	img.SetNRGBA(0, 0, color.NRGBA{0xFF, 0, 0, 0xFF})
+	img.SetNRGBA(1, 0, color.NRGBA{0, 0xFF, 0, 0x80})
+	img.SetNRGBA(2, 0, color.NRGBA{0x12, 0x34, 0x56, 0})
+	img.SetNRGBA(0, 1, color.NRGBA{1, 2, 3, 4})
+	img.SetNRGBA(2, 1, color.NRGBA{0xFF, 0xFF, 0xFF, 0xFF})
+
+	tests := []struct {
+		name       string
+		encode     func(image.Image) []byte
+		headerSize int
+	}{
+		{"EncodeDIB", EncodeDIB, sizeofBITMAPINFOHEADER},
+		{"EncodeDIBV5", EncodeDIBV5, sizeofBITMAPV5HEADER},
+	}
+
+	for _, tt := range tests {
+		data := tt.encode(img)
+
+		if len(data) != tt.headerSize+4*3*2 {
+			t.Errorf("%s: len = %d, want %d", tt.name, len(data), tt.headerSize+4*3*2)
+		}
+
+		got, err := DecodeDIB(data)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, img) {
+			t.Errorf("DecodeDIB(%s(img)) = %v, want %v", tt.name, got.Pix, img.Pix)
+		}
+	}
+}
+
+// dibHeader returns a BITMAPINFOHEADER.
+func dibHeader(width, height int32, bitCount uint16, compression, clrUsed uint32) []byte {
+	h := make([]byte, sizeofBITMAPINFOHEADER)
+	le := binary.LittleEndian
+
+	le.PutUint32(h[0:], sizeofBITMAPINFOHEADER)
+	le.PutUint32(h[4:], uint32(width))
+	le.PutUint32(h[8:], uint32(height))
+	le.PutUint16(h[12:], 1)
+	le.PutUint16(h[14:], bitCount)
+	le.PutUint32(h[16:], compression)
+	le.PutUint32(h[32:], clrUsed)
+
+	return h
+}
+
+func TestDecodeDIB(t *testing.T) {
+	red := color.NRGBA{0xFF, 0, 0, 0xFF}
+	blue := color.NRGBA{0, 0, 0xFF, 0xFF}
+	white := color.NRGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	black := color.NRGBA{0, 0, 0, 0xFF}
+
+	cat := func(parts ...[]byte) []byte {
+		return bytes.Join(parts, nil)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want [][]color.NRGBA // rows from the top
+	}{
+		{
+			// Bottom-up rows padded to 4 bytes.
+			"24 bpp",
+			cat(dibHeader(2, 2, 24, BI_RGB, 0),
+				[]byte{0xFF, 0, 0, 0xFF, 0xFF, 0xFF, 0, 0},
+				[]byte{0, 0, 0xFF, 0, 0, 0, 0, 0}),
+			[][]color.NRGBA{{red, black}, {blue, white}},
+		},
+		{
+			"1 bpp palette",
+			cat(dibHeader(3, 1, 1, BI_RGB, 2),
+				[]byte{0, 0, 0, 0, 0xFF, 0xFF, 0xFF, 0},
+				[]byte{0xA0, 0, 0, 0}),
+			[][]color.NRGBA{{white, black, white}},
+		},
+		{
+			// Top-down, without any alpha, so opaque.
+			"32 bpp top-down",
+			cat(dibHeader(1, -2, 32, BI_RGB, 0),
+				[]byte{0, 0, 0xFF, 0},
+				[]byte{0xFF, 0, 0, 0}),
+			[][]color.NRGBA{{red}, {blue}},
+		},
+		{
+			// RGB 565 with the masks after the header.
+			"16 bpp bitfields",
+			cat(dibHeader(2, 1, 16, BI_BITFIELDS, 0),
+				[]byte{0x00, 0xF8, 0, 0, 0xE0, 0x07, 0, 0, 0x1F, 0, 0, 0},
+				[]byte{0x00, 0xF8, 0x1F, 0x00}),
+			[][]color.NRGBA{{red, blue}},
+		},
+	}
+
+	for _, tt := range tests {
+		img, err := DecodeDIB(tt.data)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+
+		if b := img.Bounds(); b.Dy() != len(tt.want) || b.Dx() != len(tt.want[0]) {
+			t.Errorf("%s: bounds = %v", tt.name, b)
+			continue
+		}
+
+		for y, row := range tt.want {
+			for x, want := range row {
+				if got := img.NRGBAAt(x, y); got != want {
+					t.Errorf("%s: pixel %d, %d = %v, want %v", tt.name, x, y, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestDecodeDIBInvalid(t *testing.T) {
+	valid := append(dibHeader(2, 2, 32, BI_RGB, 0), make([]byte, 16)...)
+
+	withHeaderSize := func(size uint32) []byte {
+		b := append([]byte(nil), valid...)
+		binary.LittleEndian.PutUint32(b, size)
+		return b
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"empty", nil, ErrInvalidClipboardData},
+		{"short header", valid[:sizeofBITMAPINFOHEADER-1], ErrInvalidClipboardData},
+		{"short pixels", valid[:len(valid)-1], ErrInvalidClipboardData},
+		{"short V5 header", EncodeDIBV5(image.NewNRGBA(image.Rect(0, 0, 1, 1)))[:100], ErrInvalidClipboardData},
+		{"short palette", append(dibHeader(1, 1, 8, BI_RGB, 0), make([]byte, 4*255)...), ErrInvalidClipboardData},
+		{"short masks", append(dibHeader(1, 1, 16, BI_BITFIELDS, 0), make([]byte, 8)...), ErrInvalidClipboardData},
+		{"zero width", append(dibHeader(0, 1, 32, BI_RGB, 0), make([]byte, 4)...), ErrInvalidClipboardData},
+		{"zero height", append(dibHeader(1, 0, 32, BI_RGB, 0), make([]byte, 4)...), ErrInvalidClipboardData},
+		{"negative width", append(dibHeader(-1, 1, 32, BI_RGB, 0), make([]byte, 4)...), ErrInvalidClipboardData},
+
+		// Sizes whose pixel data does not fit in 32 bits.
+		{"huge 32 bpp", append(dibHeader(1<<16, 1<<16, 32, BI_RGB, 0), make([]byte, 64)...), ErrInvalidClipboardData},
+		{"huge 1 bpp", append(dibHeader(1<<16, -(1<<16), 1, BI_RGB, 0), make([]byte, 64)...), ErrInvalidClipboardData},
+		{"too wide", append(dibHeader(1<<16+1, 1, 1, BI_RGB, 0), make([]byte, 64)...), ErrInvalidClipboardData},
+
+		{"header size", withHeaderSize(64), ErrUnsupportedDIB},
+		{"bit count", append(dibHeader(1, 1, 2, BI_RGB, 0), make([]byte, 64)...), ErrUnsupportedDIB},
+		{"compression", append(dibHeader(1, 1, 8, 1, 0), make([]byte, 64)...), ErrUnsupportedDIB},
+	}
+
+	for _, tt := range tests {
+		if _, err := DecodeDIB(tt.data); err != tt.err {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+)
+
+// comObject is the header of COM objects implemented in Go. It must be the
+// first field of the implementing struct, so that a pointer to the struct is
+// the interface pointer handed out to COM.
+type comObject struct {
+	vtbl unsafe.Pointer
+	refs int32
+	iids []*IID
+}
+
+// liveCOMObjects keeps COM objects implemented in Go alive while they are
+// referenced, since the system stores their pointers where the garbage
+// collector cannot see them.
+var liveCOMObjects = struct {
+	sync.Mutex
+	m map[*comObject]struct{}
+}{
+	m: make(map[*comObject]struct{}),
+}
+
+// The IUnknown methods are shared by all COM objects implemented in Go.
+// Callbacks are created once, since their number is limited.
+var comUnknownVtbl struct {
+	once sync.Once
+	IUnknownVtbl
+}
+
+func unknownVtbl() IUnknownVtbl {
+	comUnknownVtbl.once.Do(func() {
+		comUnknownVtbl.QueryInterface = syscall.NewCallback(comQueryInterface)
+		comUnknownVtbl.AddRef = syscall.NewCallback(comAddRef)
+		comUnknownVtbl.Release = syscall.NewCallback(comRelease)
+	})
+
+	return comUnknownVtbl.IUnknownVtbl
+}
+
+// init sets up o with a single reference, owned by the caller, for the
+// vtable vtbl implementing iids.
+func (o *comObject) init(vtbl unsafe.Pointer, iids ...*IID) {
+	o.vtbl = vtbl
+	o.refs = 1
+	o.iids = iids
+
+	liveCOMObjects.Lock()
+	liveCOMObjects.m[o] = struct{}{}
+	liveCOMObjects.Unlock()
+}
+
+func comQueryInterface(this *comObject, riid *IID, ppvObject *unsafe.Pointer) uintptr {
+	if ppvObject == nil {
+		return E_POINTER
+	}
+
+	ok := EqualREFIID(riid, &IID_IUnknown)
+	for _, iid := range this.iids {
+		ok = ok || EqualREFIID(riid, iid)
+	}
+
+	if !ok {
+		*ppvObject = nil
+		return E_NOINTERFACE
+	}
+
+	comAddRef(this)
+	*ppvObject = unsafe.Pointer(this)
+
+	return S_OK
+}
+
+func comAddRef(this *comObject) uintptr {
+	return uintptr(atomic.AddInt32(&this.refs, 1))
+}
+
+func comRelease(this *comObject) uintptr {
+	refs := atomic.AddInt32(&this.refs, -1)
+	if refs == 0 {
+		liveCOMObjects.Lock()
+		delete(liveCOMObjects.m, this)
+		liveCOMObjects.Unlock()
+	}
+
+	return uintptr(refs)
+}
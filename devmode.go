@@ -0,0 +1,598 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"unicode/utf16"
+	"unsafe"
+)
+
+const (
+	CCHDEVICENAME = 32
+	CCHFORMNAME   = 32
+)
+
+// DEVMODE field selection bits
+const (
+	DM_ORIENTATION        = 0x00000001
+	DM_PAPERSIZE          = 0x00000002
+	DM_PAPERLENGTH        = 0x00000004
+	DM_PAPERWIDTH         = 0x00000008
+	DM_SCALE              = 0x00000010
+	DM_POSITION           = 0x00000020
+	DM_NUP                = 0x00000040
+	DM_DISPLAYORIENTATION = 0x00000080
+	DM_COPIES             = 0x00000100
+	DM_DEFAULTSOURCE      = 0x00000200
+	DM_PRINTQUALITY       = 0x00000400
+	DM_COLOR              = 0x00000800
+	DM_DUPLEX             = 0x00001000
+	DM_YRESOLUTION        = 0x00002000
+	DM_TTOPTION           = 0x00004000
+	DM_COLLATE            = 0x00008000
+	DM_FORMNAME           = 0x00010000
+	DM_LOGPIXELS          = 0x00020000
+	DM_BITSPERPEL         = 0x00040000
+	DM_PELSWIDTH          = 0x00080000
+	DM_PELSHEIGHT         = 0x00100000
+	DM_DISPLAYFLAGS       = 0x00200000
+	DM_DISPLAYFREQUENCY   = 0x00400000
+	DM_ICMMETHOD          = 0x00800000
+	DM_ICMINTENT          = 0x01000000
+	DM_MEDIATYPE          = 0x02000000
+	DM_DITHERTYPE         = 0x04000000
+	DM_PANNINGWIDTH       = 0x08000000
+	DM_PANNINGHEIGHT      = 0x10000000
+	DM_DISPLAYFIXEDOUTPUT = 0x20000000
+)
+
+type DEVMODE struct {
+	DmDeviceName       [CCHDEVICENAME]uint16
+	DmSpecVersion      uint16
+	DmDriverVersion    uint16
+	DmSize             uint16
+	DmDriverExtra      uint16
+	DmFields           uint32
+	DmOrientation      int16
+	DmPaperSize        int16
+	DmPaperLength      int16
+	DmPaperWidth       int16
+	DmScale            int16
+	DmCopies           int16
+	DmDefaultSource    int16
+	DmPrintQuality     int16
+	DmColor            int16
+	DmDuplex           int16
+	DmYResolution      int16
+	DmTTOption         int16
+	DmCollate          int16
+	DmFormName         [CCHFORMNAME]uint16
+	DmLogPixels        uint16
+	DmBitsPerPel       uint32
+	DmPelsWidth        uint32
+	DmPelsHeight       uint32
+	DmDisplayFlags     uint32
+	DmDisplayFrequency uint32
+	DmICMMethod        uint32
+	DmICMIntent        uint32
+	DmMediaType        uint32
+	DmDitherType       uint32
+	DmReserved1        uint32
+	DmReserved2        uint32
+	DmPanningWidth     uint32
+	DmPanningHeight    uint32
+}
+
+// Size of the public part of a DEVMODEW as written by current drivers.
+const DEVMODE_SIZE = 220
+
+// Display fixed output constants
+const (
+	DMDFO_DEFAULT = 0
+	DMDFO_STRETCH = 1
+	DMDFO_CENTER  = 2
+)
+
+// Display orientation constants
+const (
+	DMDO_DEFAULT = 0
+	DMDO_90      = 1
+	DMDO_180     = 2
+	DMDO_270     = 3
+)
+
+// N-up constants
+const (
+	DMNUP_SYSTEM = 1
+	DMNUP_ONEUP  = 2
+)
+
+var ErrInvalidDevMode = errors.New("invalid DEVMODE data")
+
+// byte offsets into DEVMODEW
+const (
+	devModeSizeOffset        = 68
+	devModeDriverExtraOffset = 70
+)
+
+func (dm *DEVMODE) has(field uint32) bool {
+	return dm.DmFields&field != 0
+}
+
+// ClearFields removes the given DM_* bits from DmFields, making the
+// corresponding members revert to the driver defaults.
+func (dm *DEVMODE) ClearFields(fields uint32) {
+	dm.DmFields &^= fields
+}
+
+// HasFields reports whether all of the given DM_* bits are set in DmFields.
+func (dm *DEVMODE) HasFields(fields uint32) bool {
+	return dm.DmFields&fields == fields
+}
+
+func (dm *DEVMODE) DeviceName() string {
+	return utf16ArrayToString(dm.DmDeviceName[:])
+}
+
+func (dm *DEVMODE) SetDeviceName(name string) {
+	stringToUTF16Array(dm.DmDeviceName[:], name)
+}
+
+func (dm *DEVMODE) Orientation() (int16, bool) {
+	return dm.DmOrientation, dm.has(DM_ORIENTATION)
+}
+
+func (dm *DEVMODE) SetOrientation(orientation int16) {
+	dm.DmOrientation = orientation
+	dm.DmFields |= DM_ORIENTATION
+}
+
+func (dm *DEVMODE) PaperSize() (int16, bool) {
+	return dm.DmPaperSize, dm.has(DM_PAPERSIZE)
+}
+
+func (dm *DEVMODE) SetPaperSize(paperSize int16) {
+	dm.DmPaperSize = paperSize
+	dm.DmFields |= DM_PAPERSIZE
+}
+
+// PaperLength returns the paper length in tenths of a millimeter.
+func (dm *DEVMODE) PaperLength() (int16, bool) {
+	return dm.DmPaperLength, dm.has(DM_PAPERLENGTH)
+}
+
+func (dm *DEVMODE) SetPaperLength(length int16) {
+	dm.DmPaperLength = length
+	dm.DmFields |= DM_PAPERLENGTH
+}
+
+// PaperWidth returns the paper width in tenths of a millimeter.
+func (dm *DEVMODE) PaperWidth() (int16, bool) {
+	return dm.DmPaperWidth, dm.has(DM_PAPERWIDTH)
+}
+
+func (dm *DEVMODE) SetPaperWidth(width int16) {
+	dm.DmPaperWidth = width
+	dm.DmFields |= DM_PAPERWIDTH
+}
+
+// Scale returns the scale factor in percent.
+func (dm *DEVMODE) Scale() (int16, bool) {
+	return dm.DmScale, dm.has(DM_SCALE)
+}
+
+func (dm *DEVMODE) SetScale(scale int16) {
+	dm.DmScale = scale
+	dm.DmFields |= DM_SCALE
+}
+
+func (dm *DEVMODE) Copies() (int16, bool) {
+	return dm.DmCopies, dm.has(DM_COPIES)
+}
+
+func (dm *DEVMODE) SetCopies(copies int16) {
+	dm.DmCopies = copies
+	dm.DmFields |= DM_COPIES
+}
+
+func (dm *DEVMODE) DefaultSource() (int16, bool) {
+	return dm.DmDefaultSource, dm.has(DM_DEFAULTSOURCE)
+}
+
+func (dm *DEVMODE) SetDefaultSource(source int16) {
+	dm.DmDefaultSource = source
+	dm.DmFields |= DM_DEFAULTSOURCE
+}
+
+// PrintQuality returns either one of the DMRES_* values or the x resolution
+// in dots per inch.
+func (dm *DEVMODE) PrintQuality() (int16, bool) {
+	return dm.DmPrintQuality, dm.has(DM_PRINTQUALITY)
+}
+
+func (dm *DEVMODE) SetPrintQuality(quality int16) {
+	dm.DmPrintQuality = quality
+	dm.DmFields |= DM_PRINTQUALITY
+}
+
+// Position returns the display position, which shares its storage with the
+// orientation and paper size members.
+func (dm *DEVMODE) Position() (POINT, bool) {
+	return POINT{
+		X: int32(makeLong16(dm.DmOrientation, dm.DmPaperSize)),
+		Y: int32(makeLong16(dm.DmPaperLength, dm.DmPaperWidth)),
+	}, dm.has(DM_POSITION)
+}
+
+func (dm *DEVMODE) SetPosition(pt POINT) {
+	dm.DmOrientation, dm.DmPaperSize = splitLong16(uint32(pt.X))
+	dm.DmPaperLength, dm.DmPaperWidth = splitLong16(uint32(pt.Y))
+	dm.DmFields |= DM_POSITION
+}
+
+// DisplayOrientation returns one of the DMDO_* values. It shares its storage
+// with the scale and copies members.
+func (dm *DEVMODE) DisplayOrientation() (uint32, bool) {
+	return makeLong16(dm.DmScale, dm.DmCopies), dm.has(DM_DISPLAYORIENTATION)
+}
+
+func (dm *DEVMODE) SetDisplayOrientation(orientation uint32) {
+	dm.DmScale, dm.DmCopies = splitLong16(orientation)
+	dm.DmFields |= DM_DISPLAYORIENTATION
+}
+
+// DisplayFixedOutput returns one of the DMDFO_* values. It shares its storage
+// with the default source and print quality members.
+func (dm *DEVMODE) DisplayFixedOutput() (uint32, bool) {
+	return makeLong16(dm.DmDefaultSource, dm.DmPrintQuality), dm.has(DM_DISPLAYFIXEDOUTPUT)
+}
+
+func (dm *DEVMODE) SetDisplayFixedOutput(output uint32) {
+	dm.DmDefaultSource, dm.DmPrintQuality = splitLong16(output)
+	dm.DmFields |= DM_DISPLAYFIXEDOUTPUT
+}
+
+func (dm *DEVMODE) Color() (int16, bool) {
+	return dm.DmColor, dm.has(DM_COLOR)
+}
+
+func (dm *DEVMODE) SetColor(color int16) {
+	dm.DmColor = color
+	dm.DmFields |= DM_COLOR
+}
+
+func (dm *DEVMODE) Duplex() (int16, bool) {
+	return dm.DmDuplex, dm.has(DM_DUPLEX)
+}
+
+func (dm *DEVMODE) SetDuplex(duplex int16) {
+	dm.DmDuplex = duplex
+	dm.DmFields |= DM_DUPLEX
+}
+
+func (dm *DEVMODE) YResolution() (int16, bool) {
+	return dm.DmYResolution, dm.has(DM_YRESOLUTION)
+}
+
+func (dm *DEVMODE) SetYResolution(resolution int16) {
+	dm.DmYResolution = resolution
+	dm.DmFields |= DM_YRESOLUTION
+}
+
+func (dm *DEVMODE) TTOption() (int16, bool) {
+	return dm.DmTTOption, dm.has(DM_TTOPTION)
+}
+
+func (dm *DEVMODE) SetTTOption(option int16) {
+	dm.DmTTOption = option
+	dm.DmFields |= DM_TTOPTION
+}
+
+func (dm *DEVMODE) Collate() (int16, bool) {
+	return dm.DmCollate, dm.has(DM_COLLATE)
+}
+
+func (dm *DEVMODE) SetCollate(collate int16) {
+	dm.DmCollate = collate
+	dm.DmFields |= DM_COLLATE
+}
+
+func (dm *DEVMODE) FormName() (string, bool) {
+	return utf16ArrayToString(dm.DmFormName[:]), dm.has(DM_FORMNAME)
+}
+
+func (dm *DEVMODE) SetFormName(name string) {
+	stringToUTF16Array(dm.DmFormName[:], name)
+	dm.DmFields |= DM_FORMNAME
+}
+
+func (dm *DEVMODE) LogPixels() (uint16, bool) {
+	return dm.DmLogPixels, dm.has(DM_LOGPIXELS)
+}
+
+func (dm *DEVMODE) SetLogPixels(logPixels uint16) {
+	dm.DmLogPixels = logPixels
+	dm.DmFields |= DM_LOGPIXELS
+}
+
+func (dm *DEVMODE) BitsPerPel() (uint32, bool) {
+	return dm.DmBitsPerPel, dm.has(DM_BITSPERPEL)
+}
+
+func (dm *DEVMODE) SetBitsPerPel(bits uint32) {
+	dm.DmBitsPerPel = bits
+	dm.DmFields |= DM_BITSPERPEL
+}
+
+func (dm *DEVMODE) PelsWidth() (uint32, bool) {
+	return dm.DmPelsWidth, dm.has(DM_PELSWIDTH)
+}
+
+func (dm *DEVMODE) SetPelsWidth(width uint32) {
+	dm.DmPelsWidth = width
+	dm.DmFields |= DM_PELSWIDTH
+}
+
+func (dm *DEVMODE) PelsHeight() (uint32, bool) {
+	return dm.DmPelsHeight, dm.has(DM_PELSHEIGHT)
+}
+
+func (dm *DEVMODE) SetPelsHeight(height uint32) {
+	dm.DmPelsHeight = height
+	dm.DmFields |= DM_PELSHEIGHT
+}
+
+func (dm *DEVMODE) DisplayFlags() (uint32, bool) {
+	return dm.DmDisplayFlags, dm.has(DM_DISPLAYFLAGS)
+}
+
+func (dm *DEVMODE) SetDisplayFlags(flags uint32) {
+	dm.DmDisplayFlags = flags
+	dm.DmFields |= DM_DISPLAYFLAGS
+}
+
+// Nup returns one of the DMNUP_* values. It shares its storage with the
+// display flags member.
+func (dm *DEVMODE) Nup() (uint32, bool) {
+	return dm.DmDisplayFlags, dm.has(DM_NUP)
+}
+
+func (dm *DEVMODE) SetNup(nup uint32) {
+	dm.DmDisplayFlags = nup
+	dm.DmFields |= DM_NUP
+}
+
+func (dm *DEVMODE) DisplayFrequency() (uint32, bool) {
+	return dm.DmDisplayFrequency, dm.has(DM_DISPLAYFREQUENCY)
+}
+
+func (dm *DEVMODE) SetDisplayFrequency(frequency uint32) {
+	dm.DmDisplayFrequency = frequency
+	dm.DmFields |= DM_DISPLAYFREQUENCY
+}
+
+func (dm *DEVMODE) ICMMethod() (uint32, bool) {
+	return dm.DmICMMethod, dm.has(DM_ICMMETHOD)
+}
+
+func (dm *DEVMODE) SetICMMethod(method uint32) {
+	dm.DmICMMethod = method
+	dm.DmFields |= DM_ICMMETHOD
+}
+
+func (dm *DEVMODE) ICMIntent() (uint32, bool) {
+	return dm.DmICMIntent, dm.has(DM_ICMINTENT)
+}
+
+func (dm *DEVMODE) SetICMIntent(intent uint32) {
+	dm.DmICMIntent = intent
+	dm.DmFields |= DM_ICMINTENT
+}
+
+func (dm *DEVMODE) MediaType() (uint32, bool) {
+	return dm.DmMediaType, dm.has(DM_MEDIATYPE)
+}
+
+func (dm *DEVMODE) SetMediaType(mediaType uint32) {
+	dm.DmMediaType = mediaType
+	dm.DmFields |= DM_MEDIATYPE
+}
+
+func (dm *DEVMODE) DitherType() (uint32, bool) {
+	return dm.DmDitherType, dm.has(DM_DITHERTYPE)
+}
+
+func (dm *DEVMODE) SetDitherType(ditherType uint32) {
+	dm.DmDitherType = ditherType
+	dm.DmFields |= DM_DITHERTYPE
+}
+
+func (dm *DEVMODE) PanningWidth() (uint32, bool) {
+	return dm.DmPanningWidth, dm.has(DM_PANNINGWIDTH)
+}
+
+func (dm *DEVMODE) SetPanningWidth(width uint32) {
+	dm.DmPanningWidth = width
+	dm.DmFields |= DM_PANNINGWIDTH
+}
+
+func (dm *DEVMODE) PanningHeight() (uint32, bool) {
+	return dm.DmPanningHeight, dm.has(DM_PANNINGHEIGHT)
+}
+
+func (dm *DEVMODE) SetPanningHeight(height uint32) {
+	dm.DmPanningHeight = height
+	dm.DmFields |= DM_PANNINGHEIGHT
+}
+
+// DriverExtra returns the driver private bytes that follow a DEVMODE in
+// memory. It must only be called on a DEVMODE that was obtained from
+// DocumentProperties or a buffer of at least DmSize+DmDriverExtra bytes.
+func (dm *DEVMODE) DriverExtra() []byte {
+	if dm.DmDriverExtra == 0 {
+		return nil
+	}
+
+	p := unsafe.Pointer(uintptr(unsafe.Pointer(dm)) + uintptr(dm.DmSize))
+	extra := make([]byte, dm.DmDriverExtra)
+	copy(extra, (*[1 << 16]byte)(p)[:dm.DmDriverExtra:dm.DmDriverExtra])
+
+	return extra
+}
+
+// MarshalDevMode encodes the public part of dm followed by extra exactly as
+// Windows lays out a DEVMODEW in memory. extra are the bytes that follow the
+// members of DEVMODE: if dm.DmSize is larger than DEVMODE_SIZE, as for
+// drivers with a newer DEVMODE, the first DmSize-DEVMODE_SIZE bytes are the
+// rest of the public part, and the driver private data follows. DmSize and
+// DmDriverExtra are set in the output to match what was written.
+func MarshalDevMode(dm *DEVMODE, extra []byte) ([]byte, error) {
+	size := int(dm.DmSize)
+	if size == 0 {
+		size = DEVMODE_SIZE
+	}
+	if size < devModeDriverExtraOffset+2 {
+		return nil, ErrInvalidDevMode
+	}
+
+	driverExtra := len(extra)
+	if size > DEVMODE_SIZE {
+		driverExtra -= size - DEVMODE_SIZE
+	}
+	if driverExtra < 0 || driverExtra > 0xFFFF {
+		return nil, ErrInvalidDevMode
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(DEVMODE_SIZE + len(extra))
+	if err := binary.Write(&buf, binary.LittleEndian, dm); err != nil {
+		return nil, err
+	}
+
+	b := buf.Bytes()
+	if size < DEVMODE_SIZE {
+		b = b[:size]
+	}
+	binary.LittleEndian.PutUint16(b[devModeSizeOffset:], uint16(size))
+	binary.LittleEndian.PutUint16(b[devModeDriverExtraOffset:], uint16(driverExtra))
+
+	return append(b, extra...), nil
+}
+
+// UnmarshalDevMode decodes data written by MarshalDevMode or copied from a
+// driver supplied DEVMODEW. Members beyond a shorter DmSize are left zero.
+// The bytes following the members of DEVMODE are returned as extra, as
+// MarshalDevMode takes them.
+func UnmarshalDevMode(data []byte) (dm *DEVMODE, extra []byte, err error) {
+	if len(data) < devModeDriverExtraOffset+2 {
+		return nil, nil, ErrInvalidDevMode
+	}
+
+	size := int(binary.LittleEndian.Uint16(data[devModeSizeOffset:]))
+	extraSize := int(binary.LittleEndian.Uint16(data[devModeDriverExtraOffset:]))
+	if size < devModeDriverExtraOffset+2 || len(data) < size+extraSize {
+		return nil, nil, ErrInvalidDevMode
+	}
+
+	public := size
+	if public > DEVMODE_SIZE {
+		public = DEVMODE_SIZE
+	}
+
+	b := make([]byte, DEVMODE_SIZE)
+	copy(b, data[:public])
+
+	dm = new(DEVMODE)
+	if err := binary.Read(bytes.NewReader(b), binary.LittleEndian, dm); err != nil {
+		return nil, nil, err
+	}
+
+	if n := size + extraSize - public; n > 0 {
+		extra = make([]byte, n)
+		copy(extra, data[public:size+extraSize])
+	}
+
+	return dm, extra, nil
+}
+
+// MarshalDevModeBase64 is like MarshalDevMode but returns standard base64,
+// suitable for storing in text configuration files.
+func MarshalDevModeBase64(dm *DEVMODE, extra []byte) (string, error) {
+	b, err := MarshalDevMode(dm, extra)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// UnmarshalDevModeBase64 decodes a string returned by MarshalDevModeBase64.
+func UnmarshalDevModeBase64(s string) (*DEVMODE, []byte, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return UnmarshalDevMode(b)
+}
+
+// DevModeBuffer returns a buffer holding dm followed by extra, as
+// MarshalDevMode lays them out, for passing to CreateDC, ResetDC or
+// DocumentProperties.
+func DevModeBuffer(dm *DEVMODE, extra []byte) ([]byte, error) {
+	b, err := MarshalDevMode(dm, extra)
+	if err != nil {
+		return nil, err
+	}
+
+	// The driver may read the full struct regardless of DmSize.
+	if size := int(binary.LittleEndian.Uint16(b[devModeSizeOffset:])); size < DEVMODE_SIZE {
+		buf := make([]byte, DEVMODE_SIZE+len(extra))
+		copy(buf, b[:size])
+		copy(buf[size:], extra)
+		b = buf
+	}
+
+	return b, nil
+}
+
+func makeLong16(lo, hi int16) uint32 {
+	return MAKELONG(uint16(lo), uint16(hi))
+}
+
+func splitLong16(v uint32) (int16, int16) {
+	return int16(LOWORD(v)), int16(HIWORD(v))
+}
+
+func utf16ArrayToString(a []uint16) string {
+	for i, c := range a {
+		if c == 0 {
+			return string(utf16.Decode(a[:i]))
+		}
+	}
+
+	return string(utf16.Decode(a))
+}
+
+// stringToUTF16Array copies s into a, truncating it if needed so that a
+// always ends up NUL terminated.
+func stringToUTF16Array(a []uint16, s string) {
+	u := utf16.Encode([]rune(s))
+	if len(u) > len(a)-1 {
+		u = u[:len(a)-1]
+		if n := len(u); n > 0 && utf16.IsSurrogate(rune(u[n-1])) && u[n-1] < 0xDC00 {
+			u = u[:n-1]
+		}
+	}
+
+	n := copy(a, u)
+	for i := n; i < len(a); i++ {
+		a[i] = 0
+	}
+}
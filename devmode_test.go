@@ -0,0 +1,260 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+func testDevMode() *DEVMODE {
+	dm := new(DEVMODE)
+	dm.SetDeviceName("Printer \U0001F5A8")
+	dm.DmSpecVersion = 0x0401
+	dm.DmDriverVersion = 0x0600
+	dm.SetOrientation(2) // DMORIENT_LANDSCAPE
+	dm.SetPaperSize(9)   // DMPAPER_A4
+	dm.SetCopies(3)
+	dm.SetFormName("A4")
+	dm.SetPelsWidth(1920)
+	dm.SetPanningHeight(7)
+
+	return dm
+}
+
+func TestDevModeLayout(t *testing.T) {
+	var dm DEVMODE
+
+	if n := binary.Size(&dm); n != DEVMODE_SIZE {
+		t.Errorf("binary.Size(DEVMODE) = %d, want %d", n, DEVMODE_SIZE)
+	}
+	if n := unsafe.Sizeof(dm); n != DEVMODE_SIZE {
+		t.Errorf("unsafe.Sizeof(DEVMODE) = %d, want %d", n, DEVMODE_SIZE)
+	}
+	if off := unsafe.Offsetof(dm.DmSize); off != devModeSizeOffset {
+		t.Errorf("offset of DmSize = %d, want %d", off, devModeSizeOffset)
+	}
+	if off := unsafe.Offsetof(dm.DmDriverExtra); off != devModeDriverExtraOffset {
+		t.Errorf("offset of DmDriverExtra = %d, want %d", off, devModeDriverExtraOffset)
+	}
+}
+
+func TestDevModeRoundTrip(t *testing.T) {
+	extra := []byte{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name     string
+		size     uint16
+		wantSize int
+	}{
+		{"unset", 0, DEVMODE_SIZE},
+		{"full", DEVMODE_SIZE, DEVMODE_SIZE},
+		{"short", 156, 156},
+	}
+
+	for _, tt := range tests {
+		dm := testDevMode()
+		dm.DmSize = tt.size
+
+		data, err := MarshalDevMode(dm, extra)
+		if err != nil {
+			t.Fatalf("%s: MarshalDevMode: %v", tt.name, err)
+		}
+
+		if len(data) != tt.wantSize+len(extra) {
+			t.Errorf("%s: len = %d, want %d", tt.name, len(data), tt.wantSize+len(extra))
+		}
+		if n := binary.LittleEndian.Uint16(data[devModeSizeOffset:]); int(n) != tt.wantSize {
+			t.Errorf("%s: DmSize = %d, want %d", tt.name, n, tt.wantSize)
+		}
+		if n := binary.LittleEndian.Uint16(data[devModeDriverExtraOffset:]); int(n) != len(extra) {
+			t.Errorf("%s: DmDriverExtra = %d, want %d", tt.name, n, len(extra))
+		}
+		if !bytes.Equal(data[tt.wantSize:], extra) {
+			t.Errorf("%s: driver extra = %v, want %v", tt.name, data[tt.wantSize:], extra)
+		}
+
+		got, gotExtra, err := UnmarshalDevMode(data)
+		if err != nil {
+			t.Fatalf("%s: UnmarshalDevMode: %v", tt.name, err)
+		}
+
+		want := *dm
+		want.DmSize = uint16(tt.wantSize)
+		want.DmDriverExtra = uint16(len(extra))
+		if tt.wantSize < DEVMODE_SIZE {
+			// Members beyond DmSize are not written.
+			b := make([]byte, DEVMODE_SIZE)
+			copy(b, data[:tt.wantSize])
+			want = DEVMODE{}
+			binary.Read(bytes.NewReader(b), binary.LittleEndian, &want)
+		}
+
+		if !reflect.DeepEqual(*got, want) {
+			t.Errorf("%s: UnmarshalDevMode = %+v, want %+v", tt.name, *got, want)
+		}
+		if !bytes.Equal(gotExtra, extra) {
+			t.Errorf("%s: driver extra = %v, want %v", tt.name, gotExtra, extra)
+		}
+	}
+}
+
+func mustMarshalDevMode(t *testing.T, dm *DEVMODE, extra []byte) []byte {
+	data, err := MarshalDevMode(dm, extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestDevModeLargerSizeRoundTrip(t *testing.T) {
+	// Newer drivers may report a DmSize beyond the members known here. The
+	// rest of their public part is carried in extra, before the driver
+	// private data.
+	public := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	driver := []byte{0xAA, 0xBB}
+
+	dm := testDevMode()
+	dm.DmSize = DEVMODE_SIZE + 16
+
+	data := mustMarshalDevMode(t, dm, append(append([]byte(nil), public...), driver...))
+
+	if len(data) != 236+2 {
+		t.Fatalf("len = %d, want %d", len(data), 236+2)
+	}
+	if n := binary.LittleEndian.Uint16(data[devModeSizeOffset:]); n != 236 {
+		t.Errorf("DmSize = %d, want 236", n)
+	}
+	if n := binary.LittleEndian.Uint16(data[devModeDriverExtraOffset:]); n != 2 {
+		t.Errorf("DmDriverExtra = %d, want 2", n)
+	}
+	if !bytes.Equal(data[DEVMODE_SIZE:236], public) || !bytes.Equal(data[236:], driver) {
+		t.Errorf("bytes after DEVMODE = %v", data[DEVMODE_SIZE:])
+	}
+
+	got, extra, err := UnmarshalDevMode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if copies, ok := got.Copies(); !ok || copies != 3 {
+		t.Errorf("Copies = %d, %v, want 3, true", copies, ok)
+	}
+	if got.DmSize != 236 || got.DmDriverExtra != 2 {
+		t.Errorf("DmSize, DmDriverExtra = %d, %d, want 236, 2", got.DmSize, got.DmDriverExtra)
+	}
+
+	again, err := MarshalDevMode(got, extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again, data) {
+		t.Errorf("round trip:\ngot  %v\nwant %v", again, data)
+	}
+
+	// The driver private data follows DmSize bytes.
+	b, err := DevModeBuffer(got, extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := (*DEVMODE)(unsafe.Pointer(&b[0])).DriverExtra(); !bytes.Equal(d, driver) {
+		t.Errorf("DriverExtra = %v, want %v", d, driver)
+	}
+
+	// extra must hold the rest of the public part.
+	if _, err := MarshalDevMode(dm, public[:15]); err != ErrInvalidDevMode {
+		t.Errorf("MarshalDevMode with a short public part: err = %v, want ErrInvalidDevMode", err)
+	}
+}
+
+func TestUnmarshalDevModeInvalid(t *testing.T) {
+	valid := mustMarshalDevMode(t, testDevMode(), []byte{1, 2, 3})
+
+	withSizes := func(size, extra uint16) []byte {
+		b := append([]byte(nil), valid...)
+		binary.LittleEndian.PutUint16(b[devModeSizeOffset:], size)
+		binary.LittleEndian.PutUint16(b[devModeDriverExtraOffset:], extra)
+		return b
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"truncated header", valid[:devModeDriverExtraOffset+1]},
+		{"truncated public part", valid[:DEVMODE_SIZE-1]},
+		{"truncated driver extra", valid[:len(valid)-1]},
+		{"DmSize too small", withSizes(devModeDriverExtraOffset+1, 0)},
+		{"DmSize beyond data", withSizes(DEVMODE_SIZE+4, 0)},
+		{"DmDriverExtra beyond data", withSizes(DEVMODE_SIZE, 4)},
+	}
+
+	for _, tt := range tests {
+		if _, _, err := UnmarshalDevMode(tt.data); err != ErrInvalidDevMode {
+			t.Errorf("%s: err = %v, want ErrInvalidDevMode", tt.name, err)
+		}
+	}
+
+	if _, err := MarshalDevMode(testDevMode(), make([]byte, 0x10000)); err != ErrInvalidDevMode {
+		t.Errorf("MarshalDevMode with too much driver extra data: err = %v, want ErrInvalidDevMode", err)
+	}
+}
+
+func TestDevModeBase64(t *testing.T) {
+	dm := testDevMode()
+	extra := []byte("driver")
+
+	s, err := MarshalDevModeBase64(dm, extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, gotExtra, err := UnmarshalDevModeBase64(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dm.DmSize = DEVMODE_SIZE
+	dm.DmDriverExtra = uint16(len(extra))
+	if !reflect.DeepEqual(got, dm) {
+		t.Errorf("UnmarshalDevModeBase64 = %+v, want %+v", got, dm)
+	}
+	if !bytes.Equal(gotExtra, extra) {
+		t.Errorf("driver extra = %q, want %q", gotExtra, extra)
+	}
+
+	if _, _, err := UnmarshalDevModeBase64("not base64!"); err == nil {
+		t.Error("UnmarshalDevModeBase64 accepted invalid base64")
+	}
+}
+
+func TestDevModeBuffer(t *testing.T) {
+	dm := testDevMode()
+	dm.DmSize = 156
+
+	b, err := DevModeBuffer(dm, []byte{9, 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(b) != DEVMODE_SIZE+2 {
+		t.Fatalf("len = %d, want %d", len(b), DEVMODE_SIZE+2)
+	}
+
+	// The driver extra data follows DmSize bytes, the rest is padding.
+	if b[156] != 9 || b[157] != 8 {
+		t.Errorf("driver extra at DmSize = %v", b[156:158])
+	}
+
+	p := (*DEVMODE)(unsafe.Pointer(&b[0]))
+	if extra := p.DriverExtra(); !bytes.Equal(extra, []byte{9, 8}) {
+		t.Errorf("DriverExtra = %v", extra)
+	}
+}
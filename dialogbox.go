@@ -0,0 +1,125 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"runtime"
+	"unsafe"
+)
+
+// NewDialogTemplate returns a template for a modal dialog with a caption,
+// a system menu and the shell font, like the DIALOGEX defaults of the
+// Visual Studio resource editor.
+func NewDialogTemplate(title string, width, height int16) *DialogTemplate {
+	return &DialogTemplate{
+		Style:  DS_SHELLFONT | DS_MODALFRAME | WS_POPUP | WS_CAPTION | WS_SYSMENU,
+		Width:  width,
+		Height: height,
+		Title:  title,
+		Font: &DialogFont{
+			PointSize: 8,
+			Weight:    FW_NORMAL,
+			Charset:   DEFAULT_CHARSET,
+			Typeface:  "MS Shell Dlg",
+		},
+	}
+}
+
+// AddControl appends a visible child control of class with the given style
+// and returns t.
+func (t *DialogTemplate) AddControl(class ResourceID, title string, id uint32, style uint32, x, y, width, height int16) *DialogTemplate {
+	return t.Add(DialogItem{
+		Style:  WS_CHILD | WS_VISIBLE | style,
+		X:      x,
+		Y:      y,
+		Width:  width,
+		Height: height,
+		ID:     id,
+		Class:  class,
+		Title:  ResourceName(title),
+	})
+}
+
+// AddButton appends a push button and returns t.
+func (t *DialogTemplate) AddButton(title string, id uint32, x, y, width, height int16) *DialogTemplate {
+	return t.AddControl(DialogButton, title, id, BS_PUSHBUTTON|WS_TABSTOP, x, y, width, height)
+}
+
+// AddDefButton appends a default push button and returns t.
+func (t *DialogTemplate) AddDefButton(title string, id uint32, x, y, width, height int16) *DialogTemplate {
+	return t.AddControl(DialogButton, title, id, BS_DEFPUSHBUTTON|WS_TABSTOP, x, y, width, height)
+}
+
+// AddLabel appends a left-aligned static text and returns t.
+func (t *DialogTemplate) AddLabel(title string, id uint32, x, y, width, height int16) *DialogTemplate {
+	return t.AddControl(DialogStatic, title, id, SS_LEFT, x, y, width, height)
+}
+
+// AddEdit appends a bordered edit control and returns t.
+func (t *DialogTemplate) AddEdit(id uint32, style uint32, x, y, width, height int16) *DialogTemplate {
+	return t.Add(DialogItem{
+		ExStyle: WS_EX_CLIENTEDGE,
+		Style:   WS_CHILD | WS_VISIBLE | WS_TABSTOP | ES_AUTOHSCROLL | style,
+		X:       x,
+		Y:       y,
+		Width:   width,
+		Height:  height,
+		ID:      id,
+		Class:   DialogEdit,
+	})
+}
+
+// encodeAligned encodes t into memory that is DWORD aligned, as
+// DialogBoxIndirectParam requires.
+func (t *DialogTemplate) encodeAligned() ([]uint32, error) {
+	b, err := t.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]uint32, (len(b)+3)/4)
+	copy((*[1 << 30]byte)(unsafe.Pointer(&buf[0]))[:len(b):len(b)], b)
+
+	return buf, nil
+}
+
+// DialogBox runs a modal dialog from t and returns the result passed to
+// EndDialog. proc is a DLGPROC created with syscall.NewCallback.
+func (t *DialogTemplate) DialogBox(parent HWND, proc, param uintptr) (int, error) {
+	buf, err := t.encodeAligned()
+	if err != nil {
+		return 0, err
+	}
+
+	ret := DialogBoxIndirectParam(GetModuleHandle(nil), unsafe.Pointer(&buf[0]), parent, proc, param)
+	runtime.KeepAlive(buf)
+
+	if ret == -1 {
+		return 0, errors.New("DialogBoxIndirectParam failed")
+	}
+
+	return ret, nil
+}
+
+// CreateDialog creates a modeless dialog from t. Add it to the MessageLoop
+// with AddDialog for keyboard navigation.
+func (t *DialogTemplate) CreateDialog(parent HWND, proc, param uintptr) (HWND, error) {
+	buf, err := t.encodeAligned()
+	if err != nil {
+		return 0, err
+	}
+
+	hwnd := CreateDialogIndirectParam(GetModuleHandle(nil), unsafe.Pointer(&buf[0]), parent, proc, param)
+	runtime.KeepAlive(buf)
+
+	if hwnd == 0 {
+		return 0, errors.New("CreateDialogIndirectParam failed")
+	}
+
+	return hwnd, nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf16"
+)
+
+// Dialog styles
+const (
+	DS_ABSALIGN      = 0x0001
+	DS_SYSMODAL      = 0x0002
+	DS_3DLOOK        = 0x0004
+	DS_FIXEDSYS      = 0x0008
+	DS_NOFAILCREATE  = 0x0010
+	DS_LOCALEDIT     = 0x0020
+	DS_SETFONT       = 0x0040
+	DS_MODALFRAME    = 0x0080
+	DS_NOIDLEMSG     = 0x0100
+	DS_SETFOREGROUND = 0x0200
+	DS_CONTROL       = 0x0400
+	DS_CENTER        = 0x0800
+	DS_CENTERMOUSE   = 0x1000
+	DS_CONTEXTHELP   = 0x2000
+	DS_USEPIXELS     = 0x8000
+	DS_SHELLFONT     = (DS_SETFONT | DS_FIXEDSYS)
+)
+
+// ResourceID is a string or a 16-bit ordinal, as used for the menu, class
+// and title fields of dialog templates. The zero value is empty.
+type ResourceID struct {
+	Ordinal uint16
+	Name    string
+}
+
+// ResourceOrdinal returns the ResourceID for the ordinal id.
+func ResourceOrdinal(id uint16) ResourceID {
+	return ResourceID{Ordinal: id}
+}
+
+// ResourceName returns the ResourceID for name.
+func ResourceName(name string) ResourceID {
+	return ResourceID{Name: name}
+}
+
+// Class atoms of the predefined control classes in dialog templates
+var (
+	DialogButton    = ResourceOrdinal(0x0080)
+	DialogEdit      = ResourceOrdinal(0x0081)
+	DialogStatic    = ResourceOrdinal(0x0082)
+	DialogListBox   = ResourceOrdinal(0x0083)
+	DialogScrollBar = ResourceOrdinal(0x0084)
+	DialogComboBox  = ResourceOrdinal(0x0085)
+)
+
+// DialogFont is the font of a dialog template. It sets DS_SETFONT.
+type DialogFont struct {
+	PointSize uint16
+	Weight    uint16
+	Italic    bool
+	Charset   byte
+	Typeface  string
+}
+
+// DialogItem describes a control of a DialogTemplate. Coordinates are in
+// dialog units.
+type DialogItem struct {
+	HelpID       uint32
+	ExStyle      uint32
+	Style        uint32
+	X, Y         int16
+	Width        int16
+	Height       int16
+	ID           uint32
+	Class        ResourceID
+	Title        ResourceID
+	CreationData []byte
+}
+
+// DialogTemplate describes a dialog the way a DIALOGEX resource does, so
+// that dialogs can be created without a resource compiler. Coordinates are
+// in dialog units.
+type DialogTemplate struct {
+	HelpID  uint32
+	ExStyle uint32
+	Style   uint32
+	X, Y    int16
+	Width   int16
+	Height  int16
+	Menu    ResourceID
+	Class   ResourceID
+	Title   string
+	Font    *DialogFont
+	Items   []DialogItem
+}
+
+// Add appends a control and returns t.
+func (t *DialogTemplate) Add(item DialogItem) *DialogTemplate {
+	t.Items = append(t.Items, item)
+	return t
+}
+
+// dialogEncoder writes the little-endian, WORD and DWORD aligned layout of
+// dialog templates.
+type dialogEncoder struct {
+	buf []byte
+}
+
+func (e *dialogEncoder) u16(v uint16) {
+	e.buf = append(e.buf, byte(v), byte(v>>8))
+}
+
+func (e *dialogEncoder) u32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *dialogEncoder) str(s string) {
+	for _, u := range utf16.Encode([]rune(s)) {
+		e.u16(u)
+	}
+	e.u16(0)
+}
+
+func (e *dialogEncoder) id(r ResourceID) {
+	if r.Ordinal != 0 {
+		e.u16(0xFFFF)
+		e.u16(r.Ordinal)
+		return
+	}
+
+	e.str(r.Name)
+}
+
+func (e *dialogEncoder) align4() {
+	for len(e.buf)%4 != 0 {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+// Encode returns t as a DLGTEMPLATEEX followed by its DLGITEMTEMPLATEEX
+// entries, laid out like rc.exe compiles a DIALOGEX resource.
+func (t *DialogTemplate) Encode() ([]byte, error) {
+	if len(t.Items) > 0xFFFF {
+		return nil, errors.New("too many dialog items")
+	}
+
+	style := t.Style
+	if t.Font != nil {
+		style |= DS_SETFONT
+	} else if style&DS_SETFONT != 0 {
+		return nil, errors.New("DS_SETFONT requires a font")
+	}
+
+	e := &dialogEncoder{buf: make([]byte, 0, 256+64*len(t.Items))}
+
+	e.u16(1)      // dlgVer
+	e.u16(0xFFFF) // signature
+	e.u32(t.HelpID)
+	e.u32(t.ExStyle)
+	e.u32(style)
+	e.u16(uint16(len(t.Items)))
+	e.u16(uint16(t.X))
+	e.u16(uint16(t.Y))
+	e.u16(uint16(t.Width))
+	e.u16(uint16(t.Height))
+	e.id(t.Menu)
+	e.id(t.Class)
+	e.str(t.Title)
+
+	if f := t.Font; f != nil {
+		e.u16(f.PointSize)
+		e.u16(f.Weight)
+		if f.Italic {
+			e.buf = append(e.buf, 1)
+		} else {
+			e.buf = append(e.buf, 0)
+		}
+		e.buf = append(e.buf, f.Charset)
+		e.str(f.Typeface)
+	}
+
+	for i := range t.Items {
+		it := &t.Items[i]
+
+		if len(it.CreationData) > 0xFFFF {
+			return nil, fmt.Errorf("dialog item %d: creation data too large", it.ID)
+		}
+		if it.Class == (ResourceID{}) {
+			return nil, fmt.Errorf("dialog item %d: missing class", it.ID)
+		}
+
+		e.align4()
+		e.u32(it.HelpID)
+		e.u32(it.ExStyle)
+		e.u32(it.Style)
+		e.u16(uint16(it.X))
+		e.u16(uint16(it.Y))
+		e.u16(uint16(it.Width))
+		e.u16(uint16(it.Height))
+		e.u32(it.ID)
+		e.id(it.Class)
+		e.id(it.Title)
+		e.u16(uint16(len(it.CreationData)))
+		e.buf = append(e.buf, it.CreationData...)
+	}
+
+	return e.buf, nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"testing"
+)
+
+// readDialogResources returns the data of the RT_DIALOG resources with
+// ordinal names in the .res file at path.
+func readDialogResources(t *testing.T, path string) map[uint16][]byte {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	le := binary.LittleEndian
+	dialogs := make(map[uint16][]byte)
+
+	for len(data) >= 8 {
+		dataSize := int(le.Uint32(data[0:]))
+		headerSize := int(le.Uint32(data[4:]))
+		if headerSize < 16 || len(data) < headerSize+dataSize {
+			t.Fatalf("%s: invalid resource header", path)
+		}
+
+		// Resources of type RT_DIALOG (5) have a header starting with the
+		// type and name ordinals.
+		if le.Uint16(data[8:]) == 0xFFFF && le.Uint16(data[10:]) == 5 && le.Uint16(data[12:]) == 0xFFFF {
+			dialogs[le.Uint16(data[14:])] = data[headerSize : headerSize+dataSize]
+		}
+
+		n := (headerSize + dataSize + 3) &^ 3
+		if n > len(data) {
+			break
+		}
+		data = data[n:]
+	}
+
+	return dialogs
+}
+
+// The templates of testdata/dialog.rc, whose comments name the styles.
+var testDialogTemplates = map[uint16]*DialogTemplate{
+	101: {
+		// DS_SETFONT is added for the font.
+		Style:  DS_MODALFRAME | 0x80000000 | 0x00C00000 | 0x00080000,
+		X:      10,
+		Y:      20,
+		Width:  200,
+		Height: 100,
+		Class:  ResourceOrdinal(300),
+		Title:  "Ab",
+		Font: &DialogFont{
+			PointSize: 9,
+			Weight:    700,
+			Italic:    true,
+			Charset:   0xCC,
+			Typeface:  "Segoe UI",
+		},
+		Items: []DialogItem{
+			{
+				Style: 0x50000000 | 0x00000001 | 0x00010000,
+				X:     140, Y: 80, Width: 50, Height: 14,
+				ID:    1,
+				Class: ResourceName("Button"),
+				Title: ResourceName("OK"),
+			},
+			{
+				HelpID:  77,
+				ExStyle: 0x00000200,
+				Style:   0x50000000 | 0x00000080 | 0x00010000,
+				X:       7, Y: 7, Width: 100, Height: 12,
+				ID:    1001,
+				Class: ResourceName("Edit"),
+			},
+			{
+				Style: 0x50000000,
+				X:     7, Y: 30, Width: 40, Height: 8,
+				ID:    0xFFFFFFFF,
+				Class: ResourceName("Static"),
+				Title: ResourceName("Name:"),
+			},
+			{
+				Style: 0x50010000,
+				X:     85, Y: 80, Width: 50, Height: 14,
+				ID:    2,
+				Class: DialogButton,
+				Title: ResourceName("Cancel"),
+			},
+			{
+				Style: 0x50000003,
+				X:     180, Y: 7, Width: 21, Height: 20,
+				ID:    8,
+				Class: DialogStatic,
+				Title: ResourceOrdinal(7),
+			},
+		},
+	},
+	102: {
+		HelpID:  55,
+		ExStyle: 0x00000080,
+		Style:   0x80000000 | 0x00800000 | 0x00C00000,
+		X:       -5,
+		Width:   120,
+		Height:  60,
+		Class:   ResourceName("MyDlgClass"),
+		Title:   "Odd",
+		Items: []DialogItem{
+			{
+				Style: 0x50000000,
+				X:     1, Y: 2, Width: 3, Height: 4,
+				ID:    5,
+				Class: ResourceName("msctls_trackbar32"),
+				Title: ResourceName("x"),
+			},
+			{
+				Style: 0x50000000,
+				X:     1, Y: 2, Width: 3, Height: 4,
+				ID:    6,
+				Class: ResourceName("Button"),
+			},
+		},
+	},
+	103: {
+		Style:  0x80000000,
+		Width:  50,
+		Height: 40,
+		Items: []DialogItem{
+			{
+				Style: 0x50000000,
+				X:     1, Y: 2, Width: 3, Height: 4,
+				ID:           9,
+				Class:        ResourceName("Button"),
+				Title:        ResourceName("d"),
+				CreationData: []byte{0x34, 0x12, 0x78, 0x56, 0xBC, 0x9A},
+			},
+			{
+				Style: 0x50000000,
+				X:     5, Y: 6, Width: 7, Height: 8,
+				ID:    10,
+				Class: ResourceName("Static"),
+				Title: ResourceName("e"),
+			},
+		},
+	},
+}
+
+func TestDialogTemplateEncode(t *testing.T) {
+	dialogs := readDialogResources(t, "testdata/dialog.res")
+
+	for name, tmpl := range testDialogTemplates {
+		want, ok := dialogs[name]
+		if !ok {
+			t.Fatalf("dialog %d not found in testdata/dialog.res", name)
+		}
+
+		got, err := tmpl.Encode()
+		if err != nil {
+			t.Fatalf("dialog %d: %v", name, err)
+		}
+
+		if !bytes.Equal(got, want) {
+			t.Errorf("dialog %d:\ngot  % x\nwant % x", name, got, want)
+		}
+	}
+}
+
+func TestDialogTemplateEncodeMenu(t *testing.T) {
+	// In dialog 102, the menu is an empty string at offset 26, after the
+	// fixed DLGTEMPLATEEX members. The class and title follow up to offset
+	// 58, and the first item starts DWORD aligned at offset 60.
+	res := readDialogResources(t, "testdata/dialog.res")[102]
+
+	tests := []struct {
+		menu ResourceID
+		data []byte
+	}{
+		{ResourceOrdinal(300), []byte{0xFF, 0xFF, 0x2C, 0x01}},
+		{ResourceName("M"), []byte{'M', 0, 0, 0}},
+		{ResourceName("MM"), []byte{'M', 0, 'M', 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		tmpl := *testDialogTemplates[102]
+		tmpl.Menu = tt.menu
+
+		got, err := tmpl.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := append(append([]byte(nil), res[:26]...), tt.data...)
+		want = append(want, res[28:58]...)
+		for len(want)%4 != 0 {
+			want = append(want, 0)
+		}
+		want = append(want, res[60:]...)
+
+		if !bytes.Equal(got, want) {
+			t.Errorf("menu %+v:\ngot  % x\nwant % x", tt.menu, got, want)
+		}
+	}
+}
+
+func TestDialogTemplateEncodeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl *DialogTemplate
+	}{
+		{"DS_SETFONT without font", &DialogTemplate{Style: DS_SETFONT}},
+		{"item without class", (&DialogTemplate{}).Add(DialogItem{ID: 1})},
+		{"creation data", (&DialogTemplate{}).Add(DialogItem{Class: DialogButton, CreationData: make([]byte, 0x10000)})},
+		{"too many items", &DialogTemplate{Items: make([]DialogItem, 0x10000)}},
+	}
+
+	for _, tt := range tests {
+		if _, err := tt.tmpl.Encode(); err == nil {
+			t.Errorf("%s: Encode succeeded", tt.name)
+		}
+	}
+}
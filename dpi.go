@@ -0,0 +1,92 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+const USER_DEFAULT_SCREEN_DPI = 96
+
+// DPI is a resolution in dots per inch. Its methods scale values given at
+// USER_DEFAULT_SCREEN_DPI (96), i.e. at 100%, to the resolution.
+type DPI int32
+
+// dpiMulDiv computes a*b/c rounded half away from zero, like MulDiv. As
+// MulDiv, it returns -1 if c is 0 or the result overflows an int32.
+func dpiMulDiv(a, b, c int32) int32 {
+	if c == 0 {
+		return -1
+	}
+
+	n := int64(a) * int64(b)
+	d := int64(c)
+
+	neg := (n < 0) != (d < 0)
+	if n < 0 {
+		n = -n
+	}
+	if d < 0 {
+		d = -d
+	}
+
+	q := (n + d/2) / d
+	if neg {
+		q = -q
+	}
+
+	if q != int64(int32(q)) {
+		return -1
+	}
+
+	return int32(q)
+}
+
+// Factor returns the scale factor of d, e.g. 1.5 for 144 DPI.
+func (d DPI) Factor() float64 {
+	return float64(d) / USER_DEFAULT_SCREEN_DPI
+}
+
+// Scale scales v from 96 DPI to d.
+func (d DPI) Scale(v int32) int32 {
+	return dpiMulDiv(v, int32(d), USER_DEFAULT_SCREEN_DPI)
+}
+
+// Unscale scales v from d to 96 DPI.
+func (d DPI) Unscale(v int32) int32 {
+	return dpiMulDiv(v, USER_DEFAULT_SCREEN_DPI, int32(d))
+}
+
+// ScaleFrom scales v, which is valid at DPI from, to d.
+func (d DPI) ScaleFrom(v int32, from DPI) int32 {
+	return dpiMulDiv(v, int32(d), int32(from))
+}
+
+// ScalePoint scales p from 96 DPI to d.
+func (d DPI) ScalePoint(p POINT) POINT {
+	return POINT{d.Scale(p.X), d.Scale(p.Y)}
+}
+
+// ScaleSize scales s from 96 DPI to d.
+func (d DPI) ScaleSize(s SIZE) SIZE {
+	return SIZE{d.Scale(s.CX), d.Scale(s.CY)}
+}
+
+// ScaleRect scales r from 96 DPI to d. Edges are scaled independently, so
+// adjacent rectangles stay adjacent.
+func (d DPI) ScaleRect(r RECT) RECT {
+	return RECT{d.Scale(r.Left), d.Scale(r.Top), d.Scale(r.Right), d.Scale(r.Bottom)}
+}
+
+// ScaleRectFrom scales r, which is valid at DPI from, to d.
+func (d DPI) ScaleRectFrom(r RECT, from DPI) RECT {
+	return RECT{
+		d.ScaleFrom(r.Left, from),
+		d.ScaleFrom(r.Top, from),
+		d.ScaleFrom(r.Right, from),
+		d.ScaleFrom(r.Bottom, from),
+	}
+}
+
+// FontHeight returns the LOGFONT height of a font of size points at d.
+func (d DPI) FontHeight(points int32) int32 {
+	return -dpiMulDiv(points, int32(d), 72)
+}
@@ -0,0 +1,124 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"testing"
+)
+
+func TestDPIMulDiv(t *testing.T) {
+	// Results of MulDiv, which rounds half away from zero.
+	tests := []struct {
+		a, b, c, want int32
+	}{
+		{6, 7, 8, 5},
+		{-6, 7, 8, -5},
+		{6, -7, 8, -5},
+		{6, 7, -8, -5},
+		{-6, -7, 8, 5},
+		{-6, -7, -8, -5},
+		{1, 1, 2, 1},
+		{-1, 1, 2, -1},
+		{1, -1, 2, -1},
+		{1, 1, -2, -1},
+		{3, 1, 2, 2},
+		{-3, 1, 2, -2},
+		{1, 1, 3, 0},
+		{2, 1, 3, 1},
+		{-2, 1, 3, -1},
+		{0, 5, 7, 0},
+		{0x7FFFFFFF, 0x7FFFFFFF, 0x7FFFFFFF, 0x7FFFFFFF},
+		{-0x7FFFFFFF - 1, 1, 1, -0x7FFFFFFF - 1},
+
+		// Division by zero and overflow
+		{3, 4, 0, -1},
+		{0x7FFFFFFF, 2, 1, -1},
+		{-0x7FFFFFFF - 1, 1, -1, -1},
+		{-0x7FFFFFFF - 1, 2, 1, -1},
+	}
+
+	for _, tt := range tests {
+		if got := dpiMulDiv(tt.a, tt.b, tt.c); got != tt.want {
+			t.Errorf("dpiMulDiv(%d, %d, %d) = %d, want %d", tt.a, tt.b, tt.c, got, tt.want)
+		}
+	}
+}
+
+func TestDPIScale(t *testing.T) {
+	tests := []struct {
+		dpi              DPI
+		v                int32
+		scaled, unscaled int32
+	}{
+		{96, 10, 10, 10},    // 10, 10
+		{120, 10, 13, 8},    // 12.5, 8
+		{120, -10, -13, -8}, // -12.5, -8
+		{144, 3, 5, 2},      // 4.5, 2
+		{144, -3, -5, -2},   // -4.5, -2
+		{144, 1, 2, 1},      // 1.5, 0.667
+		{168, 7, 12, 4},     // 12.25, 4
+		{192, -1, -2, -1},   // -2, -0.5
+		{240, 1, 3, 0},      // 2.5, 0.4
+	}
+
+	for _, tt := range tests {
+		if got := tt.dpi.Scale(tt.v); got != tt.scaled {
+			t.Errorf("DPI(%d).Scale(%d) = %d, want %d", tt.dpi, tt.v, got, tt.scaled)
+		}
+		if got := tt.dpi.Unscale(tt.v); got != tt.unscaled {
+			t.Errorf("DPI(%d).Unscale(%d) = %d, want %d", tt.dpi, tt.v, got, tt.unscaled)
+		}
+		if got := tt.dpi.ScaleFrom(tt.v, USER_DEFAULT_SCREEN_DPI); got != tt.scaled {
+			t.Errorf("DPI(%d).ScaleFrom(%d, 96) = %d, want %d", tt.dpi, tt.v, got, tt.scaled)
+		}
+	}
+
+	if got := DPI(144).ScaleFrom(-5, 120); got != -6 {
+		t.Errorf("DPI(144).ScaleFrom(-5, 120) = %d, want -6", got)
+	}
+	// The product does not overflow before the division.
+	if got := DPI(144).Unscale(0x7FFFFFFF); got != 1431655765 {
+		t.Errorf("DPI(144).Unscale(0x7FFFFFFF) = %d, want 1431655765", got)
+	}
+	if got := DPI(0).Unscale(10); got != -1 {
+		t.Errorf("DPI(0).Unscale(10) = %d, want -1", got)
+	}
+	if f := DPI(144).Factor(); f != 1.5 {
+		t.Errorf("DPI(144).Factor() = %g, want 1.5", f)
+	}
+}
+
+func TestDPIScaleRect(t *testing.T) {
+	// Adjacent rectangles stay adjacent, as the edges are scaled on their
+	// own.
+	a, b := RECT{-3, 0, 3, 5}, RECT{3, 0, 7, 5}
+	sa, sb := DPI(120).ScaleRect(a), DPI(120).ScaleRect(b)
+
+	if want := (RECT{-4, 0, 4, 6}); sa != want {
+		t.Errorf("ScaleRect(%v) = %v, want %v", a, sa, want)
+	}
+	if sa.Right != sb.Left {
+		t.Errorf("ScaleRect: %v and %v are not adjacent", sa, sb)
+	}
+
+	if got, want := DPI(96).ScaleRectFrom(sa, 120), (RECT{-3, 0, 3, 5}); got != want {
+		t.Errorf("ScaleRectFrom(%v, 120) = %v, want %v", sa, got, want)
+	}
+
+	if got := DPI(144).ScalePoint(POINT{-1, 2}); got != (POINT{-2, 3}) {
+		t.Errorf("ScalePoint = %v, want {-2 3}", got)
+	}
+	if got := DPI(144).ScaleSize(SIZE{5, 6}); got != (SIZE{8, 9}) {
+		t.Errorf("ScaleSize = %v, want {8 9}", got)
+	}
+
+	// 9 points at 96 DPI are 12 pixels, at 120 DPI 15.
+	if h := DPI(96).FontHeight(9); h != -12 {
+		t.Errorf("DPI(96).FontHeight(9) = %d, want -12", h)
+	}
+	if h := DPI(120).FontHeight(9); h != -15 {
+		t.Errorf("DPI(120).FontHeight(9) = %d, want -15", h)
+	}
+}
@@ -0,0 +1,593 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// DroppedFiles returns the paths of the files of a WM_DROPFILES message.
+func DroppedFiles(hDrop HDROP) []string {
+	n := DragQueryFile(hDrop, 0xFFFFFFFF, nil, 0)
+
+	files := make([]string, 0, n)
+	for i := uint(0); i < n; i++ {
+		size := DragQueryFile(hDrop, i, nil, 0)
+
+		buf := make([]uint16, size+1)
+		DragQueryFile(hDrop, i, &buf[0], size+1)
+
+		files = append(files, syscall.UTF16ToString(buf))
+	}
+
+	return files
+}
+
+// DropPoint returns where the files of a WM_DROPFILES message were dropped,
+// in client coordinates of the window, and whether that is in its client
+// area.
+func DropPoint(hDrop HDROP) (POINT, bool) {
+	var pt POINT
+	inClient := DragQueryPoint(hDrop, &pt)
+
+	return pt, inClient
+}
+
+// OnDropFiles makes w accept files dropped from the shell and calls f with
+// their paths and the drop point in client coordinates. This is the simple
+// WM_DROPFILES protocol; use RegisterDropTarget for other data and for drop
+// feedback.
+func (w *Window) OnDropFiles(f func(files []string, pt POINT)) {
+	if w.hwnd != 0 {
+		DragAcceptFiles(w.hwnd, true)
+	}
+
+	w.Handle(WM_CREATE, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		DragAcceptFiles(w.hwnd, true)
+		return 0, false
+	})
+
+	w.Handle(WM_DROPFILES, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		hDrop := CrackDropFilesMsg(wParam, lParam).Drop
+
+		files := DroppedFiles(hDrop)
+		pt, _ := DropPoint(hDrop)
+		DragFinish(hDrop)
+
+		f(files, pt)
+
+		return 0, true
+	})
+}
+
+// dataFormatEtc returns the FORMATETC for format in global memory, the only
+// storage medium supported by DataObject and DataSource.
+func dataFormatEtc(format uint32) FORMATETC {
+	return FORMATETC{
+		CfFormat: uint16(format),
+		DwAspect: DVASPECT_CONTENT,
+		Lindex:   -1,
+		Tymed:    TYMED_HGLOBAL,
+	}
+}
+
+// DataObject gives access to the data of an OLE drag and drop operation,
+// like Clipboard does for the clipboard. Formats are clipboard formats.
+type DataObject struct {
+	obj *IDataObject
+}
+
+// IDataObject returns the underlying COM object.
+func (d *DataObject) IDataObject() *IDataObject {
+	return d.obj
+}
+
+// Formats returns the formats available in global memory.
+func (d *DataObject) Formats() []uint32 {
+	var enum *IEnumFORMATETC
+	if FAILED(d.obj.EnumFormatEtc(DATADIR_GET, &enum)) || enum == nil {
+		return nil
+	}
+	defer enum.Release()
+
+	var formats []uint32
+	for {
+		var fe FORMATETC
+		var n uint32
+		if enum.Next(1, &fe, &n) != S_OK || n != 1 {
+			break
+		}
+
+		if fe.Tymed&TYMED_HGLOBAL != 0 {
+			formats = append(formats, uint32(fe.CfFormat))
+		}
+	}
+
+	return formats
+}
+
+// Has reports whether format is available.
+func (d *DataObject) Has(format uint32) bool {
+	fe := dataFormatEtc(format)
+
+	return d.obj.QueryGetData(&fe) == S_OK
+}
+
+// Get returns a copy of the data in format.
+func (d *DataObject) Get(format uint32) ([]byte, error) {
+	fe := dataFormatEtc(format)
+
+	var medium STGMEDIUM
+	if hr := d.obj.GetData(&fe, &medium); FAILED(hr) {
+		if uint32(hr) == DV_E_FORMATETC {
+			return nil, ErrClipboardFormatUnavailable
+		}
+
+		return nil, fmt.Errorf("IDataObject.GetData failed: 0x%08X", uint32(hr))
+	}
+	defer ReleaseStgMedium(&medium)
+
+	if medium.Tymed != TYMED_HGLOBAL {
+		return nil, errors.New("data is not in global memory")
+	}
+
+	return globalBytes(HGLOBAL(medium.Data))
+}
+
+// Text returns the CF_UNICODETEXT data.
+func (d *DataObject) Text() (string, error) {
+	data, err := d.Get(CF_UNICODETEXT)
+	if err != nil {
+		return "", err
+	}
+
+	return DecodeUnicodeText(data), nil
+}
+
+// HTML returns the "HTML Format" data.
+func (d *DataObject) HTML() (*HTMLClip, error) {
+	format, err := ClipboardFormat("HTML Format")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := d.Get(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeHTMLFormat(data)
+}
+
+// Files returns the file list of the CF_HDROP data.
+func (d *DataObject) Files() ([]string, error) {
+	data, err := d.Get(CF_HDROP)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeDropFiles(data)
+}
+
+// Image returns the image, preferring CF_DIBV5 for its alpha channel.
+func (d *DataObject) Image() (*image.NRGBA, error) {
+	format := uint32(CF_DIBV5)
+	if !d.Has(format) {
+		format = CF_DIB
+	}
+
+	data, err := d.Get(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeDIB(data)
+}
+
+// DefaultDropEffect returns the effect a drop with the MK_* flags keyState
+// has by convention, restricted to the DROPEFFECT_* flags allowed: Ctrl
+// copies, Shift moves, Ctrl+Shift links, and no modifier moves if the
+// source allows it.
+func DefaultDropEffect(keyState, allowed uint32) uint32 {
+	var want uint32
+	switch keyState & (MK_CONTROL | MK_SHIFT) {
+	case MK_CONTROL | MK_SHIFT:
+		want = DROPEFFECT_LINK
+
+	case MK_CONTROL:
+		want = DROPEFFECT_COPY
+
+	case MK_SHIFT:
+		want = DROPEFFECT_MOVE
+
+	default:
+		for _, e := range []uint32{DROPEFFECT_MOVE, DROPEFFECT_COPY, DROPEFFECT_LINK} {
+			if allowed&e != 0 {
+				return e
+			}
+		}
+	}
+
+	return want & allowed
+}
+
+// DragEvent describes a drag and drop operation over a DropTarget.
+type DragEvent struct {
+	Data     *DataObject // valid until the drag leaves or is dropped
+	KeyState uint32      // MK_* flags of the mouse buttons and modifiers
+	Point    POINT       // cursor position in screen coordinates
+	Allowed  uint32      // DROPEFFECT_* flags allowed by the source
+}
+
+// DropTarget handles OLE drag and drop over a window. Enter, Over and Drop
+// return the effect of dropping, one of the DROPEFFECT_* flags in
+// e.Allowed, or DROPEFFECT_NONE to refuse the data. DROPEFFECT_SCROLL may be
+// added while the target scrolls.
+//
+// If Enter is nil, DefaultDropEffect is used. If Over is nil, the result of
+// Enter is kept. If Drop is nil, nothing is dropped.
+type DropTarget struct {
+	Enter func(e *DragEvent) uint32
+	Over  func(e *DragEvent) uint32
+	Leave func()
+	Drop  func(e *DragEvent) uint32
+}
+
+// dropTarget implements IDropTarget for a DropTarget.
+type dropTarget struct {
+	comObject
+	t      *DropTarget
+	data   *DataObject
+	effect uint32
+}
+
+// dataObject implements IDataObject for a DataSource.
+type dataObject struct {
+	comObject
+	src *DataSource
+}
+
+// dropSource implements IDropSource with the standard behavior.
+type dropSource struct {
+	comObject
+}
+
+var dragDropVtbls struct {
+	once       sync.Once
+	dropTarget IDropTargetVtbl
+	dataObject IDataObjectVtbl
+	dropSource IDropSourceVtbl
+}
+
+func initDragDropVtbls() {
+	v := &dragDropVtbls
+
+	v.once.Do(func() {
+		v.dropTarget = IDropTargetVtbl{
+			IUnknownVtbl: unknownVtbl(),
+			DragEnter:    syscall.NewCallback(dropTargetDragEnter),
+			DragOver:     syscall.NewCallback(dropTargetDragOver),
+			DragLeave:    syscall.NewCallback(dropTargetDragLeave),
+			Drop:         syscall.NewCallback(dropTargetDrop),
+		}
+
+		v.dataObject = IDataObjectVtbl{
+			IUnknownVtbl:          unknownVtbl(),
+			GetData:               syscall.NewCallback(dataObjectGetData),
+			GetDataHere:           syscall.NewCallback(dataObjectGetDataHere),
+			QueryGetData:          syscall.NewCallback(dataObjectQueryGetData),
+			GetCanonicalFormatEtc: syscall.NewCallback(dataObjectGetCanonicalFormatEtc),
+			SetData:               syscall.NewCallback(dataObjectSetData),
+			EnumFormatEtc:         syscall.NewCallback(dataObjectEnumFormatEtc),
+			DAdvise:               syscall.NewCallback(dataObjectDAdvise),
+			DUnadvise:             syscall.NewCallback(dataObjectDUnadvise),
+			EnumDAdvise:           syscall.NewCallback(dataObjectEnumDAdvise),
+		}
+
+		v.dropSource = IDropSourceVtbl{
+			IUnknownVtbl:      unknownVtbl(),
+			QueryContinueDrag: syscall.NewCallback(dropSourceQueryContinueDrag),
+			GiveFeedback:      syscall.NewCallback(dropSourceGiveFeedback),
+		}
+	})
+}
+
+// RegisterDropTarget makes hwnd accept OLE drag and drop, handled by t.
+// OLE must have been initialized on the thread with OleInitialize. Call
+// RevokeDropTarget before hwnd is destroyed.
+func RegisterDropTarget(hwnd HWND, t *DropTarget) error {
+	initDragDropVtbls()
+
+	dt := &dropTarget{t: t}
+	dt.init(unsafe.Pointer(&dragDropVtbls.dropTarget), &IID_IDropTarget)
+
+	// RegisterDragDrop takes its own reference.
+	hr := RegisterDragDrop(hwnd, (*IDropTarget)(unsafe.Pointer(dt)))
+	comRelease(&dt.comObject)
+
+	if FAILED(hr) {
+		return fmt.Errorf("RegisterDragDrop failed: 0x%08X", uint32(hr))
+	}
+
+	return nil
+}
+
+// RevokeDropTarget undoes RegisterDropTarget.
+func RevokeDropTarget(hwnd HWND) error {
+	if hr := RevokeDragDrop(hwnd); FAILED(hr) {
+		return fmt.Errorf("RevokeDragDrop failed: 0x%08X", uint32(hr))
+	}
+
+	return nil
+}
+
+func (dt *dropTarget) releaseData() {
+	if dt.data != nil {
+		dt.data.obj.Release()
+		dt.data = nil
+	}
+}
+
+func (dt *dropTarget) dragEnter(pDataObj *IDataObject, grfKeyState uint32, pt POINT, pdwEffect *uint32) uintptr {
+	dt.releaseData()
+
+	pDataObj.AddRef()
+	dt.data = &DataObject{pDataObj}
+
+	e := &DragEvent{dt.data, grfKeyState, pt, *pdwEffect}
+
+	effect := DefaultDropEffect(grfKeyState, e.Allowed)
+	if dt.t.Enter != nil {
+		effect = dt.t.Enter(e)
+	}
+	dt.effect = effect & (e.Allowed | DROPEFFECT_SCROLL)
+
+	*pdwEffect = dt.effect
+
+	return S_OK
+}
+
+func (dt *dropTarget) dragOver(grfKeyState uint32, pt POINT, pdwEffect *uint32) uintptr {
+	if dt.data == nil {
+		*pdwEffect = DROPEFFECT_NONE
+		return S_OK
+	}
+
+	e := &DragEvent{dt.data, grfKeyState, pt, *pdwEffect}
+
+	effect := dt.effect
+	switch {
+	case dt.t.Over != nil:
+		effect = dt.t.Over(e)
+
+	case dt.t.Enter == nil:
+		effect = DefaultDropEffect(grfKeyState, e.Allowed)
+	}
+	dt.effect = effect & (e.Allowed | DROPEFFECT_SCROLL)
+
+	*pdwEffect = dt.effect
+
+	return S_OK
+}
+
+func (dt *dropTarget) drop(pDataObj *IDataObject, grfKeyState uint32, pt POINT, pdwEffect *uint32) uintptr {
+	defer dt.releaseData()
+
+	e := &DragEvent{&DataObject{pDataObj}, grfKeyState, pt, *pdwEffect}
+
+	var effect uint32 = DROPEFFECT_NONE
+	if dt.t.Drop != nil {
+		effect = dt.t.Drop(e)
+	}
+
+	*pdwEffect = effect & e.Allowed
+
+	return S_OK
+}
+
+func dropTargetDragLeave(this *dropTarget) uintptr {
+	if this.t.Leave != nil {
+		this.t.Leave()
+	}
+
+	this.releaseData()
+
+	return S_OK
+}
+
+// DataSource holds data in several formats for a drag and drop operation
+// started with DoDragDrop. Formats are clipboard formats.
+type DataSource struct {
+	formats []uint32
+	data    map[uint32][]byte
+}
+
+// NewDataSource returns an empty DataSource.
+func NewDataSource() *DataSource {
+	return &DataSource{data: make(map[uint32][]byte)}
+}
+
+// Set offers data in format. Formats are offered in the order they were
+// first set, which should be from the richest to the plainest.
+func (s *DataSource) Set(format uint32, data []byte) {
+	if _, ok := s.data[format]; !ok {
+		s.formats = append(s.formats, format)
+	}
+
+	s.data[format] = data
+}
+
+// SetText offers s as CF_UNICODETEXT.
+func (s *DataSource) SetText(text string) {
+	s.Set(CF_UNICODETEXT, EncodeUnicodeText(text))
+}
+
+// SetHTML offers the HTML fragment as "HTML Format".
+func (s *DataSource) SetHTML(fragment, sourceURL string) error {
+	format, err := ClipboardFormat("HTML Format")
+	if err != nil {
+		return err
+	}
+
+	s.Set(format, EncodeHTMLFormat(fragment, sourceURL))
+
+	return nil
+}
+
+// SetFiles offers paths as CF_HDROP.
+func (s *DataSource) SetFiles(paths []string) {
+	s.Set(CF_HDROP, EncodeDropFiles(paths))
+}
+
+// SetImage offers img as CF_DIBV5 and, for targets that do not read it,
+// as CF_DIB.
+func (s *DataSource) SetImage(img image.Image) {
+	s.Set(CF_DIBV5, EncodeDIBV5(img))
+	s.Set(CF_DIB, EncodeDIB(img))
+}
+
+// DoDragDrop drags the data of s until it is dropped or the drag is
+// cancelled, and returns the DROPEFFECT_* effect of the drop, which is
+// DROPEFFECT_NONE if it was cancelled. The effects allowed are given by
+// allowed. It must be called on a thread with OLE initialized, usually on
+// WM_LBUTTONDOWN or WM_MOUSEMOVE with a button pressed.
+func (s *DataSource) DoDragDrop(allowed uint32) (uint32, error) {
+	initDragDropVtbls()
+
+	obj := &dataObject{src: s}
+	obj.init(unsafe.Pointer(&dragDropVtbls.dataObject), &IID_IDataObject)
+	defer comRelease(&obj.comObject)
+
+	src := &dropSource{}
+	src.init(unsafe.Pointer(&dragDropVtbls.dropSource), &IID_IDropSource)
+	defer comRelease(&src.comObject)
+
+	var effect uint32
+	switch hr := DoDragDrop((*IDataObject)(unsafe.Pointer(obj)), (*IDropSource)(unsafe.Pointer(src)), allowed, &effect); hr {
+	case DRAGDROP_S_DROP:
+		return effect, nil
+
+	case DRAGDROP_S_CANCEL:
+		return DROPEFFECT_NONE, nil
+
+	default:
+		return DROPEFFECT_NONE, fmt.Errorf("DoDragDrop failed: 0x%08X", uint32(hr))
+	}
+}
+
+// lookup returns the data requested by fe, or an error code.
+func (o *dataObject) lookup(fe *FORMATETC) ([]byte, uintptr) {
+	switch {
+	case fe == nil:
+		return nil, E_INVALIDARG
+
+	case fe.DwAspect != DVASPECT_CONTENT:
+		return nil, DV_E_DVASPECT
+
+	case fe.Tymed&TYMED_HGLOBAL == 0:
+		return nil, DV_E_TYMED
+	}
+
+	data, ok := o.src.data[uint32(fe.CfFormat)]
+	if !ok {
+		return nil, DV_E_FORMATETC
+	}
+
+	return data, S_OK
+}
+
+func dataObjectGetData(this *dataObject, pformatetcIn *FORMATETC, pmedium *STGMEDIUM) uintptr {
+	data, hr := this.lookup(pformatetcIn)
+	if hr != S_OK {
+		return hr
+	}
+
+	hMem, err := globalAllocBytes(data)
+	if err != nil {
+		return E_OUTOFMEMORY
+	}
+
+	// The receiver frees the memory with ReleaseStgMedium.
+	*pmedium = STGMEDIUM{Tymed: TYMED_HGLOBAL, Data: uintptr(hMem)}
+
+	return S_OK
+}
+
+func dataObjectGetDataHere(this *dataObject, pformatetc *FORMATETC, pmedium *STGMEDIUM) uintptr {
+	return E_NOTIMPL
+}
+
+func dataObjectQueryGetData(this *dataObject, pformatetc *FORMATETC) uintptr {
+	_, hr := this.lookup(pformatetc)
+	return hr
+}
+
+func dataObjectGetCanonicalFormatEtc(this *dataObject, pformatectIn, pformatetcOut *FORMATETC) uintptr {
+	if pformatectIn == nil || pformatetcOut == nil {
+		return E_INVALIDARG
+	}
+
+	*pformatetcOut = *pformatectIn
+	pformatetcOut.Ptd = 0
+
+	return DATA_S_SAMEFORMATETC
+}
+
+func dataObjectSetData(this *dataObject, pformatetc *FORMATETC, pmedium *STGMEDIUM, fRelease int32) uintptr {
+	return E_NOTIMPL
+}
+
+func dataObjectEnumFormatEtc(this *dataObject, dwDirection uint32, ppenumFormatEtc **IEnumFORMATETC) uintptr {
+	if dwDirection != DATADIR_GET {
+		return E_NOTIMPL
+	}
+
+	fes := make([]FORMATETC, len(this.src.formats))
+	for i, format := range this.src.formats {
+		fes[i] = dataFormatEtc(format)
+	}
+
+	var p *FORMATETC
+	if len(fes) > 0 {
+		p = &fes[0]
+	}
+
+	return uintptr(SHCreateStdEnumFmtEtc(uint32(len(fes)), p, ppenumFormatEtc))
+}
+
+func dataObjectDAdvise(this *dataObject, pformatetc *FORMATETC, advf uint32, pAdvSink *IUnknown, pdwConnection *uint32) uintptr {
+	return OLE_E_ADVISENOTSUPPORTED
+}
+
+func dataObjectDUnadvise(this *dataObject, dwConnection uint32) uintptr {
+	return OLE_E_ADVISENOTSUPPORTED
+}
+
+func dataObjectEnumDAdvise(this *dataObject, ppenumAdvise **IUnknown) uintptr {
+	return OLE_E_ADVISENOTSUPPORTED
+}
+
+func dropSourceQueryContinueDrag(this *dropSource, fEscapePressed int32, grfKeyState uint32) uintptr {
+	switch {
+	case fEscapePressed != 0:
+		return DRAGDROP_S_CANCEL
+
+	case grfKeyState&(MK_LBUTTON|MK_RBUTTON) == 0:
+		return DRAGDROP_S_DROP
+	}
+
+	return S_OK
+}
+
+func dropSourceGiveFeedback(this *dropSource, dwEffect uint32) uintptr {
+	return DRAGDROP_S_USEDEFAULTCURSORS
+}
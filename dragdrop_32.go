@@ -0,0 +1,22 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows,386 windows,arm
+
+package win
+
+// On 32-bit systems, the POINTL argument of IDropTarget methods is passed
+// by value as two arguments.
+
+func dropTargetDragEnter(this *dropTarget, pDataObj *IDataObject, grfKeyState uint32, x, y int32, pdwEffect *uint32) uintptr {
+	return this.dragEnter(pDataObj, grfKeyState, POINT{x, y}, pdwEffect)
+}
+
+func dropTargetDragOver(this *dropTarget, grfKeyState uint32, x, y int32, pdwEffect *uint32) uintptr {
+	return this.dragOver(grfKeyState, POINT{x, y}, pdwEffect)
+}
+
+func dropTargetDrop(this *dropTarget, pDataObj *IDataObject, grfKeyState uint32, x, y int32, pdwEffect *uint32) uintptr {
+	return this.drop(pDataObj, grfKeyState, POINT{x, y}, pdwEffect)
+}
@@ -0,0 +1,26 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows,amd64 windows,arm64
+
+package win
+
+// On 64-bit systems, the POINTL argument of IDropTarget methods is passed
+// by value in a single register.
+
+func pointFromPOINTL(pt uintptr) POINT {
+	return POINT{int32(pt), int32(pt >> 32)}
+}
+
+func dropTargetDragEnter(this *dropTarget, pDataObj *IDataObject, grfKeyState uint32, pt uintptr, pdwEffect *uint32) uintptr {
+	return this.dragEnter(pDataObj, grfKeyState, pointFromPOINTL(pt), pdwEffect)
+}
+
+func dropTargetDragOver(this *dropTarget, grfKeyState uint32, pt uintptr, pdwEffect *uint32) uintptr {
+	return this.dragOver(grfKeyState, pointFromPOINTL(pt), pdwEffect)
+}
+
+func dropTargetDrop(this *dropTarget, pDataObj *IDataObject, grfKeyState uint32, pt uintptr, pdwEffect *uint32) uintptr {
+	return this.drop(pDataObj, grfKeyState, pointFromPOINTL(pt), pdwEffect)
+}
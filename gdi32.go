@@ -397,11 +397,6 @@ const (
 	DC_MEDIATYPES        = 35
 )
 
-const (
-	CCHDEVICENAME = 32
-	CCHFORMNAME   = 32
-)
-
 const (
 	DM_UPDATE      = 1
 	DM_COPY        = 2
@@ -413,40 +408,6 @@ const (
 	DM_OUT_DEFAULT = DM_UPDATE
 )
 
-// DEVMODE field selection bits
-const (
-	DM_ORIENTATION        = 0x00000001
-	DM_PAPERSIZE          = 0x00000002
-	DM_PAPERLENGTH        = 0x00000004
-	DM_PAPERWIDTH         = 0x00000008
-	DM_SCALE              = 0x00000010
-	DM_POSITION           = 0x00000020
-	DM_NUP                = 0x00000040
-	DM_DISPLAYORIENTATION = 0x00000080
-	DM_COPIES             = 0x00000100
-	DM_DEFAULTSOURCE      = 0x00000200
-	DM_PRINTQUALITY       = 0x00000400
-	DM_COLOR              = 0x00000800
-	DM_DUPLEX             = 0x00001000
-	DM_YRESOLUTION        = 0x00002000
-	DM_TTOPTION           = 0x00004000
-	DM_COLLATE            = 0x00008000
-	DM_FORMNAME           = 0x00010000
-	DM_LOGPIXELS          = 0x00020000
-	DM_BITSPERPEL         = 0x00040000
-	DM_PELSWIDTH          = 0x00080000
-	DM_PELSHEIGHT         = 0x00100000
-	DM_DISPLAYFLAGS       = 0x00200000
-	DM_DISPLAYFREQUENCY   = 0x00400000
-	DM_ICMMETHOD          = 0x00800000
-	DM_ICMINTENT          = 0x01000000
-	DM_MEDIATYPE          = 0x02000000
-	DM_DITHERTYPE         = 0x04000000
-	DM_PANNINGWIDTH       = 0x08000000
-	DM_PANNINGHEIGHT      = 0x10000000
-	DM_DISPLAYFIXEDOUTPUT = 0x20000000
-)
-
 // Orientation constants
 const (
 	DMORIENT_PORTRAIT  = 1
@@ -674,16 +635,6 @@ const (
 	STRETCH_HALFTONE    = HALFTONE
 )
 
-// Bitmap compression constants
-const (
-	BI_RGB       = 0
-	BI_RLE8      = 1
-	BI_RLE4      = 2
-	BI_BITFIELDS = 3
-	BI_JPEG      = 4
-	BI_PNG       = 5
-)
-
 // Bitmap color table usage
 const (
 	DIB_RGB_COLORS = 0
@@ -754,6 +705,12 @@ const (
 	COMPLEXREGION = 3
 )
 
+// Polygon fill modes
+const (
+	ALTERNATE = 1
+	WINDING   = 2
+)
+
 // AlphaBlend operations
 const (
 	AC_SRC_ALPHA = 0x1
@@ -769,20 +726,6 @@ func RGB(r, g, b byte) COLORREF {
 	return COLORREF(r) | (COLORREF(g) << 8) | (COLORREF(b) << 16)
 }
 
-type (
-	COLORREF     uint32
-	HBITMAP      uintptr
-	HBRUSH       uintptr
-	HDC          uintptr
-	HFONT        uintptr
-	HGDIOBJ      uintptr
-	HENHMETAFILE uintptr
-	HPALETTE     uintptr
-	HPEN         uintptr
-	HRGN         uintptr
-	CLIPFORMAT   uint16
-)
-
 type PIXELFORMATDESCRIPTOR struct {
 	NSize           uint16
 	NVersion        uint16
@@ -852,55 +795,6 @@ type TEXTMETRIC struct {
 	TmCharSet          byte
 }
 
-type DEVMODE struct {
-	DmDeviceName       [CCHDEVICENAME]uint16
-	DmSpecVersion      uint16
-	DmDriverVersion    uint16
-	DmSize             uint16
-	DmDriverExtra      uint16
-	DmFields           uint32
-	DmOrientation      int16
-	DmPaperSize        int16
-	DmPaperLength      int16
-	DmPaperWidth       int16
-	DmScale            int16
-	DmCopies           int16
-	DmDefaultSource    int16
-	DmPrintQuality     int16
-	DmColor            int16
-	DmDuplex           int16
-	DmYResolution      int16
-	DmTTOption         int16
-	DmCollate          int16
-	DmFormName         [CCHFORMNAME]uint16
-	DmLogPixels        uint16
-	DmBitsPerPel       uint32
-	DmPelsWidth        uint32
-	DmPelsHeight       uint32
-	DmDisplayFlags     uint32
-	DmDisplayFrequency uint32
-	DmICMMethod        uint32
-	DmICMIntent        uint32
-	DmMediaType        uint32
-	DmDitherType       uint32
-	DmReserved1        uint32
-	DmReserved2        uint32
-	DmPanningWidth     uint32
-	DmPanningHeight    uint32
-}
-
-type POINT struct {
-	X, Y int32
-}
-
-type RECT struct {
-	Left, Top, Right, Bottom int32
-}
-
-type SIZE struct {
-	CX, CY int32
-}
-
 type DOCINFO struct {
 	CbSize       int32
 	LpszDocName  *uint16
@@ -909,6 +803,20 @@ type DOCINFO struct {
 	FwType       uint32
 }
 
+type XFORM struct {
+	EM11 float32
+	EM12 float32
+	EM21 float32
+	EM22 float32
+	EDx  float32
+	EDy  float32
+}
+
+type RGNDATA struct {
+	Rdh    RGNDATAHEADER
+	Buffer [1]byte
+}
+
 type LOGBRUSH struct {
 	LbStyle uint32
 	LbColor COLORREF
@@ -1044,12 +952,15 @@ var (
 
 	// Functions
 	abortDoc                *windows.LazyProc
+	abortPath               *windows.LazyProc
 	addFontResourceEx       *windows.LazyProc
 	addFontMemResourceEx    *windows.LazyProc
 	alphaBlend              *windows.LazyProc
+	beginPath               *windows.LazyProc
 	bitBlt                  *windows.LazyProc
 	choosePixelFormat       *windows.LazyProc
 	closeEnhMetaFile        *windows.LazyProc
+	closeFigure             *windows.LazyProc
 	combineRgn              *windows.LazyProc
 	copyEnhMetaFile         *windows.LazyProc
 	createBitmap            *windows.LazyProc
@@ -1058,10 +969,13 @@ var (
 	createCompatibleDC      *windows.LazyProc
 	createDC                *windows.LazyProc
 	createDIBSection        *windows.LazyProc
+	createEllipticRgn       *windows.LazyProc
 	createFontIndirect      *windows.LazyProc
 	createEnhMetaFile       *windows.LazyProc
 	createIC                *windows.LazyProc
 	createPatternBrush      *windows.LazyProc
+	createPolygonRgn        *windows.LazyProc
+	createPolyPolygonRgn    *windows.LazyProc
 	createRectRgn           *windows.LazyProc
 	createRoundRectRgn      *windows.LazyProc
 	deleteDC                *windows.LazyProc
@@ -1070,9 +984,14 @@ var (
 	ellipse                 *windows.LazyProc
 	endDoc                  *windows.LazyProc
 	endPage                 *windows.LazyProc
+	endPath                 *windows.LazyProc
 	excludeClipRect         *windows.LazyProc
 	extCreatePen            *windows.LazyProc
+	extCreateRegion         *windows.LazyProc
+	fillPath                *windows.LazyProc
 	fillRgn                 *windows.LazyProc
+	flattenPath             *windows.LazyProc
+	frameRgn                *windows.LazyProc
 	gdiFlush                *windows.LazyProc
 	getBkColor              *windows.LazyProc
 	getDeviceCaps           *windows.LazyProc
@@ -1081,6 +1000,8 @@ var (
 	getEnhMetaFileHeader    *windows.LazyProc
 	getObject               *windows.LazyProc
 	getPixel                *windows.LazyProc
+	getPolyFillMode         *windows.LazyProc
+	getRegionData           *windows.LazyProc
 	getRgnBox               *windows.LazyProc
 	getStockObject          *windows.LazyProc
 	getTextColor            *windows.LazyProc
@@ -1092,14 +1013,20 @@ var (
 	intersectClipRect       *windows.LazyProc
 	lineTo                  *windows.LazyProc
 	moveToEx                *windows.LazyProc
+	offsetRgn               *windows.LazyProc
+	pathToRegion            *windows.LazyProc
 	playEnhMetaFile         *windows.LazyProc
 	polyline                *windows.LazyProc
+	ptInRegion              *windows.LazyProc
 	rectangle               *windows.LazyProc
+	rectInRegion            *windows.LazyProc
 	removeFontResourceEx    *windows.LazyProc
 	removeFontMemResourceEx *windows.LazyProc
 	resetDC                 *windows.LazyProc
 	restoreDC               *windows.LazyProc
 	roundRect               *windows.LazyProc
+	selectClipPath          *windows.LazyProc
+	selectClipRgn           *windows.LazyProc
 	selectObject            *windows.LazyProc
 	setBkColor              *windows.LazyProc
 	setBkMode               *windows.LazyProc
@@ -1107,6 +1034,7 @@ var (
 	setDIBits               *windows.LazyProc
 	setPixel                *windows.LazyProc
 	setPixelFormat          *windows.LazyProc
+	setPolyFillMode         *windows.LazyProc
 	setStretchBltMode       *windows.LazyProc
 	setTextColor            *windows.LazyProc
 	setViewportOrgEx        *windows.LazyProc
@@ -1114,9 +1042,12 @@ var (
 	startDoc                *windows.LazyProc
 	startPage               *windows.LazyProc
 	stretchBlt              *windows.LazyProc
+	strokeAndFillPath       *windows.LazyProc
+	strokePath              *windows.LazyProc
 	swapBuffers             *windows.LazyProc
 	textOut                 *windows.LazyProc
 	transparentBlt          *windows.LazyProc
+	widenPath               *windows.LazyProc
 )
 
 func init() {
@@ -1126,11 +1057,14 @@ func init() {
 
 	// Functions
 	abortDoc = libgdi32.NewProc("AbortDoc")
+	abortPath = libgdi32.NewProc("AbortPath")
 	addFontResourceEx = libgdi32.NewProc("AddFontResourceExW")
 	addFontMemResourceEx = libgdi32.NewProc("AddFontMemResourceEx")
+	beginPath = libgdi32.NewProc("BeginPath")
 	bitBlt = libgdi32.NewProc("BitBlt")
 	choosePixelFormat = libgdi32.NewProc("ChoosePixelFormat")
 	closeEnhMetaFile = libgdi32.NewProc("CloseEnhMetaFile")
+	closeFigure = libgdi32.NewProc("CloseFigure")
 	combineRgn = libgdi32.NewProc("CombineRgn")
 	copyEnhMetaFile = libgdi32.NewProc("CopyEnhMetaFileW")
 	createBitmap = libgdi32.NewProc("CreateBitmap")
@@ -1139,10 +1073,13 @@ func init() {
 	createCompatibleDC = libgdi32.NewProc("CreateCompatibleDC")
 	createDC = libgdi32.NewProc("CreateDCW")
 	createDIBSection = libgdi32.NewProc("CreateDIBSection")
+	createEllipticRgn = libgdi32.NewProc("CreateEllipticRgn")
 	createEnhMetaFile = libgdi32.NewProc("CreateEnhMetaFileW")
 	createFontIndirect = libgdi32.NewProc("CreateFontIndirectW")
 	createIC = libgdi32.NewProc("CreateICW")
 	createPatternBrush = libgdi32.NewProc("CreatePatternBrush")
+	createPolygonRgn = libgdi32.NewProc("CreatePolygonRgn")
+	createPolyPolygonRgn = libgdi32.NewProc("CreatePolyPolygonRgn")
 	createRectRgn = libgdi32.NewProc("CreateRectRgn")
 	createRoundRectRgn = libgdi32.NewProc("CreateRoundRectRgn")
 
@@ -1152,9 +1089,14 @@ func init() {
 	ellipse = libgdi32.NewProc("Ellipse")
 	endDoc = libgdi32.NewProc("EndDoc")
 	endPage = libgdi32.NewProc("EndPage")
+	endPath = libgdi32.NewProc("EndPath")
 	excludeClipRect = libgdi32.NewProc("ExcludeClipRect")
 	extCreatePen = libgdi32.NewProc("ExtCreatePen")
+	extCreateRegion = libgdi32.NewProc("ExtCreateRegion")
+	fillPath = libgdi32.NewProc("FillPath")
 	fillRgn = libgdi32.NewProc("FillRgn")
+	flattenPath = libgdi32.NewProc("FlattenPath")
+	frameRgn = libgdi32.NewProc("FrameRgn")
 	gdiFlush = libgdi32.NewProc("GdiFlush")
 	getBkColor = libgdi32.NewProc("GetBkColor")
 	getDeviceCaps = libgdi32.NewProc("GetDeviceCaps")
@@ -1163,6 +1105,8 @@ func init() {
 	getEnhMetaFileHeader = libgdi32.NewProc("GetEnhMetaFileHeader")
 	getObject = libgdi32.NewProc("GetObjectW")
 	getPixel = libgdi32.NewProc("GetPixel")
+	getPolyFillMode = libgdi32.NewProc("GetPolyFillMode")
+	getRegionData = libgdi32.NewProc("GetRegionData")
 	getRgnBox = libgdi32.NewProc("GetRgnBox")
 	getStockObject = libgdi32.NewProc("GetStockObject")
 	getTextColor = libgdi32.NewProc("GetTextColor")
@@ -1173,15 +1117,21 @@ func init() {
 	intersectClipRect = libgdi32.NewProc("IntersectClipRect")
 	lineTo = libgdi32.NewProc("LineTo")
 	moveToEx = libgdi32.NewProc("MoveToEx")
+	offsetRgn = libgdi32.NewProc("OffsetRgn")
+	pathToRegion = libgdi32.NewProc("PathToRegion")
 	playEnhMetaFile = libgdi32.NewProc("PlayEnhMetaFile")
 	polyline = libgdi32.NewProc("Polyline")
+	ptInRegion = libgdi32.NewProc("PtInRegion")
 	rectangle = libgdi32.NewProc("Rectangle")
+	rectInRegion = libgdi32.NewProc("RectInRegion")
 	removeFontResourceEx = libgdi32.NewProc("RemoveFontResourceExW")
 	removeFontMemResourceEx = libgdi32.NewProc("RemoveFontMemResourceEx")
 	resetDC = libgdi32.NewProc("ResetDCW")
 	restoreDC = libgdi32.NewProc("RestoreDC")
 	roundRect = libgdi32.NewProc("RoundRect")
 	saveDC = libgdi32.NewProc("SaveDC")
+	selectClipPath = libgdi32.NewProc("SelectClipPath")
+	selectClipRgn = libgdi32.NewProc("SelectClipRgn")
 	selectObject = libgdi32.NewProc("SelectObject")
 	setBkColor = libgdi32.NewProc("SetBkColor")
 	setBkMode = libgdi32.NewProc("SetBkMode")
@@ -1189,14 +1139,18 @@ func init() {
 	setDIBits = libgdi32.NewProc("SetDIBits")
 	setPixel = libgdi32.NewProc("SetPixel")
 	setPixelFormat = libgdi32.NewProc("SetPixelFormat")
+	setPolyFillMode = libgdi32.NewProc("SetPolyFillMode")
 	setStretchBltMode = libgdi32.NewProc("SetStretchBltMode")
 	setTextColor = libgdi32.NewProc("SetTextColor")
 	setViewportOrgEx = libgdi32.NewProc("SetViewportOrgEx")
 	startDoc = libgdi32.NewProc("StartDocW")
 	startPage = libgdi32.NewProc("StartPage")
 	stretchBlt = libgdi32.NewProc("StretchBlt")
+	strokeAndFillPath = libgdi32.NewProc("StrokeAndFillPath")
+	strokePath = libgdi32.NewProc("StrokePath")
 	swapBuffers = libgdi32.NewProc("SwapBuffers")
 	textOut = libgdi32.NewProc("TextOutW")
+	widenPath = libgdi32.NewProc("WidenPath")
 
 	alphaBlend = libmsimg32.NewProc("AlphaBlend")
 	gradientFill = libmsimg32.NewProc("GradientFill")
@@ -1212,6 +1166,15 @@ func AbortDoc(hdc HDC) int32 {
 	return int32(ret)
 }
 
+func AbortPath(hdc HDC) bool {
+	ret, _, _ := syscall.Syscall(abortPath.Addr(), 1,
+		uintptr(hdc),
+		0,
+		0)
+
+	return ret != 0
+}
+
 func AddFontResourceEx(lpszFilename *uint16, fl uint32, pdv unsafe.Pointer) int32 {
 	ret, _, _ := syscall.Syscall(addFontResourceEx.Addr(), 3,
 		uintptr(unsafe.Pointer(lpszFilename)),
@@ -1251,6 +1214,15 @@ func AlphaBlend(hdcDest HDC, nXOriginDest, nYOriginDest, nWidthDest, nHeightDest
 	return ret != 0
 }
 
+func BeginPath(hdc HDC) bool {
+	ret, _, _ := syscall.Syscall(beginPath.Addr(), 1,
+		uintptr(hdc),
+		0,
+		0)
+
+	return ret != 0
+}
+
 func BitBlt(hdcDest HDC, nXDest, nYDest, nWidth, nHeight int32, hdcSrc HDC, nXSrc, nYSrc int32, dwRop uint32) bool {
 	ret, _, _ := syscall.Syscall9(bitBlt.Addr(), 9,
 		uintptr(hdcDest),
@@ -1284,6 +1256,15 @@ func CloseEnhMetaFile(hdc HDC) HENHMETAFILE {
 	return HENHMETAFILE(ret)
 }
 
+func CloseFigure(hdc HDC) bool {
+	ret, _, _ := syscall.Syscall(closeFigure.Addr(), 1,
+		uintptr(hdc),
+		0,
+		0)
+
+	return ret != 0
+}
+
 func CombineRgn(hrgnDest, hrgnSrc1, hrgnSrc2 HRGN, fnCombineMode int32) int32 {
 	ret, _, _ := syscall.Syscall6(combineRgn.Addr(), 4,
 		uintptr(hrgnDest),
@@ -1368,6 +1349,18 @@ func CreateDIBSection(hdc HDC, pbmih *BITMAPINFOHEADER, iUsage uint32, ppvBits *
 	return HBITMAP(ret)
 }
 
+func CreateEllipticRgn(nLeftRect, nTopRect, nRightRect, nBottomRect int32) HRGN {
+	ret, _, _ := syscall.Syscall6(createEllipticRgn.Addr(), 4,
+		uintptr(nLeftRect),
+		uintptr(nTopRect),
+		uintptr(nRightRect),
+		uintptr(nBottomRect),
+		0,
+		0)
+
+	return HRGN(ret)
+}
+
 func CreateEnhMetaFile(hdcRef HDC, lpFilename *uint16, lpRect *RECT, lpDescription *uint16) HDC {
 	ret, _, _ := syscall.Syscall6(createEnhMetaFile.Addr(), 4,
 		uintptr(hdcRef),
@@ -1410,6 +1403,27 @@ func CreatePatternBrush(hbmp HBITMAP) HBRUSH {
 	return HBRUSH(ret)
 }
 
+func CreatePolygonRgn(lppt *POINT, cPoints, fnPolyFillMode int32) HRGN {
+	ret, _, _ := syscall.Syscall(createPolygonRgn.Addr(), 3,
+		uintptr(unsafe.Pointer(lppt)),
+		uintptr(cPoints),
+		uintptr(fnPolyFillMode))
+
+	return HRGN(ret)
+}
+
+func CreatePolyPolygonRgn(lppt *POINT, lpPolyCounts *int32, nCount, fnPolyFillMode int32) HRGN {
+	ret, _, _ := syscall.Syscall6(createPolyPolygonRgn.Addr(), 4,
+		uintptr(unsafe.Pointer(lppt)),
+		uintptr(unsafe.Pointer(lpPolyCounts)),
+		uintptr(nCount),
+		uintptr(fnPolyFillMode),
+		0,
+		0)
+
+	return HRGN(ret)
+}
+
 func CreateRectRgn(nLeftRect, nTopRect, nRightRect, nBottomRect int32) HRGN {
 	ret, _, _ := syscall.Syscall6(createRectRgn.Addr(), 4,
 		uintptr(nLeftRect),
@@ -1491,6 +1505,15 @@ func EndPage(hdc HDC) int32 {
 	return int32(ret)
 }
 
+func EndPath(hdc HDC) bool {
+	ret, _, _ := syscall.Syscall(endPath.Addr(), 1,
+		uintptr(hdc),
+		0,
+		0)
+
+	return ret != 0
+}
+
 func ExcludeClipRect(hdc HDC, nLeftRect, nTopRect, nRightRect, nBottomRect int32) int32 {
 	ret, _, _ := syscall.Syscall6(excludeClipRect.Addr(), 5,
 		uintptr(hdc),
@@ -1515,6 +1538,24 @@ func ExtCreatePen(dwPenStyle, dwWidth uint32, lplb *LOGBRUSH, dwStyleCount uint3
 	return HPEN(ret)
 }
 
+func ExtCreateRegion(lpXform *XFORM, nCount uint32, lpRgnData *RGNDATA) HRGN {
+	ret, _, _ := syscall.Syscall(extCreateRegion.Addr(), 3,
+		uintptr(unsafe.Pointer(lpXform)),
+		uintptr(nCount),
+		uintptr(unsafe.Pointer(lpRgnData)))
+
+	return HRGN(ret)
+}
+
+func FillPath(hdc HDC) bool {
+	ret, _, _ := syscall.Syscall(fillPath.Addr(), 1,
+		uintptr(hdc),
+		0,
+		0)
+
+	return ret != 0
+}
+
 func FillRgn(hdc HDC, hrgn HRGN, hbr HBRUSH) bool {
 	ret, _, _ := syscall.Syscall(fillRgn.Addr(), 3,
 		uintptr(hdc),
@@ -1524,6 +1565,27 @@ func FillRgn(hdc HDC, hrgn HRGN, hbr HBRUSH) bool {
 	return ret != 0
 }
 
+func FlattenPath(hdc HDC) bool {
+	ret, _, _ := syscall.Syscall(flattenPath.Addr(), 1,
+		uintptr(hdc),
+		0,
+		0)
+
+	return ret != 0
+}
+
+func FrameRgn(hdc HDC, hrgn HRGN, hbr HBRUSH, nWidth, nHeight int32) bool {
+	ret, _, _ := syscall.Syscall6(frameRgn.Addr(), 5,
+		uintptr(hdc),
+		uintptr(hrgn),
+		uintptr(hbr),
+		uintptr(nWidth),
+		uintptr(nHeight),
+		0)
+
+	return ret != 0
+}
+
 func GdiFlush() bool {
 	ret, _, _ := syscall.Syscall(gdiFlush.Addr(), 0,
 		0,
@@ -1601,6 +1663,24 @@ func GetPixel(hdc HDC, nXPos, nYPos int32) COLORREF {
 	return COLORREF(ret)
 }
 
+func GetPolyFillMode(hdc HDC) int32 {
+	ret, _, _ := syscall.Syscall(getPolyFillMode.Addr(), 1,
+		uintptr(hdc),
+		0,
+		0)
+
+	return int32(ret)
+}
+
+func GetRegionData(hRgn HRGN, dwCount uint32, lpRgnData *RGNDATA) uint32 {
+	ret, _, _ := syscall.Syscall(getRegionData.Addr(), 3,
+		uintptr(hRgn),
+		uintptr(dwCount),
+		uintptr(unsafe.Pointer(lpRgnData)))
+
+	return uint32(ret)
+}
+
 func GetRgnBox(hrgn HRGN, lprc *RECT) int32 {
 	ret, _, _ := syscall.Syscall(getRgnBox.Addr(), 2,
 		uintptr(hrgn),
@@ -1718,6 +1798,24 @@ func MoveToEx(hdc HDC, x, y int, lpPoint *POINT) bool {
 	return ret != 0
 }
 
+func OffsetRgn(hrgn HRGN, nXOffset, nYOffset int32) int32 {
+	ret, _, _ := syscall.Syscall(offsetRgn.Addr(), 3,
+		uintptr(hrgn),
+		uintptr(nXOffset),
+		uintptr(nYOffset))
+
+	return int32(ret)
+}
+
+func PathToRegion(hdc HDC) HRGN {
+	ret, _, _ := syscall.Syscall(pathToRegion.Addr(), 1,
+		uintptr(hdc),
+		0,
+		0)
+
+	return HRGN(ret)
+}
+
 func PlayEnhMetaFile(hdc HDC, hemf HENHMETAFILE, lpRect *RECT) bool {
 	ret, _, _ := syscall.Syscall(playEnhMetaFile.Addr(), 3,
 		uintptr(hdc),
@@ -1736,6 +1834,15 @@ func Polyline(hdc HDC, lppt unsafe.Pointer, cPoints int32) bool {
 	return ret != 0
 }
 
+func PtInRegion(hrgn HRGN, X, Y int32) bool {
+	ret, _, _ := syscall.Syscall(ptInRegion.Addr(), 3,
+		uintptr(hrgn),
+		uintptr(X),
+		uintptr(Y))
+
+	return ret != 0
+}
+
 func Rectangle_(hdc HDC, nLeftRect, nTopRect, nRightRect, nBottomRect int32) bool {
 	ret, _, _ := syscall.Syscall6(rectangle.Addr(), 5,
 		uintptr(hdc),
@@ -1748,6 +1855,15 @@ func Rectangle_(hdc HDC, nLeftRect, nTopRect, nRightRect, nBottomRect int32) boo
 	return ret != 0
 }
 
+func RectInRegion(hrgn HRGN, lprc *RECT) bool {
+	ret, _, _ := syscall.Syscall(rectInRegion.Addr(), 2,
+		uintptr(hrgn),
+		uintptr(unsafe.Pointer(lprc)),
+		0)
+
+	return ret != 0
+}
+
 func RemoveFontResourceEx(lpszFilename *uint16, fl uint32, pdv unsafe.Pointer) bool {
 	ret, _, _ := syscall.Syscall(removeFontResourceEx.Addr(), 3,
 		uintptr(unsafe.Pointer(lpszFilename)),
@@ -1806,6 +1922,24 @@ func SaveDC(hdc HDC) int32 {
 	return int32(ret)
 }
 
+func SelectClipPath(hdc HDC, iMode int32) bool {
+	ret, _, _ := syscall.Syscall(selectClipPath.Addr(), 2,
+		uintptr(hdc),
+		uintptr(iMode),
+		0)
+
+	return ret != 0
+}
+
+func SelectClipRgn(hdc HDC, hrgn HRGN) int32 {
+	ret, _, _ := syscall.Syscall(selectClipRgn.Addr(), 2,
+		uintptr(hdc),
+		uintptr(hrgn),
+		0)
+
+	return int32(ret)
+}
+
 func SelectObject(hdc HDC, hgdiobj HGDIOBJ) HGDIOBJ {
 	ret, _, _ := syscall.Syscall(selectObject.Addr(), 2,
 		uintptr(hdc),
@@ -1881,6 +2015,15 @@ func SetPixelFormat(hdc HDC, iPixelFormat int32, ppfd *PIXELFORMATDESCRIPTOR) bo
 	return ret != 0
 }
 
+func SetPolyFillMode(hdc HDC, iPolyFillMode int32) int32 {
+	ret, _, _ := syscall.Syscall(setPolyFillMode.Addr(), 2,
+		uintptr(hdc),
+		uintptr(iPolyFillMode),
+		0)
+
+	return int32(ret)
+}
+
 func SetStretchBltMode(hdc HDC, iStretchMode int32) int32 {
 	ret, _, _ := syscall.Syscall(setStretchBltMode.Addr(), 2,
 		uintptr(hdc),
@@ -1947,6 +2090,24 @@ func StretchBlt(hdcDest HDC, nXOriginDest, nYOriginDest, nWidthDest, nHeightDest
 	return ret != 0
 }
 
+func StrokeAndFillPath(hdc HDC) bool {
+	ret, _, _ := syscall.Syscall(strokeAndFillPath.Addr(), 1,
+		uintptr(hdc),
+		0,
+		0)
+
+	return ret != 0
+}
+
+func StrokePath(hdc HDC) bool {
+	ret, _, _ := syscall.Syscall(strokePath.Addr(), 1,
+		uintptr(hdc),
+		0,
+		0)
+
+	return ret != 0
+}
+
 func SwapBuffers(hdc HDC) bool {
 	ret, _, _ := syscall.Syscall(swapBuffers.Addr(), 1,
 		uintptr(hdc),
@@ -1984,3 +2145,12 @@ func TransparentBlt(hdcDest HDC, xoriginDest, yoriginDest, wDest, hDest int32, h
 
 	return ret != 0
 }
+
+func WidenPath(hdc HDC) bool {
+	ret, _, _ := syscall.Syscall(widenPath.Addr(), 1,
+		uintptr(hdc),
+		0,
+		0)
+
+	return ret != 0
+}
@@ -0,0 +1,102 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"fmt"
+)
+
+// HotKeyManager registers system-wide hotkeys for a window and calls Go
+// functions when they are pressed. All methods must be called on the
+// thread of the window.
+type HotKeyManager struct {
+	w        *Window
+	nextID   int32
+	handlers map[int32]func()
+}
+
+// NewHotKeyManager returns a HotKeyManager delivering the WM_HOTKEY
+// messages of w to the registered functions.
+func NewHotKeyManager(w *Window) *HotKeyManager {
+	m := &HotKeyManager{
+		w:        w,
+		handlers: make(map[int32]func()),
+	}
+
+	w.Handle(WM_HOTKEY, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		f := m.handlers[CrackHotKeyMsg(wParam, lParam).ID]
+		if f == nil {
+			return 0, false
+		}
+
+		f()
+
+		return 0, true
+	})
+
+	w.Handle(WM_DESTROY, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		m.Close()
+		return 0, false
+	})
+
+	return m
+}
+
+// Register registers the hotkey s, e.g. "Ctrl+Alt+K", and calls f when it
+// is pressed. Holding the keys down does not repeat the hotkey. It returns
+// an id for Unregister.
+func (m *HotKeyManager) Register(s string, f func()) (int32, error) {
+	sc, err := ParseShortcut(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.RegisterShortcut(sc, f)
+}
+
+// RegisterShortcut is like Register, with a parsed shortcut.
+func (m *HotKeyManager) RegisterShortcut(sc Shortcut, f func()) (int32, error) {
+	// Applications must use ids from 0x0000 to 0xBFFF.
+	if m.nextID > 0xBFFF {
+		return 0, errors.New("too many hotkeys")
+	}
+	id := m.nextID
+
+	if !RegisterHotKey(m.w.HWND(), id, sc.HotKeyModifiers()|MOD_NOREPEAT, uint32(sc.Key)) {
+		return 0, fmt.Errorf("RegisterHotKey failed for %s; it may be in use by another application", sc)
+	}
+
+	m.nextID++
+	m.handlers[id] = f
+
+	return id, nil
+}
+
+// Unregister unregisters the hotkey id.
+func (m *HotKeyManager) Unregister(id int32) error {
+	if _, ok := m.handlers[id]; !ok {
+		return errors.New("unknown hotkey id")
+	}
+
+	delete(m.handlers, id)
+
+	if !UnregisterHotKey(m.w.HWND(), id) {
+		return errors.New("UnregisterHotKey failed")
+	}
+
+	return nil
+}
+
+// Close unregisters all hotkeys. It is called automatically when the
+// window is destroyed.
+func (m *HotKeyManager) Close() {
+	for id := range m.handlers {
+		UnregisterHotKey(m.w.HWND(), id)
+		delete(m.handlers, id)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"unsafe"
+)
+
+// HICONFromImage creates an icon from img, keeping its alpha channel. If
+// hotspot is not nil, it creates a cursor with that hotspot instead. The
+// caller must destroy the returned handle with DestroyIcon.
+func HICONFromImage(img image.Image, hotspot *image.Point) (HICON, error) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	if w <= 0 || h <= 0 {
+		return 0, errors.New("empty icon image")
+	}
+
+	var bmh BITMAPV5HEADER
+	bmh.BiSize = uint32(unsafe.Sizeof(bmh))
+	bmh.BiWidth = int32(w)
+	bmh.BiHeight = -int32(h) // top-down
+	bmh.BiPlanes = 1
+	bmh.BiBitCount = 32
+	bmh.BiCompression = BI_BITFIELDS
+	bmh.BV4RedMask = 0x00FF0000
+	bmh.BV4GreenMask = 0x0000FF00
+	bmh.BV4BlueMask = 0x000000FF
+	bmh.BV4AlphaMask = 0xFF000000
+
+	var bits unsafe.Pointer
+	hbmColor := CreateDIBSection(0, &bmh.BITMAPINFOHEADER, DIB_RGB_COLORS, &bits, 0, 0)
+	if hbmColor == 0 {
+		return 0, errors.New("CreateDIBSection failed")
+	}
+	defer DeleteObject(HGDIOBJ(hbmColor))
+
+	pix := (*[1 << 30]byte)(bits)[: 4*w*h : 4*w*h]
+
+	// The rows of monochrome bitmaps are WORD aligned.
+	maskStride := ((w + 15) / 16) * 2
+	mask := make([]byte, maskStride*h)
+
+	for y := 0; y < h; y++ {
+		row := pix[4*w*y:]
+		maskRow := mask[maskStride*y:]
+
+		for x := 0; x < w; x++ {
+			c := color.NRGBAModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA)
+
+			row[4*x+0] = c.B
+			row[4*x+1] = c.G
+			row[4*x+2] = c.R
+			row[4*x+3] = c.A
+
+			if c.A == 0 {
+				maskRow[x/8] |= 0x80 >> uint(x%8)
+			}
+		}
+	}
+
+	hbmMask := CreateBitmap(int32(w), int32(h), 1, 1, unsafe.Pointer(&mask[0]))
+	if hbmMask == 0 {
+		return 0, errors.New("CreateBitmap failed")
+	}
+	defer DeleteObject(HGDIOBJ(hbmMask))
+
+	ii := ICONINFO{
+		FIcon:    TRUE,
+		HbmMask:  hbmMask,
+		HbmColor: hbmColor,
+	}
+	if hotspot != nil {
+		ii.FIcon = FALSE
+		ii.XHotspot = uint32(hotspot.X)
+		ii.YHotspot = uint32(hotspot.Y)
+	}
+
+	hIcon := CreateIconIndirect(&ii)
+	if hIcon == 0 {
+		return 0, errors.New("CreateIconIndirect failed")
+	}
+
+	return hIcon, nil
+}
+
+// ImageFromHICON returns the image of an icon or cursor. Icons without
+// alpha channel, including monochrome ones, are transparent where their
+// mask is set.
+func ImageFromHICON(hIcon HICON) (*image.NRGBA, error) {
+	var ii ICONINFO
+	if !GetIconInfo(hIcon, &ii) {
+		return nil, errors.New("GetIconInfo failed")
+	}
+	defer DeleteObject(HGDIOBJ(ii.HbmMask))
+	if ii.HbmColor != 0 {
+		defer DeleteObject(HGDIOBJ(ii.HbmColor))
+	}
+
+	var bm BITMAP
+	if GetObject(HGDIOBJ(ii.HbmMask), unsafe.Sizeof(bm), unsafe.Pointer(&bm)) == 0 {
+		return nil, errors.New("GetObject failed")
+	}
+
+	w, h := int(bm.BmWidth), int(bm.BmHeight)
+
+	// Monochrome icons have no color bitmap, the mask holds the AND mask
+	// on top of the XOR mask instead.
+	if ii.HbmColor == 0 {
+		h /= 2
+	}
+
+	if w <= 0 || h <= 0 {
+		return nil, errors.New("empty icon")
+	}
+
+	hdc := GetDC(0)
+	if hdc == 0 {
+		return nil, errors.New("GetDC failed")
+	}
+	defer ReleaseDC(0, hdc)
+
+	// GetDIBits converts monochrome bitmaps to black and white pixels.
+	bitmapPixels := func(hbm HBITMAP, lines int) ([]byte, error) {
+		var bmi BITMAPINFO
+		bmi.BmiHeader.BiSize = uint32(unsafe.Sizeof(bmi.BmiHeader))
+		bmi.BmiHeader.BiWidth = int32(w)
+		bmi.BmiHeader.BiHeight = -int32(lines) // top-down
+		bmi.BmiHeader.BiPlanes = 1
+		bmi.BmiHeader.BiBitCount = 32
+		bmi.BmiHeader.BiCompression = BI_RGB
+
+		buf := make([]byte, 4*w*lines)
+		if GetDIBits(hdc, hbm, 0, uint32(lines), &buf[0], &bmi, DIB_RGB_COLORS) == 0 {
+			return nil, errors.New("GetDIBits failed")
+		}
+
+		return buf, nil
+	}
+
+	var colorPix, maskPix []byte
+	var err error
+
+	if ii.HbmColor == 0 {
+		if maskPix, err = bitmapPixels(ii.HbmMask, 2*h); err != nil {
+			return nil, err
+		}
+		colorPix = maskPix[4*w*h:]
+	} else {
+		if colorPix, err = bitmapPixels(ii.HbmColor, h); err != nil {
+			return nil, err
+		}
+		if maskPix, err = bitmapPixels(ii.HbmMask, h); err != nil {
+			return nil, err
+		}
+	}
+
+	hasAlpha := false
+	if ii.HbmColor != 0 {
+		for i := 3; i < 4*w*h; i += 4 {
+			if colorPix[i] != 0 {
+				hasAlpha = true
+				break
+			}
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+
+	for i := 0; i < w*h; i++ {
+		p := img.Pix[4*i:]
+		p[0] = colorPix[4*i+2]
+		p[1] = colorPix[4*i+1]
+		p[2] = colorPix[4*i+0]
+
+		switch {
+		case hasAlpha:
+			p[3] = colorPix[4*i+3]
+
+		case maskPix[4*i] != 0:
+			// Transparent, or inverting the screen, which images cannot
+			// express.
+			p[0], p[1], p[2], p[3] = 0, 0, 0, 0
+
+		default:
+			p[3] = 0xFF
+		}
+	}
+
+	return img, nil
+}
+
+// HICON creates an icon or cursor of f at size pixels, scaled to dpi, from
+// the image that looks best at that size. The caller must destroy the
+// returned handle with DestroyIcon.
+func (f *IconFile) HICON(size int, dpi DPI) (HICON, error) {
+	size = int(dpi.Scale(int32(size)))
+
+	img := f.Best(size)
+	if img == nil {
+		return 0, ErrInvalidIconFile
+	}
+
+	data, err := img.resource(f.Cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	hIcon := CreateIconFromResourceEx(&data[0], uint32(len(data)), !f.Cursor, 0x00030000, int32(size), int32(size), LR_DEFAULTCOLOR)
+	if hIcon == 0 {
+		return 0, errors.New("CreateIconFromResourceEx failed")
+	}
+
+	return hIcon, nil
+}
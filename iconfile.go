@@ -0,0 +1,346 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// The encoder and decoder in this file work on the contents of .ico and
+// .cur files and do not call into Windows.
+
+var ErrInvalidIconFile = errors.New("invalid icon file")
+
+const (
+	sizeofICONDIR      = 6
+	sizeofICONDIRENTRY = 16
+)
+
+// IconImage is an image of an .ico or .cur file.
+type IconImage struct {
+	Image    image.Image
+	Hotspot  image.Point // cursors only
+	BitCount int         // bits per pixel of the stored bitmap
+	PNG      bool        // stored PNG compressed
+}
+
+// IconFile is the contents of an .ico or .cur file, which holds the same
+// icon or cursor in several sizes and color depths.
+type IconFile struct {
+	Cursor bool
+	Images []*IconImage
+}
+
+// DecodeIconFile decodes .ico or .cur data. Entries may be PNG compressed
+// or uncompressed 1, 4, 8, 24 or 32 bpp bitmaps with an AND mask.
+func DecodeIconFile(data []byte) (*IconFile, error) {
+	le := binary.LittleEndian
+
+	if len(data) < sizeofICONDIR || le.Uint16(data[0:]) != 0 {
+		return nil, ErrInvalidIconFile
+	}
+
+	f := new(IconFile)
+	switch le.Uint16(data[2:]) {
+	case 1:
+
+	case 2:
+		f.Cursor = true
+
+	default:
+		return nil, ErrInvalidIconFile
+	}
+
+	count := int(le.Uint16(data[4:]))
+	if len(data) < sizeofICONDIR+count*sizeofICONDIRENTRY {
+		return nil, ErrInvalidIconFile
+	}
+
+	for i := 0; i < count; i++ {
+		e := data[sizeofICONDIR+i*sizeofICONDIRENTRY:]
+
+		size := int(le.Uint32(e[8:]))
+		offset := int(le.Uint32(e[12:]))
+		if offset < 0 || size < 0 || offset+size > len(data) || offset+size < offset {
+			return nil, ErrInvalidIconFile
+		}
+
+		img, err := decodeIconImage(data[offset : offset+size])
+		if err != nil {
+			return nil, err
+		}
+
+		if f.Cursor {
+			img.Hotspot = image.Pt(int(le.Uint16(e[4:])), int(le.Uint16(e[6:])))
+		}
+
+		f.Images = append(f.Images, img)
+	}
+
+	return f, nil
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// decodeIconImage decodes the data of an icon directory entry, which is a
+// PNG file or a DIB of twice the height of the image, holding the color
+// bitmap followed by the AND mask.
+func decodeIconImage(data []byte) (*IconImage, error) {
+	if bytes.HasPrefix(data, pngSignature) {
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+
+		return &IconImage{Image: img, BitCount: 32, PNG: true}, nil
+	}
+
+	le := binary.LittleEndian
+
+	if len(data) < sizeofBITMAPINFOHEADER || le.Uint32(data[0:]) != sizeofBITMAPINFOHEADER {
+		return nil, ErrUnsupportedDIB
+	}
+
+	width := int(int32(le.Uint32(data[4:])))
+	height := int(int32(le.Uint32(data[8:]))) / 2
+	bitCount := int(le.Uint16(data[14:]))
+	clrUsed := int(le.Uint32(data[32:]))
+
+	if width <= 0 || height <= 0 || le.Uint32(data[16:]) != BI_RGB {
+		return nil, ErrUnsupportedDIB
+	}
+
+	// DecodeDIB reads the color bitmap if given the real height.
+	dib := append([]byte(nil), data...)
+	le.PutUint32(dib[8:], uint32(height))
+
+	img, err := DecodeDIB(dib)
+	if err != nil {
+		return nil, err
+	}
+
+	colors := 0
+	if bitCount <= 8 {
+		colors = clrUsed
+		if colors == 0 || colors > 1<<uint(bitCount) {
+			colors = 1 << uint(bitCount)
+		}
+	}
+
+	// The sizes are computed in 64 bits so that they cannot overflow an int
+	// on 32-bit platforms.
+	maskOffset := int64(sizeofBITMAPINFOHEADER+4*colors) + int64((width*bitCount+31)/32*4)*int64(height)
+	maskStride := ((width + 31) / 32) * 4
+
+	// Bitmaps without alpha channel are transparent where the mask is
+	// set, as are 32 bpp bitmaps whose alpha channel is unused.
+	if (bitCount < 32 || isOpaque(img)) && int64(len(data)) >= maskOffset+int64(maskStride)*int64(height) {
+		mask := data[maskOffset:]
+
+		for y := 0; y < height; y++ {
+			row := mask[maskStride*(height-1-y):]
+			for x := 0; x < width; x++ {
+				if row[x/8]&(0x80>>uint(x%8)) != 0 {
+					img.Pix[img.Stride*y+4*x+3] = 0
+				}
+			}
+		}
+	}
+
+	return &IconImage{Image: img, BitCount: bitCount}, nil
+}
+
+func isOpaque(img *image.NRGBA) bool {
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] != 0xFF {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Encode returns f as .ico or .cur data. Images of 256 pixels or more, and
+// those with PNG set, are PNG compressed, the others are stored as 32 bpp
+// bitmaps, which Windows XP and later support for all sizes.
+func (f *IconFile) Encode() ([]byte, error) {
+	if len(f.Images) > 0xFFFF {
+		return nil, errors.New("too many icon images")
+	}
+
+	le := binary.LittleEndian
+
+	header := make([]byte, sizeofICONDIR+len(f.Images)*sizeofICONDIRENTRY)
+
+	typ := uint16(1)
+	if f.Cursor {
+		typ = 2
+	}
+	le.PutUint16(header[2:], typ)
+	le.PutUint16(header[4:], uint16(len(f.Images)))
+
+	var body []byte
+	for i, img := range f.Images {
+		data, err := img.encode()
+		if err != nil {
+			return nil, err
+		}
+
+		b := img.Image.Bounds()
+
+		e := header[sizeofICONDIR+i*sizeofICONDIRENTRY:]
+		e[0] = iconDirSize(b.Dx())
+		e[1] = iconDirSize(b.Dy())
+		if f.Cursor {
+			le.PutUint16(e[4:], uint16(img.Hotspot.X))
+			le.PutUint16(e[6:], uint16(img.Hotspot.Y))
+		} else {
+			le.PutUint16(e[4:], 1)  // planes
+			le.PutUint16(e[6:], 32) // bit count
+		}
+		le.PutUint32(e[8:], uint32(len(data)))
+		le.PutUint32(e[12:], uint32(len(header)+len(body)))
+
+		body = append(body, data...)
+	}
+
+	return append(header, body...), nil
+}
+
+// iconDirSize returns the ICONDIRENTRY width or height byte of n, where 0
+// means 256 or more.
+func iconDirSize(n int) byte {
+	if n >= 256 {
+		return 0
+	}
+
+	return byte(n)
+}
+
+// encode returns the data of the directory entry of img.
+func (img *IconImage) encode() ([]byte, error) {
+	b := img.Image.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	if w <= 0 || h <= 0 {
+		return nil, errors.New("empty icon image")
+	}
+
+	if img.PNG || w >= 256 || h >= 256 {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img.Image); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	le := binary.LittleEndian
+
+	stride := 4 * w
+	maskStride := ((w + 31) / 32) * 4
+
+	data := make([]byte, sizeofBITMAPINFOHEADER+stride*h+maskStride*h)
+
+	le.PutUint32(data[0:], sizeofBITMAPINFOHEADER)
+	le.PutUint32(data[4:], uint32(w))
+	le.PutUint32(data[8:], uint32(2*h))
+	le.PutUint16(data[12:], 1)  // planes
+	le.PutUint16(data[14:], 32) // bit count
+	le.PutUint32(data[20:], uint32(stride*h+maskStride*h))
+
+	pix := data[sizeofBITMAPINFOHEADER:]
+	mask := pix[stride*h:]
+
+	for y := 0; y < h; y++ {
+		row := pix[stride*(h-1-y):]
+		maskRow := mask[maskStride*(h-1-y):]
+
+		for x := 0; x < w; x++ {
+			c := color.NRGBAModel.Convert(img.Image.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA)
+
+			row[4*x+0] = c.B
+			row[4*x+1] = c.G
+			row[4*x+2] = c.R
+			row[4*x+3] = c.A
+
+			if c.A == 0 {
+				maskRow[x/8] |= 0x80 >> uint(x%8)
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// resource returns img in the format of RT_ICON and RT_CURSOR resources,
+// as CreateIconFromResourceEx expects it. Cursor resources start with the
+// hotspot.
+func (img *IconImage) resource(cursor bool) ([]byte, error) {
+	data, err := img.encode()
+	if err != nil || !cursor {
+		return data, err
+	}
+
+	res := make([]byte, 4+len(data))
+	binary.LittleEndian.PutUint16(res[0:], uint16(img.Hotspot.X))
+	binary.LittleEndian.PutUint16(res[2:], uint16(img.Hotspot.Y))
+	copy(res[4:], data)
+
+	return res, nil
+}
+
+// Best returns the image that looks best when shown at size pixels: an
+// image of that size with the most colors, else the smallest larger one,
+// which scales down well, else the largest one. It returns nil if f has no
+// images.
+func (f *IconFile) Best(size int) *IconImage {
+	var best *IconImage
+	var bestSize int
+
+	better := func(img *IconImage, s int) bool {
+		switch {
+		case best == nil:
+			return true
+
+		case s == bestSize:
+			return img.BitCount > best.BitCount
+
+		case bestSize == size:
+			return false
+
+		case s == size:
+			return true
+
+		case s > size && bestSize > size:
+			return s < bestSize
+
+		case s > size || bestSize > size:
+			return s > size
+		}
+
+		return s > bestSize
+	}
+
+	for _, img := range f.Images {
+		b := img.Image.Bounds()
+
+		s := b.Dx()
+		if b.Dy() > s {
+			s = b.Dy()
+		}
+
+		if better(img, s) {
+			best, bestSize = img, s
+		}
+	}
+
+	return best
+}
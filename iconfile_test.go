@@ -0,0 +1,181 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func testIconImage(size int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{uint8(x), uint8(y), 0x80, uint8(x + y)})
+		}
+	}
+
+	return img
+}
+
+func TestIconFileRoundTrip(t *testing.T) {
+	for _, cursor := range []bool{false, true} {
+		f := &IconFile{
+			Cursor: cursor,
+			Images: []*IconImage{
+				{Image: testIconImage(16), BitCount: 32},
+				{Image: testIconImage(33), BitCount: 32, PNG: true},
+				{Image: testIconImage(256), BitCount: 32},
+			},
+		}
+		if cursor {
+			for i, img := range f.Images {
+				img.Hotspot = image.Pt(i, 2*i)
+			}
+		}
+
+		data, err := f.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := DecodeIconFile(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Cursor != cursor || len(got.Images) != len(f.Images) {
+			t.Fatalf("DecodeIconFile: Cursor = %v, %d images", got.Cursor, len(got.Images))
+		}
+
+		for i, img := range got.Images {
+			want := f.Images[i]
+			wantPNG := want.PNG || i == 2
+
+			if img.PNG != wantPNG || img.BitCount != 32 || img.Hotspot != want.Hotspot {
+				t.Errorf("image %d: PNG = %v, BitCount = %d, Hotspot = %v", i, img.PNG, img.BitCount, img.Hotspot)
+			}
+
+			// PNG decodes to NRGBA as well, as the images hold alpha.
+			if nrgba, ok := img.Image.(*image.NRGBA); !ok || !reflect.DeepEqual(nrgba.Pix, want.Image.(*image.NRGBA).Pix) {
+				t.Errorf("image %d differs", i)
+			}
+		}
+	}
+}
+
+// iconDIB returns an icon directory entry of a width x height bitmap with
+// an AND mask.
+func iconDIB(width, height int32, bitCount uint16, pixels, mask []byte) []byte {
+	h := dibHeader(width, 2*height, bitCount, BI_RGB, 0)
+
+	return append(append(h, pixels...), mask...)
+}
+
+func TestDecodeIconImageMask(t *testing.T) {
+	// A 2x1 24 bpp bitmap, whose right pixel is masked out.
+	img, err := decodeIconImage(iconDIB(2, 1, 24,
+		[]byte{0xFF, 0, 0, 0, 0xFF, 0, 0, 0},
+		[]byte{0x40, 0, 0, 0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nrgba := img.Image.(*image.NRGBA)
+	if c := nrgba.NRGBAAt(0, 0); c != (color.NRGBA{0, 0, 0xFF, 0xFF}) {
+		t.Errorf("left pixel = %v", c)
+	}
+	if c := nrgba.NRGBAAt(1, 0); c.A != 0 {
+		t.Errorf("right pixel = %v, want transparent", c)
+	}
+
+	// Without the mask, the bitmap is opaque.
+	img, err = decodeIconImage(iconDIB(2, 1, 24, []byte{0xFF, 0, 0, 0, 0xFF, 0, 0, 0}, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := img.Image.(*image.NRGBA).NRGBAAt(1, 0); c.A != 0xFF {
+		t.Errorf("right pixel without mask = %v, want opaque", c)
+	}
+}
+
+func TestDecodeIconFileInvalid(t *testing.T) {
+	valid, err := (&IconFile{Images: []*IconImage{{Image: testIconImage(4)}}}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modified := func(offset int, v uint32) []byte {
+		b := append([]byte(nil), valid...)
+		binary.LittleEndian.PutUint32(b[offset:], v)
+		return b
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"empty", nil, ErrInvalidIconFile},
+		{"type", modified(0, 3<<16), ErrInvalidIconFile},
+		{"truncated directory", valid[:sizeofICONDIR+sizeofICONDIRENTRY-1], ErrInvalidIconFile},
+		{"truncated image", valid[:len(valid)-1], ErrInvalidIconFile},
+		{"size beyond data", modified(sizeofICONDIR+8, uint32(len(valid))), ErrInvalidIconFile},
+		{"huge offset", modified(sizeofICONDIR+12, 0xFFFFFFFF), ErrInvalidIconFile},
+		{"huge size", modified(sizeofICONDIR+8, 0xFFFFFFFF), ErrInvalidIconFile},
+	}
+
+	for _, tt := range tests {
+		if _, err := DecodeIconFile(tt.data); err != tt.err {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.err)
+		}
+	}
+
+	// Sizes whose pixel data does not fit in 32 bits.
+	for _, data := range [][]byte{
+		iconDIB(1<<16, 1<<15, 32, make([]byte, 64), nil),
+		iconDIB(1<<16, 1<<15, 1, make([]byte, 64), nil),
+	} {
+		if _, err := decodeIconImage(data); err != ErrInvalidClipboardData {
+			t.Errorf("decodeIconImage of %dx%d: err = %v, want ErrInvalidClipboardData",
+				binary.LittleEndian.Uint32(data[4:]), binary.LittleEndian.Uint32(data[8:])/2, err)
+		}
+	}
+}
+
+func TestIconFileBest(t *testing.T) {
+	images := []*IconImage{
+		{Image: testIconImage(16), BitCount: 8},
+		{Image: testIconImage(16), BitCount: 32},
+		{Image: testIconImage(32), BitCount: 32},
+		{Image: testIconImage(48), BitCount: 32},
+	}
+	f := &IconFile{Images: images}
+
+	tests := []struct {
+		size int
+		want *IconImage
+	}{
+		{16, images[1]},
+		{20, images[2]},
+		{32, images[2]},
+		{40, images[3]},
+		{64, images[3]},
+		{8, images[1]},
+	}
+
+	for _, tt := range tests {
+		if got := f.Best(tt.size); got != tt.want {
+			t.Errorf("Best(%d) = %v", tt.size, got.Image.Bounds())
+		}
+	}
+
+	if (&IconFile{}).Best(16) != nil {
+		t.Error("Best of an empty IconFile is not nil")
+	}
+}
@@ -0,0 +1,517 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"unicode/utf16"
+	"unsafe"
+)
+
+// Virtual key codes
+const (
+	VK_LBUTTON             = 1
+	VK_RBUTTON             = 2
+	VK_CANCEL              = 3
+	VK_MBUTTON             = 4
+	VK_XBUTTON1            = 5
+	VK_XBUTTON2            = 6
+	VK_BACK                = 8
+	VK_TAB                 = 9
+	VK_CLEAR               = 12
+	VK_RETURN              = 13
+	VK_SHIFT               = 16
+	VK_CONTROL             = 17
+	VK_MENU                = 18
+	VK_PAUSE               = 19
+	VK_CAPITAL             = 20
+	VK_KANA                = 0x15
+	VK_HANGEUL             = 0x15
+	VK_HANGUL              = 0x15
+	VK_JUNJA               = 0x17
+	VK_FINAL               = 0x18
+	VK_HANJA               = 0x19
+	VK_KANJI               = 0x19
+	VK_ESCAPE              = 0x1B
+	VK_CONVERT             = 0x1C
+	VK_NONCONVERT          = 0x1D
+	VK_ACCEPT              = 0x1E
+	VK_MODECHANGE          = 0x1F
+	VK_SPACE               = 32
+	VK_PRIOR               = 33
+	VK_NEXT                = 34
+	VK_END                 = 35
+	VK_HOME                = 36
+	VK_LEFT                = 37
+	VK_UP                  = 38
+	VK_RIGHT               = 39
+	VK_DOWN                = 40
+	VK_SELECT              = 41
+	VK_PRINT               = 42
+	VK_EXECUTE             = 43
+	VK_SNAPSHOT            = 44
+	VK_INSERT              = 45
+	VK_DELETE              = 46
+	VK_HELP                = 47
+	VK_LWIN                = 0x5B
+	VK_RWIN                = 0x5C
+	VK_APPS                = 0x5D
+	VK_SLEEP               = 0x5F
+	VK_NUMPAD0             = 0x60
+	VK_NUMPAD1             = 0x61
+	VK_NUMPAD2             = 0x62
+	VK_NUMPAD3             = 0x63
+	VK_NUMPAD4             = 0x64
+	VK_NUMPAD5             = 0x65
+	VK_NUMPAD6             = 0x66
+	VK_NUMPAD7             = 0x67
+	VK_NUMPAD8             = 0x68
+	VK_NUMPAD9             = 0x69
+	VK_MULTIPLY            = 0x6A
+	VK_ADD                 = 0x6B
+	VK_SEPARATOR           = 0x6C
+	VK_SUBTRACT            = 0x6D
+	VK_DECIMAL             = 0x6E
+	VK_DIVIDE              = 0x6F
+	VK_F1                  = 0x70
+	VK_F2                  = 0x71
+	VK_F3                  = 0x72
+	VK_F4                  = 0x73
+	VK_F5                  = 0x74
+	VK_F6                  = 0x75
+	VK_F7                  = 0x76
+	VK_F8                  = 0x77
+	VK_F9                  = 0x78
+	VK_F10                 = 0x79
+	VK_F11                 = 0x7A
+	VK_F12                 = 0x7B
+	VK_F13                 = 0x7C
+	VK_F14                 = 0x7D
+	VK_F15                 = 0x7E
+	VK_F16                 = 0x7F
+	VK_F17                 = 0x80
+	VK_F18                 = 0x81
+	VK_F19                 = 0x82
+	VK_F20                 = 0x83
+	VK_F21                 = 0x84
+	VK_F22                 = 0x85
+	VK_F23                 = 0x86
+	VK_F24                 = 0x87
+	VK_NUMLOCK             = 0x90
+	VK_SCROLL              = 0x91
+	VK_LSHIFT              = 0xA0
+	VK_RSHIFT              = 0xA1
+	VK_LCONTROL            = 0xA2
+	VK_RCONTROL            = 0xA3
+	VK_LMENU               = 0xA4
+	VK_RMENU               = 0xA5
+	VK_BROWSER_BACK        = 0xA6
+	VK_BROWSER_FORWARD     = 0xA7
+	VK_BROWSER_REFRESH     = 0xA8
+	VK_BROWSER_STOP        = 0xA9
+	VK_BROWSER_SEARCH      = 0xAA
+	VK_BROWSER_FAVORITES   = 0xAB
+	VK_BROWSER_HOME        = 0xAC
+	VK_VOLUME_MUTE         = 0xAD
+	VK_VOLUME_DOWN         = 0xAE
+	VK_VOLUME_UP           = 0xAF
+	VK_MEDIA_NEXT_TRACK    = 0xB0
+	VK_MEDIA_PREV_TRACK    = 0xB1
+	VK_MEDIA_STOP          = 0xB2
+	VK_MEDIA_PLAY_PAUSE    = 0xB3
+	VK_LAUNCH_MAIL         = 0xB4
+	VK_LAUNCH_MEDIA_SELECT = 0xB5
+	VK_LAUNCH_APP1         = 0xB6
+	VK_LAUNCH_APP2         = 0xB7
+	VK_OEM_1               = 0xBA
+	VK_OEM_PLUS            = 0xBB
+	VK_OEM_COMMA           = 0xBC
+	VK_OEM_MINUS           = 0xBD
+	VK_OEM_PERIOD          = 0xBE
+	VK_OEM_2               = 0xBF
+	VK_OEM_3               = 0xC0
+	VK_OEM_4               = 0xDB
+	VK_OEM_5               = 0xDC
+	VK_OEM_6               = 0xDD
+	VK_OEM_7               = 0xDE
+	VK_OEM_8               = 0xDF
+	VK_OEM_102             = 0xE2
+	VK_PROCESSKEY          = 0xE5
+	VK_PACKET              = 0xE7
+	VK_ATTN                = 0xF6
+	VK_CRSEL               = 0xF7
+	VK_EXSEL               = 0xF8
+	VK_EREOF               = 0xF9
+	VK_PLAY                = 0xFA
+	VK_ZOOM                = 0xFB
+	VK_NONAME              = 0xFC
+	VK_PA1                 = 0xFD
+	VK_OEM_CLEAR           = 0xFE
+)
+
+// INPUT Type
+const (
+	INPUT_MOUSE    = 0
+	INPUT_KEYBOARD = 1
+	INPUT_HARDWARE = 2
+)
+
+// MOUSEINPUT MouseData
+const (
+	XBUTTON1 = 0x0001
+	XBUTTON2 = 0x0002
+)
+
+// MOUSEINPUT DwFlags
+const (
+	MOUSEEVENTF_ABSOLUTE        = 0x8000
+	MOUSEEVENTF_HWHEEL          = 0x1000
+	MOUSEEVENTF_MOVE            = 0x0001
+	MOUSEEVENTF_MOVE_NOCOALESCE = 0x2000
+	MOUSEEVENTF_LEFTDOWN        = 0x0002
+	MOUSEEVENTF_LEFTUP          = 0x0004
+	MOUSEEVENTF_RIGHTDOWN       = 0x0008
+	MOUSEEVENTF_RIGHTUP         = 0x0010
+	MOUSEEVENTF_MIDDLEDOWN      = 0x0020
+	MOUSEEVENTF_MIDDLEUP        = 0x0040
+	MOUSEEVENTF_VIRTUALDESK     = 0x4000
+	MOUSEEVENTF_WHEEL           = 0x0800
+	MOUSEEVENTF_XDOWN           = 0x0080
+	MOUSEEVENTF_XUP             = 0x0100
+)
+
+// KEYBDINPUT DwFlags
+const (
+	KEYEVENTF_EXTENDEDKEY = 0x0001
+	KEYEVENTF_KEYUP       = 0x0002
+	KEYEVENTF_SCANCODE    = 0x0008
+	KEYEVENTF_UNICODE     = 0x0004
+)
+
+type MOUSE_INPUT struct {
+	Type uint32
+	Mi   MOUSEINPUT
+}
+
+type MOUSEINPUT struct {
+	Dx          int32
+	Dy          int32
+	MouseData   uint32
+	DwFlags     uint32
+	Time        uint32
+	DwExtraInfo uintptr
+}
+
+type KEYBD_INPUT struct {
+	Type uint32
+	Ki   KEYBDINPUT
+}
+
+type KEYBDINPUT struct {
+	WVk         uint16
+	WScan       uint16
+	DwFlags     uint32
+	Time        uint32
+	DwExtraInfo uintptr
+	Unused      [8]byte
+}
+
+type HARDWARE_INPUT struct {
+	Type uint32
+	Hi   HARDWAREINPUT
+}
+
+type HARDWAREINPUT struct {
+	UMsg    uint32
+	WParamL uint16
+	WParamH uint16
+	Unused  [16]byte
+}
+
+// Input is one INPUT record of a SendInput batch. Depending on Type, either
+// Mouse or Keyboard is used.
+type Input struct {
+	Type     uint32 // INPUT_MOUSE or INPUT_KEYBOARD
+	Mouse    MOUSEINPUT
+	Keyboard KEYBDINPUT
+}
+
+// MouseButton identifies a mouse button for InputBuilder.
+type MouseButton int
+
+const (
+	LeftButton MouseButton = iota
+	RightButton
+	MiddleButton
+	XButton1
+	XButton2
+)
+
+// InputBuilder collects keyboard and mouse input and injects it with a
+// single SendInput call, so that other input cannot interleave with it.
+//
+// The methods append to the batch and return the builder, so calls can be
+// chained. Nothing is sent until Send is called.
+type InputBuilder struct {
+	// Desktop is the virtual desktop rectangle used to normalize absolute
+	// mouse coordinates. NewInputBuilder initializes it from
+	// GetSystemMetrics.
+	Desktop RECT
+
+	inputs []Input
+}
+
+// Inputs returns the batch built so far. The slice is owned by the builder.
+func (b *InputBuilder) Inputs() []Input {
+	return b.inputs
+}
+
+// Len returns the number of INPUT records in the batch.
+func (b *InputBuilder) Len() int {
+	return len(b.inputs)
+}
+
+// Reset empties the batch.
+func (b *InputBuilder) Reset() *InputBuilder {
+	b.inputs = b.inputs[:0]
+	return b
+}
+
+func (b *InputBuilder) key(vk, scan uint16, flags uint32) *InputBuilder {
+	b.inputs = append(b.inputs, Input{
+		Type: INPUT_KEYBOARD,
+		Keyboard: KEYBDINPUT{
+			WVk:     vk,
+			WScan:   scan,
+			DwFlags: flags,
+		},
+	})
+
+	return b
+}
+
+func (b *InputBuilder) mouse(dx, dy int32, data, flags uint32) *InputBuilder {
+	b.inputs = append(b.inputs, Input{
+		Type: INPUT_MOUSE,
+		Mouse: MOUSEINPUT{
+			Dx:        dx,
+			Dy:        dy,
+			MouseData: data,
+			DwFlags:   flags,
+		},
+	})
+
+	return b
+}
+
+// isExtendedKey reports whether vk is on the extended part of the keyboard
+// and needs KEYEVENTF_EXTENDEDKEY to be told apart from its numpad twin.
+func isExtendedKey(vk uint16) bool {
+	switch vk {
+	case VK_RCONTROL, VK_RMENU, VK_INSERT, VK_DELETE, VK_HOME, VK_END, VK_PRIOR, VK_NEXT,
+		VK_LEFT, VK_UP, VK_RIGHT, VK_DOWN, VK_NUMLOCK, VK_DIVIDE, VK_SNAPSHOT, VK_CANCEL,
+		VK_LWIN, VK_RWIN, VK_APPS:
+		return true
+	}
+
+	return false
+}
+
+func keyFlags(vk uint16) uint32 {
+	if isExtendedKey(vk) {
+		return KEYEVENTF_EXTENDEDKEY
+	}
+
+	return 0
+}
+
+// KeyDown presses the virtual key vk.
+func (b *InputBuilder) KeyDown(vk uint16) *InputBuilder {
+	return b.key(vk, 0, keyFlags(vk))
+}
+
+// KeyUp releases the virtual key vk.
+func (b *InputBuilder) KeyUp(vk uint16) *InputBuilder {
+	return b.key(vk, 0, keyFlags(vk)|KEYEVENTF_KEYUP)
+}
+
+// KeyPress presses and releases the virtual key vk.
+func (b *InputBuilder) KeyPress(vk uint16) *InputBuilder {
+	return b.KeyDown(vk).KeyUp(vk)
+}
+
+// KeyChord presses keys in order and releases them in reverse order, e.g.
+// KeyChord(VK_CONTROL, 'C') for Ctrl+C.
+func (b *InputBuilder) KeyChord(keys ...uint16) *InputBuilder {
+	for _, vk := range keys {
+		b.KeyDown(vk)
+	}
+
+	for i := len(keys) - 1; i >= 0; i-- {
+		b.KeyUp(keys[i])
+	}
+
+	return b
+}
+
+// TypeText types s independently of the keyboard layout by sending each
+// UTF-16 code unit with KEYEVENTF_UNICODE. Characters outside the BMP are
+// sent as surrogate pairs. "\n" and "\r\n" are sent as VK_RETURN and "\t" as
+// VK_TAB, since many controls ignore them as characters.
+func (b *InputBuilder) TypeText(s string) *InputBuilder {
+	var units [2]uint16
+
+	for i, r := range s {
+		switch r {
+		case '\r':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				continue
+			}
+			b.KeyPress(VK_RETURN)
+			continue
+
+		case '\n':
+			b.KeyPress(VK_RETURN)
+			continue
+
+		case '\t':
+			b.KeyPress(VK_TAB)
+			continue
+		}
+
+		n := 1
+		if r >= 0x10000 {
+			r1, r2 := utf16.EncodeRune(r)
+			units[0], units[1] = uint16(r1), uint16(r2)
+			n = 2
+		} else {
+			units[0] = uint16(r)
+		}
+
+		for _, u := range units[:n] {
+			b.key(0, u, KEYEVENTF_UNICODE)
+			b.key(0, u, KEYEVENTF_UNICODE|KEYEVENTF_KEYUP)
+		}
+	}
+
+	return b
+}
+
+// NormalizeVirtualDesktop maps the screen coordinates x, y to the 0..65535
+// range used by MOUSEEVENTF_ABSOLUTE|MOUSEEVENTF_VIRTUALDESK, where 0 and
+// 65535 are the first and last pixel of desktop.
+func NormalizeVirtualDesktop(x, y int32, desktop RECT) (nx, ny int32) {
+	return normalizeAxis(x, desktop.Left, desktop.Right), normalizeAxis(y, desktop.Top, desktop.Bottom)
+}
+
+func normalizeAxis(v, min, max int32) int32 {
+	last := int64(max) - int64(min) - 1
+	if last <= 0 {
+		return 0
+	}
+
+	n := int64(v) - int64(min)
+	if n < 0 {
+		n = 0
+	} else if n > last {
+		n = last
+	}
+
+	// Rounding to nearest makes the system map the value back to the
+	// same pixel.
+	return int32((n*65535 + last/2) / last)
+}
+
+// MouseMove moves the cursor to the screen coordinates x, y, which may lie
+// on any monitor of the virtual desktop.
+func (b *InputBuilder) MouseMove(x, y int32) *InputBuilder {
+	nx, ny := NormalizeVirtualDesktop(x, y, b.Desktop)
+
+	return b.mouse(nx, ny, 0, MOUSEEVENTF_MOVE|MOUSEEVENTF_ABSOLUTE|MOUSEEVENTF_VIRTUALDESK)
+}
+
+// MouseMoveBy moves the cursor relative to its current position. The
+// distance is subject to mouse acceleration settings.
+func (b *InputBuilder) MouseMoveBy(dx, dy int32) *InputBuilder {
+	return b.mouse(dx, dy, 0, MOUSEEVENTF_MOVE)
+}
+
+func buttonFlags(button MouseButton) (down, up, data uint32) {
+	switch button {
+	case RightButton:
+		return MOUSEEVENTF_RIGHTDOWN, MOUSEEVENTF_RIGHTUP, 0
+
+	case MiddleButton:
+		return MOUSEEVENTF_MIDDLEDOWN, MOUSEEVENTF_MIDDLEUP, 0
+
+	case XButton1:
+		return MOUSEEVENTF_XDOWN, MOUSEEVENTF_XUP, XBUTTON1
+
+	case XButton2:
+		return MOUSEEVENTF_XDOWN, MOUSEEVENTF_XUP, XBUTTON2
+	}
+
+	return MOUSEEVENTF_LEFTDOWN, MOUSEEVENTF_LEFTUP, 0
+}
+
+// MouseDown presses button at the current cursor position.
+func (b *InputBuilder) MouseDown(button MouseButton) *InputBuilder {
+	down, _, data := buttonFlags(button)
+	return b.mouse(0, 0, data, down)
+}
+
+// MouseUp releases button at the current cursor position.
+func (b *InputBuilder) MouseUp(button MouseButton) *InputBuilder {
+	_, up, data := buttonFlags(button)
+	return b.mouse(0, 0, data, up)
+}
+
+// Click presses and releases button.
+func (b *InputBuilder) Click(button MouseButton) *InputBuilder {
+	return b.MouseDown(button).MouseUp(button)
+}
+
+// DoubleClick clicks button twice. Both clicks are injected at once, so
+// they are always within the double-click time.
+func (b *InputBuilder) DoubleClick(button MouseButton) *InputBuilder {
+	return b.Click(button).Click(button)
+}
+
+// ClickAt moves the cursor to x, y and clicks button there.
+func (b *InputBuilder) ClickAt(x, y int32, button MouseButton) *InputBuilder {
+	return b.MouseMove(x, y).Click(button)
+}
+
+// Wheel rotates the vertical wheel by delta, in multiples or fractions of
+// WHEEL_DELTA. Positive values scroll away from the user.
+func (b *InputBuilder) Wheel(delta int32) *InputBuilder {
+	return b.mouse(0, 0, uint32(delta), MOUSEEVENTF_WHEEL)
+}
+
+// HWheel rotates the horizontal wheel by delta. Positive values scroll to
+// the right.
+func (b *InputBuilder) HWheel(delta int32) *InputBuilder {
+	return b.mouse(0, 0, uint32(delta), MOUSEEVENTF_HWHEEL)
+}
+
+// marshal converts the batch to the memory layout of an INPUT array.
+// MOUSE_INPUT and KEYBD_INPUT both have the size of INPUT on every
+// architecture, so a MOUSE_INPUT slice provides the backing storage.
+func (b *InputBuilder) marshal() []MOUSE_INPUT {
+	buf := make([]MOUSE_INPUT, len(b.inputs))
+
+	for i := range b.inputs {
+		in := &b.inputs[i]
+
+		switch in.Type {
+		case INPUT_KEYBOARD:
+			*(*KEYBD_INPUT)(unsafe.Pointer(&buf[i])) = KEYBD_INPUT{Type: INPUT_KEYBOARD, Ki: in.Keyboard}
+
+		default:
+			buf[i] = MOUSE_INPUT{Type: in.Type, Mi: in.Mouse}
+		}
+	}
+
+	return buf
+}
@@ -0,0 +1,153 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+func keyInput(vk, scan uint16, flags uint32) Input {
+	return Input{Type: INPUT_KEYBOARD, Keyboard: KEYBDINPUT{WVk: vk, WScan: scan, DwFlags: flags}}
+}
+
+func mouseInput(dx, dy int32, data, flags uint32) Input {
+	return Input{Type: INPUT_MOUSE, Mouse: MOUSEINPUT{Dx: dx, Dy: dy, MouseData: data, DwFlags: flags}}
+}
+
+func checkInputs(t *testing.T, name string, b *InputBuilder, want []Input) {
+	t.Helper()
+
+	if got := b.Inputs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("%s:\ngot  %+v\nwant %+v", name, got, want)
+	}
+}
+
+func TestInputBuilderKeyChord(t *testing.T) {
+	b := new(InputBuilder).KeyChord(VK_CONTROL, VK_SHIFT, 'S')
+	checkInputs(t, "Ctrl+Shift+S", b, []Input{
+		keyInput(VK_CONTROL, 0, 0),
+		keyInput(VK_SHIFT, 0, 0),
+		keyInput('S', 0, 0),
+		keyInput('S', 0, KEYEVENTF_KEYUP),
+		keyInput(VK_SHIFT, 0, KEYEVENTF_KEYUP),
+		keyInput(VK_CONTROL, 0, KEYEVENTF_KEYUP),
+	})
+
+	// Keys of the extended part of the keyboard are flagged as such.
+	b = new(InputBuilder).KeyChord(VK_RCONTROL, VK_MENU, VK_DELETE)
+	checkInputs(t, "RCtrl+Alt+Del", b, []Input{
+		keyInput(VK_RCONTROL, 0, KEYEVENTF_EXTENDEDKEY),
+		keyInput(VK_MENU, 0, 0),
+		keyInput(VK_DELETE, 0, KEYEVENTF_EXTENDEDKEY),
+		keyInput(VK_DELETE, 0, KEYEVENTF_EXTENDEDKEY|KEYEVENTF_KEYUP),
+		keyInput(VK_MENU, 0, KEYEVENTF_KEYUP),
+		keyInput(VK_RCONTROL, 0, KEYEVENTF_EXTENDEDKEY|KEYEVENTF_KEYUP),
+	})
+
+	if b.Len() != 6 {
+		t.Errorf("Len = %d, want 6", b.Len())
+	}
+	if b.Reset().Len() != 0 {
+		t.Errorf("Len after Reset = %d, want 0", b.Len())
+	}
+}
+
+func TestInputBuilderTypeText(t *testing.T) {
+	unicode := func(units ...uint16) []Input {
+		var inputs []Input
+		for _, u := range units {
+			inputs = append(inputs,
+				keyInput(0, u, KEYEVENTF_UNICODE),
+				keyInput(0, u, KEYEVENTF_UNICODE|KEYEVENTF_KEYUP))
+		}
+		return inputs
+	}
+
+	press := func(vk uint16) []Input {
+		return []Input{keyInput(vk, 0, 0), keyInput(vk, 0, KEYEVENTF_KEYUP)}
+	}
+
+	var want []Input
+	want = append(want, unicode('a', 0xE9)...)
+	// U+1F600 is sent as a surrogate pair.
+	want = append(want, unicode(0xD83D, 0xDE00)...)
+	want = append(want, press(VK_RETURN)...)
+	want = append(want, press(VK_RETURN)...)
+	want = append(want, press(VK_RETURN)...)
+	want = append(want, press(VK_TAB)...)
+	want = append(want, unicode('z')...)
+
+	checkInputs(t, "TypeText", new(InputBuilder).TypeText("a\u00E9\U0001F600\r\n\n\r\tz"), want)
+}
+
+func TestNormalizeVirtualDesktop(t *testing.T) {
+	tests := []struct {
+		x, y    int32
+		desktop RECT
+		nx, ny  int32
+	}{
+		{0, 0, RECT{0, 0, 1920, 1080}, 0, 0},
+		{1919, 1079, RECT{0, 0, 1920, 1080}, 65535, 65535},
+		{960, 540, RECT{0, 0, 1920, 1080}, 32785, 32798},
+		{5000, -5, RECT{0, 0, 1920, 1080}, 65535, 0},
+
+		// A monitor left of and above the primary one.
+		{-1920, -200, RECT{-1920, -200, 1920, 1080}, 0, 0},
+		{0, 0, RECT{-1920, -200, 1920, 1080}, 32776, 10248},
+		{1919, 1079, RECT{-1920, -200, 1920, 1080}, 65535, 65535},
+
+		{7, 7, RECT{}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if nx, ny := NormalizeVirtualDesktop(tt.x, tt.y, tt.desktop); nx != tt.nx || ny != tt.ny {
+			t.Errorf("NormalizeVirtualDesktop(%d, %d, %+v) = %d, %d, want %d, %d", tt.x, tt.y, tt.desktop, nx, ny, tt.nx, tt.ny)
+		}
+	}
+}
+
+func TestInputBuilderMouse(t *testing.T) {
+	b := &InputBuilder{Desktop: RECT{-1920, -200, 1920, 1080}}
+	b.ClickAt(0, 0, RightButton).Click(XButton2).MouseMoveBy(-3, 4).Wheel(-120).HWheel(60)
+
+	const abs = MOUSEEVENTF_MOVE | MOUSEEVENTF_ABSOLUTE | MOUSEEVENTF_VIRTUALDESK
+
+	checkInputs(t, "mouse", b, []Input{
+		mouseInput(32776, 10248, 0, abs),
+		mouseInput(0, 0, 0, MOUSEEVENTF_RIGHTDOWN),
+		mouseInput(0, 0, 0, MOUSEEVENTF_RIGHTUP),
+		mouseInput(0, 0, XBUTTON2, MOUSEEVENTF_XDOWN),
+		mouseInput(0, 0, XBUTTON2, MOUSEEVENTF_XUP),
+		mouseInput(-3, 4, 0, MOUSEEVENTF_MOVE),
+		mouseInput(0, 0, 0xFFFFFF88, MOUSEEVENTF_WHEEL),
+		mouseInput(0, 0, 60, MOUSEEVENTF_HWHEEL),
+	})
+}
+
+func TestInputSize(t *testing.T) {
+	want := uintptr(28)
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		want = 40
+	}
+
+	if n := unsafe.Sizeof(MOUSE_INPUT{}); n != want {
+		t.Errorf("sizeof MOUSE_INPUT = %d, want %d", n, want)
+	}
+	if n := unsafe.Sizeof(KEYBD_INPUT{}); n != want {
+		t.Errorf("sizeof KEYBD_INPUT = %d, want %d", n, want)
+	}
+
+	buf := new(InputBuilder).KeyDown(VK_DELETE).MouseMoveBy(1, 2).marshal()
+
+	ki := (*KEYBD_INPUT)(unsafe.Pointer(&buf[0]))
+	if ki.Type != INPUT_KEYBOARD || ki.Ki.WVk != VK_DELETE || ki.Ki.DwFlags != KEYEVENTF_EXTENDEDKEY {
+		t.Errorf("marshaled keyboard input = %+v", *ki)
+	}
+	if mi := buf[1]; mi.Type != INPUT_MOUSE || mi.Mi.Dx != 1 || mi.Mi.Dy != 2 || mi.Mi.DwFlags != MOUSEEVENTF_MOVE {
+		t.Errorf("marshaled mouse input = %+v", mi)
+	}
+}
@@ -75,10 +75,12 @@ var (
 	getProfileString                   *windows.LazyProc
 	getThreadLocale                    *windows.LazyProc
 	getThreadUILanguage                *windows.LazyProc
+	getTickCount                       *windows.LazyProc
 	getVersion                         *windows.LazyProc
 	globalAlloc                        *windows.LazyProc
 	globalFree                         *windows.LazyProc
 	globalLock                         *windows.LazyProc
+	globalSize                         *windows.LazyProc
 	globalUnlock                       *windows.LazyProc
 	moveMemory                         *windows.LazyProc
 	mulDiv                             *windows.LazyProc
@@ -89,19 +91,6 @@ var (
 	systemTimeToFileTime               *windows.LazyProc
 )
 
-type (
-	ATOM          uint16
-	HANDLE        uintptr
-	HGLOBAL       HANDLE
-	HINSTANCE     HANDLE
-	LCID          uint32
-	LCTYPE        uint32
-	LANGID        uint16
-	HMODULE       uintptr
-	HWINEVENTHOOK HANDLE
-	HRSRC         uintptr
-)
-
 type FILETIME struct {
 	DwLowDateTime  uint32
 	DwHighDateTime uint32
@@ -161,10 +150,12 @@ func init() {
 	getProfileString = libkernel32.NewProc("GetProfileStringW")
 	getThreadLocale = libkernel32.NewProc("GetThreadLocale")
 	getThreadUILanguage = libkernel32.NewProc("GetThreadUILanguage")
+	getTickCount = libkernel32.NewProc("GetTickCount")
 	getVersion = libkernel32.NewProc("GetVersion")
 	globalAlloc = libkernel32.NewProc("GlobalAlloc")
 	globalFree = libkernel32.NewProc("GlobalFree")
 	globalLock = libkernel32.NewProc("GlobalLock")
+	globalSize = libkernel32.NewProc("GlobalSize")
 	globalUnlock = libkernel32.NewProc("GlobalUnlock")
 	moveMemory = libkernel32.NewProc("RtlMoveMemory")
 	mulDiv = libkernel32.NewProc("MulDiv")
@@ -347,6 +338,15 @@ func GetThreadUILanguage() LANGID {
 	return LANGID(ret)
 }
 
+func GetTickCount() uint32 {
+	ret, _, _ := syscall.Syscall(getTickCount.Addr(), 0,
+		0,
+		0,
+		0)
+
+	return uint32(ret)
+}
+
 func GetVersion() uint32 {
 	ret, _, _ := syscall.Syscall(getVersion.Addr(), 0,
 		0,
@@ -382,6 +382,15 @@ func GlobalLock(hMem HGLOBAL) unsafe.Pointer {
 	return unsafe.Pointer(ret)
 }
 
+func GlobalSize(hMem HGLOBAL) uintptr {
+	ret, _, _ := syscall.Syscall(globalSize.Addr(), 1,
+		uintptr(hMem),
+		0,
+		0)
+
+	return ret
+}
+
 func GlobalUnlock(hMem HGLOBAL) bool {
 	ret, _, _ := syscall.Syscall(globalUnlock.Addr(), 1,
 		uintptr(hMem),
@@ -0,0 +1,530 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// KeyboardEvent is a keystroke seen by a low-level keyboard hook.
+type KeyboardEvent struct {
+	Message uint32 // WM_KEYDOWN, WM_KEYUP, WM_SYSKEYDOWN or WM_SYSKEYUP
+	KBDLLHOOKSTRUCT
+
+	// Modifiers are the modifier keys held down, including the key of the
+	// event if it is a modifier that is pressed.
+	Modifiers KeyModifiers
+
+	// Swallowed reports whether the event was kept from the system. It is
+	// only set for events received from LowLevelHook.KeyboardEvents.
+	Swallowed bool
+}
+
+// Down reports whether the key was pressed rather than released.
+func (e *KeyboardEvent) Down() bool {
+	return e.Flags&LLKHF_UP == 0
+}
+
+// Injected reports whether the event was synthesized, e.g. by SendInput.
+func (e *KeyboardEvent) Injected() bool {
+	return e.Flags&LLKHF_INJECTED != 0
+}
+
+// Shortcut returns the key combination of the event.
+func (e *KeyboardEvent) Shortcut() Shortcut {
+	return Shortcut{Modifiers: e.Modifiers, Key: uint16(e.VkCode)}
+}
+
+// MouseEvent is a mouse input event seen by a low-level mouse hook.
+type MouseEvent struct {
+	Message uint32 // WM_MOUSEMOVE, WM_LBUTTONDOWN, WM_MOUSEWHEEL, ...
+	MSLLHOOKSTRUCT
+
+	// Swallowed reports whether the event was kept from the system. It is
+	// only set for events received from LowLevelHook.MouseEvents.
+	Swallowed bool
+}
+
+// Injected reports whether the event was synthesized, e.g. by SendInput.
+func (e *MouseEvent) Injected() bool {
+	return e.Flags&LLMHF_INJECTED != 0
+}
+
+// WheelDelta returns the wheel rotation of WM_MOUSEWHEEL and WM_MOUSEHWHEEL
+// events in multiples of WHEEL_DELTA.
+func (e *MouseEvent) WheelDelta() int16 {
+	return int16(e.MouseData >> 16)
+}
+
+// LowLevelHookOptions configures a LowLevelHook.
+type LowLevelHookOptions struct {
+	// Keyboard and Mouse select the hooks to install.
+	Keyboard bool
+	Mouse    bool
+
+	// FilterKeyboard and FilterMouse, if not nil, decide whether an event
+	// is swallowed. They run on a separate goroutine, one event at a time.
+	// Events whose decision takes longer than DecisionTimeout pass
+	// through unless SwallowOnTimeout is set, so without it a filter
+	// cannot be relied on to block input.
+	FilterKeyboard func(e *KeyboardEvent) bool
+	FilterMouse    func(e *MouseEvent) bool
+
+	// SwallowOnTimeout makes events whose decision times out swallowed
+	// instead of passed through. A filter that hangs then blocks all
+	// keyboard or mouse input of the desktop until the hook is closed.
+	SwallowOnTimeout bool
+
+	// DecisionTimeout defaults to 50 ms. It is capped at half of
+	// LowLevelHooksTimeout.
+	DecisionTimeout time.Duration
+
+	// OnTimeout, if not nil, is called on its own goroutine when the hook
+	// idHook missed the deadline of the system, which silently removes
+	// such hooks. The hook is reinstalled in that case.
+	OnTimeout func(idHook int32, elapsed time.Duration)
+
+	// Buffer is the capacity of the event channels. It defaults to 64.
+	Buffer int
+}
+
+// LowLevelHook installs WH_KEYBOARD_LL and WH_MOUSE_LL hooks on a thread
+// with its own message loop, which the system calls for all input of the
+// desktop.
+type LowLevelHook struct {
+	// first for 64-bit alignment of atomic operations
+	dropped   uint64
+	late      uint64
+	timeouts  uint64
+	lastInput int64
+
+	// KeyboardEvents and MouseEvents receive the events of the installed
+	// hooks and are nil otherwise. Events are dropped while a channel is
+	// full. The channels are closed when the hook is closed.
+	KeyboardEvents <-chan KeyboardEvent
+	MouseEvents    <-chan MouseEvent
+
+	opts      LowLevelHookOptions
+	keyboard  chan KeyboardEvent
+	mouse     chan MouseEvent
+	decisions chan *hookDecision
+	osTimeout uint32
+
+	// accessed on the hook thread only
+	held   uint8
+	hhooks map[int32]HHOOK
+
+	loop      *MessageLoop
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// hookDecision is a filter call handed to the decision goroutine.
+type hookDecision struct {
+	f      func() bool
+	result chan bool
+}
+
+// The hook procedures are created once, since the number of callbacks is
+// limited. Low-level hooks are called on the thread that installed them,
+// so they find their LowLevelHook by the thread id.
+var lowLevelHooks = struct {
+	sync.Mutex
+	once         sync.Once
+	keyboardProc uintptr
+	mouseProc    uintptr
+	byThread     map[uint32]*LowLevelHook
+}{
+	byThread: make(map[uint32]*LowLevelHook),
+}
+
+// LowLevelHooksTimeout returns the time low-level hook procedures may take
+// before the system skips them. On Windows 7 and later, hooks that exceed
+// it are removed without notice.
+func LowLevelHooksTimeout() time.Duration {
+	// The value is missing on most systems, in which case the system
+	// uses a few hundred milliseconds.
+	ms := uint32(300)
+
+	subKey, _ := syscall.UTF16PtrFromString(`Control Panel\Desktop`)
+	name, _ := syscall.UTF16PtrFromString("LowLevelHooksTimeout")
+
+	var hKey HKEY
+	if RegOpenKeyEx(HKEY_CURRENT_USER, subKey, 0, KEY_READ, &hKey) == ERROR_SUCCESS {
+		defer RegCloseKey(hKey)
+
+		var typ, v uint32
+		size := uint32(unsafe.Sizeof(v))
+		if RegQueryValueEx(hKey, name, nil, &typ, (*byte)(unsafe.Pointer(&v)), &size) == ERROR_SUCCESS && typ == REG_DWORD && v > 0 {
+			ms = v
+		}
+	}
+
+	// Windows 10 1709 and later never wait longer than a second.
+	if ms > 1000 {
+		ms = 1000
+	}
+
+	return time.Duration(ms) * time.Millisecond
+}
+
+// IdleTime returns the time since the last input of the session.
+func IdleTime() (time.Duration, error) {
+	lii := LASTINPUTINFO{CbSize: uint32(unsafe.Sizeof(LASTINPUTINFO{}))}
+	if !GetLastInputInfo(&lii) {
+		return 0, errors.New("GetLastInputInfo failed")
+	}
+
+	return time.Duration(GetTickCount()-lii.DwTime) * time.Millisecond, nil
+}
+
+// NewLowLevelHook installs the hooks selected by opts. Call Close to remove
+// them.
+func NewLowLevelHook(opts *LowLevelHookOptions) (*LowLevelHook, error) {
+	if !opts.Keyboard && !opts.Mouse {
+		return nil, errors.New("no hook selected")
+	}
+
+	osTimeout := LowLevelHooksTimeout()
+
+	h := &LowLevelHook{
+		opts:      *opts,
+		osTimeout: uint32(osTimeout / time.Millisecond),
+		hhooks:    make(map[int32]HHOOK),
+		done:      make(chan struct{}),
+	}
+
+	if h.opts.DecisionTimeout <= 0 {
+		h.opts.DecisionTimeout = 50 * time.Millisecond
+	}
+	if h.opts.DecisionTimeout > osTimeout/2 {
+		h.opts.DecisionTimeout = osTimeout / 2
+	}
+
+	buffer := opts.Buffer
+	if buffer <= 0 {
+		buffer = 64
+	}
+
+	var hookIDs []int32
+	if opts.Keyboard {
+		h.keyboard = make(chan KeyboardEvent, buffer)
+		h.KeyboardEvents = h.keyboard
+		hookIDs = append(hookIDs, WH_KEYBOARD_LL)
+	}
+	if opts.Mouse {
+		h.mouse = make(chan MouseEvent, buffer)
+		h.MouseEvents = h.mouse
+		hookIDs = append(hookIDs, WH_MOUSE_LL)
+	}
+
+	if opts.FilterKeyboard != nil || opts.FilterMouse != nil {
+		h.decisions = make(chan *hookDecision)
+		go func() {
+			for d := range h.decisions {
+				d.result <- d.f()
+			}
+		}()
+	}
+
+	lowLevelHooks.once.Do(func() {
+		lowLevelHooks.keyboardProc = syscall.NewCallback(lowLevelKeyboardProc)
+		lowLevelHooks.mouseProc = syscall.NewCallback(lowLevelMouseProc)
+	})
+
+	started := make(chan error, 1)
+
+	go func() {
+		defer close(h.done)
+		defer func() {
+			if h.keyboard != nil {
+				close(h.keyboard)
+			}
+			if h.mouse != nil {
+				close(h.mouse)
+			}
+			if h.decisions != nil {
+				close(h.decisions)
+			}
+		}()
+
+		loop, err := NewMessageLoop()
+		if err != nil {
+			started <- err
+			return
+		}
+		defer loop.Dispose()
+		h.loop = loop
+
+		tid := GetCurrentThreadId()
+
+		lowLevelHooks.Lock()
+		lowLevelHooks.byThread[tid] = h
+		lowLevelHooks.Unlock()
+
+		defer func() {
+			for _, hhk := range h.hhooks {
+				UnhookWindowsHookEx(hhk)
+			}
+
+			lowLevelHooks.Lock()
+			delete(lowLevelHooks.byThread, tid)
+			lowLevelHooks.Unlock()
+		}()
+
+		for _, id := range hookIDs {
+			if err := h.install(id); err != nil {
+				started <- err
+				return
+			}
+		}
+
+		started <- nil
+
+		loop.Run()
+	}()
+
+	if err := <-started; err != nil {
+		<-h.done
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (h *LowLevelHook) install(idHook int32) error {
+	proc := lowLevelHooks.keyboardProc
+	if idHook == WH_MOUSE_LL {
+		proc = lowLevelHooks.mouseProc
+	}
+
+	hhk := SetWindowsHookEx(idHook, proc, GetModuleHandle(nil), 0)
+	if hhk == 0 {
+		return errors.New("SetWindowsHookEx failed")
+	}
+	h.hhooks[idHook] = hhk
+
+	return nil
+}
+
+// reinstall replaces a hook that the system may have removed.
+func (h *LowLevelHook) reinstall(idHook int32) {
+	if hhk, ok := h.hhooks[idHook]; ok {
+		UnhookWindowsHookEx(hhk)
+		delete(h.hhooks, idHook)
+	}
+
+	h.install(idHook)
+}
+
+func lowLevelHookForThread() *LowLevelHook {
+	lowLevelHooks.Lock()
+	h := lowLevelHooks.byThread[GetCurrentThreadId()]
+	lowLevelHooks.Unlock()
+
+	return h
+}
+
+// lowLevelKeyboardProc is the WH_KEYBOARD_LL hook procedure. The hook
+// handle passed to CallNextHookEx is ignored by the system.
+func lowLevelKeyboardProc(nCode int32, wParam, lParam uintptr) uintptr {
+	h := lowLevelHookForThread()
+	if h == nil || nCode != HC_ACTION {
+		return CallNextHookEx(0, nCode, wParam, lParam)
+	}
+
+	e := KeyboardEvent{
+		Message:         uint32(wParam),
+		KBDLLHOOKSTRUCT: *(*KBDLLHOOKSTRUCT)(unsafe.Pointer(lParam)),
+	}
+	e.Modifiers = h.trackModifiers(e.VkCode, e.Down())
+
+	if f := h.opts.FilterKeyboard; f != nil {
+		c := e
+		e.Swallowed = h.decide(func() bool { return f(&c) })
+	}
+
+	atomic.StoreInt64(&h.lastInput, time.Now().UnixNano())
+
+	select {
+	case h.keyboard <- e:
+	default:
+		atomic.AddUint64(&h.dropped, 1)
+	}
+
+	h.checkDeadline(WH_KEYBOARD_LL, e.Time)
+
+	if e.Swallowed {
+		return 1
+	}
+
+	return CallNextHookEx(0, nCode, wParam, lParam)
+}
+
+// lowLevelMouseProc is the WH_MOUSE_LL hook procedure.
+func lowLevelMouseProc(nCode int32, wParam, lParam uintptr) uintptr {
+	h := lowLevelHookForThread()
+	if h == nil || nCode != HC_ACTION {
+		return CallNextHookEx(0, nCode, wParam, lParam)
+	}
+
+	e := MouseEvent{
+		Message:        uint32(wParam),
+		MSLLHOOKSTRUCT: *(*MSLLHOOKSTRUCT)(unsafe.Pointer(lParam)),
+	}
+
+	if f := h.opts.FilterMouse; f != nil {
+		c := e
+		e.Swallowed = h.decide(func() bool { return f(&c) })
+	}
+
+	atomic.StoreInt64(&h.lastInput, time.Now().UnixNano())
+
+	select {
+	case h.mouse <- e:
+	default:
+		atomic.AddUint64(&h.dropped, 1)
+	}
+
+	h.checkDeadline(WH_MOUSE_LL, e.Time)
+
+	if e.Swallowed {
+		return 1
+	}
+
+	return CallNextHookEx(0, nCode, wParam, lParam)
+}
+
+// modifierKeys maps the bits of LowLevelHook.held to modifiers.
+var modifierKeys = []struct {
+	vk  uint32
+	mod KeyModifiers
+}{
+	{VK_LCONTROL, ModCtrl},
+	{VK_RCONTROL, ModCtrl},
+	{VK_LSHIFT, ModShift},
+	{VK_RSHIFT, ModShift},
+	{VK_LMENU, ModAlt},
+	{VK_RMENU, ModAlt},
+	{VK_LWIN, ModWin},
+	{VK_RWIN, ModWin},
+}
+
+// trackModifiers records the state of modifier keys and returns the
+// modifiers held down. Low-level hooks see keys before the system updates
+// its key state, so GetAsyncKeyState cannot be used.
+func (h *LowLevelHook) trackModifiers(vk uint32, down bool) KeyModifiers {
+	var mods KeyModifiers
+
+	for i, k := range modifierKeys {
+		if k.vk == vk {
+			if down {
+				h.held |= 1 << uint(i)
+			} else {
+				h.held &^= 1 << uint(i)
+			}
+		}
+
+		if h.held&(1<<uint(i)) != 0 {
+			mods |= k.mod
+		}
+	}
+
+	return mods
+}
+
+// decide runs f on the decision goroutine and returns its result, or
+// SwallowOnTimeout if it does not finish in time.
+func (h *LowLevelHook) decide(f func() bool) bool {
+	d := &hookDecision{f: f, result: make(chan bool, 1)}
+
+	timer := time.NewTimer(h.opts.DecisionTimeout)
+	defer timer.Stop()
+
+	select {
+	case h.decisions <- d:
+	case <-timer.C:
+		atomic.AddUint64(&h.late, 1)
+		return h.opts.SwallowOnTimeout
+	}
+
+	select {
+	case swallow := <-d.result:
+		return swallow
+
+	case <-timer.C:
+		atomic.AddUint64(&h.late, 1)
+		return h.opts.SwallowOnTimeout
+	}
+}
+
+// checkDeadline detects events that took longer than the system allows,
+// measured from the time stamp of the event, and reinstalls the hook.
+func (h *LowLevelHook) checkDeadline(idHook int32, eventTime uint32) {
+	elapsed := GetTickCount() - eventTime
+	if elapsed < h.osTimeout {
+		return
+	}
+
+	atomic.AddUint64(&h.timeouts, 1)
+
+	h.loop.Post(func() {
+		h.reinstall(idHook)
+	})
+
+	if f := h.opts.OnTimeout; f != nil {
+		go f(idHook, time.Duration(elapsed)*time.Millisecond)
+	}
+}
+
+// LastInput returns the time of the last event seen by the hooks, or the
+// zero time if there was none.
+func (h *LowLevelHook) LastInput() time.Time {
+	ns := atomic.LoadInt64(&h.lastInput)
+	if ns == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, ns)
+}
+
+// Dropped returns the number of events dropped because a channel was full.
+func (h *LowLevelHook) Dropped() uint64 {
+	return atomic.LoadUint64(&h.dropped)
+}
+
+// LateDecisions returns the number of events whose filter did not decide
+// within DecisionTimeout, which passed through or, with SwallowOnTimeout,
+// were swallowed.
+func (h *LowLevelHook) LateDecisions() uint64 {
+	return atomic.LoadUint64(&h.late)
+}
+
+// Timeouts returns the number of times a hook missed the deadline of the
+// system and was reinstalled.
+func (h *LowLevelHook) Timeouts() uint64 {
+	return atomic.LoadUint64(&h.timeouts)
+}
+
+// Close removes the hooks and closes the event channels.
+func (h *LowLevelHook) Close() error {
+	var err error
+
+	h.closeOnce.Do(func() {
+		err = h.loop.Quit(0)
+		if err == nil {
+			<-h.done
+		}
+	})
+
+	return err
+}
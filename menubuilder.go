@@ -0,0 +1,480 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// MenuEntry is an entry of a Menu: an Item, a Submenu, a RadioGroup or
+// Separator.
+type MenuEntry interface {
+	insert(c *CommandMenu, hmenu HMENU, pos uint32) (n uint32, err error)
+}
+
+// Menu describes a menu as a tree of entries, e.g.
+//
+//	win.Menu{
+//		win.Submenu{Text: "&File", Items: win.Menu{
+//			win.Item{Text: "&Open...\tCtrl+O", Handler: open},
+//			win.Separator,
+//			win.Item{Text: "E&xit", Handler: exit},
+//		}},
+//	}
+//
+// Create or CreatePopup build the native menu from it.
+type Menu []MenuEntry
+
+// Item is a command of a Menu.
+type Item struct {
+	// Text is the item text. A shortcut after a tab, as in
+	// "&Open\tCtrl+O", is displayed right-aligned and becomes an entry of
+	// CommandMenu.Accelerators.
+	Text string
+
+	// ID is the command id sent with WM_COMMAND, below 0x8000. Items
+	// without an ID get one from 0x8000 up.
+	ID uint16
+
+	// Handler is called when the item is chosen.
+	Handler func()
+
+	// Enabled and Checked, if set, are evaluated when the menu containing
+	// the item is about to open.
+	Enabled func() bool
+	Checked func() bool
+
+	// Default makes the item the default item of its menu, shown in bold.
+	Default bool
+
+	// Bitmap is shown next to the text. CheckedBitmap and UncheckedBitmap
+	// replace the check mark.
+	Bitmap          HBITMAP
+	CheckedBitmap   HBITMAP
+	UncheckedBitmap HBITMAP
+
+	// OwnerDraw makes the owner window draw the item in response to
+	// WM_MEASUREITEM and WM_DRAWITEM. Data is passed as itemData.
+	OwnerDraw bool
+	Data      uintptr
+}
+
+// Submenu is a popup menu within a Menu.
+type Submenu struct {
+	Text    string
+	Items   Menu
+	Enabled func() bool
+	Bitmap  HBITMAP
+}
+
+// RadioGroup is a run of items of which exactly one is checked with a
+// radio bullet. Choosing an item checks it before its Handler runs.
+type RadioGroup struct {
+	Items []Item
+
+	// Selected, if set, returns the id of the item to check and is
+	// evaluated when the menu containing the group is about to open.
+	// Otherwise the first item is checked initially.
+	Selected func() uint16
+}
+
+// MenuSeparator is the type of Separator.
+type MenuSeparator struct{}
+
+// Separator is a separator line in a Menu.
+var Separator MenuEntry = MenuSeparator{}
+
+const firstAutoMenuID = 0x8000
+
+// CommandMenu is a native menu built from a Menu. It routes WM_COMMAND to
+// the handlers of its items and updates their state on WM_INITMENUPOPUP.
+type CommandMenu struct {
+	hmenu  HMENU
+	nextID uint32
+	items  map[uint16]*menuItem
+	states map[HMENU][]*menuItem
+	radios map[HMENU][]*menuRadio
+	accels []ACCEL
+}
+
+type menuItem struct {
+	menu    HMENU
+	pos     uint32
+	handler func()
+	enabled func() bool
+	checked func() bool
+	radio   *menuRadio
+}
+
+type menuRadio struct {
+	menu     HMENU
+	first    uint32
+	ids      []uint16
+	selected func() uint16
+}
+
+// Create builds a menu bar from m.
+func (m Menu) Create() (*CommandMenu, error) {
+	return m.create(false)
+}
+
+// CreatePopup builds a popup menu, e.g. a context menu, from m.
+func (m Menu) CreatePopup() (*CommandMenu, error) {
+	return m.create(true)
+}
+
+func (m Menu) create(popup bool) (*CommandMenu, error) {
+	c := &CommandMenu{
+		nextID: firstAutoMenuID,
+		items:  make(map[uint16]*menuItem),
+		states: make(map[HMENU][]*menuItem),
+		radios: make(map[HMENU][]*menuRadio),
+	}
+
+	if popup {
+		c.hmenu = CreatePopupMenu()
+	} else {
+		c.hmenu = CreateMenu()
+	}
+	if c.hmenu == 0 {
+		return nil, errors.New("CreateMenu failed")
+	}
+
+	if err := c.build(c.hmenu, m); err != nil {
+		DestroyMenu(c.hmenu)
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *CommandMenu) build(hmenu HMENU, entries Menu) error {
+	var pos uint32
+
+	for _, e := range entries {
+		n, err := e.insert(c, hmenu, pos)
+		if err != nil {
+			return err
+		}
+		pos += n
+	}
+
+	c.Refresh(hmenu)
+
+	return nil
+}
+
+func insertMenuEntry(hmenu HMENU, pos uint32, mii *MENUITEMINFO) error {
+	mii.CbSize = uint32(unsafe.Sizeof(*mii))
+
+	if !InsertMenuItem(hmenu, pos, true, mii) {
+		return errors.New("InsertMenuItem failed")
+	}
+
+	return nil
+}
+
+func (MenuSeparator) insert(c *CommandMenu, hmenu HMENU, pos uint32) (uint32, error) {
+	return 1, insertMenuEntry(hmenu, pos, &MENUITEMINFO{
+		FMask: MIIM_FTYPE,
+		FType: MFT_SEPARATOR,
+	})
+}
+
+func (s Submenu) insert(c *CommandMenu, hmenu HMENU, pos uint32) (uint32, error) {
+	text, err := syscall.UTF16PtrFromString(s.Text)
+	if err != nil {
+		return 0, err
+	}
+
+	sub := CreatePopupMenu()
+	if sub == 0 {
+		return 0, errors.New("CreatePopupMenu failed")
+	}
+
+	mii := MENUITEMINFO{
+		FMask:      MIIM_FTYPE | MIIM_STRING | MIIM_SUBMENU,
+		FType:      MFT_STRING,
+		HSubMenu:   sub,
+		DwTypeData: text,
+	}
+	if s.Bitmap != 0 {
+		mii.FMask |= MIIM_BITMAP
+		mii.HbmpItem = s.Bitmap
+	}
+
+	if err := insertMenuEntry(hmenu, pos, &mii); err != nil {
+		DestroyMenu(sub)
+		return 0, err
+	}
+
+	// From here on, sub is destroyed together with hmenu.
+
+	if s.Enabled != nil {
+		c.states[hmenu] = append(c.states[hmenu], &menuItem{menu: hmenu, pos: pos, enabled: s.Enabled})
+	}
+
+	return 1, c.build(sub, s.Items)
+}
+
+func (it Item) insert(c *CommandMenu, hmenu HMENU, pos uint32) (uint32, error) {
+	_, err := c.insertItem(hmenu, pos, &it, nil)
+
+	return 1, err
+}
+
+func (g RadioGroup) insert(c *CommandMenu, hmenu HMENU, pos uint32) (uint32, error) {
+	r := &menuRadio{
+		menu:     hmenu,
+		first:    pos,
+		selected: g.Selected,
+	}
+
+	for i := range g.Items {
+		id, err := c.insertItem(hmenu, pos+uint32(i), &g.Items[i], r)
+		if err != nil {
+			return 0, err
+		}
+		r.ids = append(r.ids, id)
+	}
+
+	if len(r.ids) > 0 {
+		c.radios[hmenu] = append(c.radios[hmenu], r)
+
+		if r.selected == nil {
+			r.check(0)
+		}
+	}
+
+	return uint32(len(g.Items)), nil
+}
+
+func (c *CommandMenu) insertItem(hmenu HMENU, pos uint32, it *Item, radio *menuRadio) (uint16, error) {
+	text, err := syscall.UTF16PtrFromString(it.Text)
+	if err != nil {
+		return 0, err
+	}
+
+	id := it.ID
+	if id == 0 {
+		if c.nextID > 0xFFFF {
+			return 0, fmt.Errorf("menu item %q: out of automatic ids", it.Text)
+		}
+		id = uint16(c.nextID)
+		c.nextID++
+	} else if id >= firstAutoMenuID {
+		return 0, fmt.Errorf("menu item %q: id %d is in the automatic range", it.Text, id)
+	} else if c.items[id] != nil {
+		return 0, fmt.Errorf("menu item %q: duplicate id %d", it.Text, id)
+	}
+
+	mii := MENUITEMINFO{
+		FMask:      MIIM_FTYPE | MIIM_ID | MIIM_STRING | MIIM_DATA,
+		FType:      MFT_STRING,
+		WID:        uint32(id),
+		DwItemData: it.Data,
+		DwTypeData: text,
+	}
+	if it.OwnerDraw {
+		mii.FType |= MFT_OWNERDRAW
+	}
+	if radio != nil {
+		mii.FType |= MFT_RADIOCHECK
+	}
+	if it.Bitmap != 0 {
+		mii.FMask |= MIIM_BITMAP
+		mii.HbmpItem = it.Bitmap
+	}
+
+	if err := insertMenuEntry(hmenu, pos, &mii); err != nil {
+		return 0, err
+	}
+
+	if it.CheckedBitmap != 0 || it.UncheckedBitmap != 0 {
+		if !SetMenuItemBitmaps(hmenu, pos, MF_BYPOSITION, it.UncheckedBitmap, it.CheckedBitmap) {
+			return 0, errors.New("SetMenuItemBitmaps failed")
+		}
+	}
+
+	if it.Default && !SetMenuDefaultItem(hmenu, pos, true) {
+		return 0, errors.New("SetMenuDefaultItem failed")
+	}
+
+	if i := strings.LastIndex(it.Text, "\t"); i >= 0 {
+		sc, err := ParseShortcut(it.Text[i+1:])
+		if err != nil {
+			return 0, fmt.Errorf("menu item %q: %v", it.Text, err)
+		}
+
+		accel, err := sc.Accel(id)
+		if err != nil {
+			return 0, fmt.Errorf("menu item %q: %v", it.Text, err)
+		}
+
+		c.accels = append(c.accels, accel)
+	}
+
+	mi := &menuItem{
+		menu:    hmenu,
+		pos:     pos,
+		handler: it.Handler,
+		enabled: it.Enabled,
+		checked: it.Checked,
+		radio:   radio,
+	}
+	c.items[id] = mi
+
+	if mi.enabled != nil || mi.checked != nil {
+		c.states[hmenu] = append(c.states[hmenu], mi)
+	}
+
+	return id, nil
+}
+
+func (r *menuRadio) check(index int) {
+	CheckMenuRadioItem(r.menu, r.first, r.first+uint32(len(r.ids))-1, r.first+uint32(index), MF_BYPOSITION)
+}
+
+// HMENU returns the native menu.
+func (c *CommandMenu) HMENU() HMENU {
+	return c.hmenu
+}
+
+// Accelerators returns accelerator table entries for the shortcuts in the
+// item texts.
+func (c *CommandMenu) Accelerators() []ACCEL {
+	return append([]ACCEL(nil), c.accels...)
+}
+
+// CreateAcceleratorTable creates an accelerator table from Accelerators.
+// Add it to the MessageLoop for the window c is attached to.
+func (c *CommandMenu) CreateAcceleratorTable() (*AcceleratorTable, error) {
+	return NewAcceleratorTable(c.accels)
+}
+
+// Refresh evaluates the Enabled, Checked and Selected functions of the
+// entries directly contained in hmenu, which is c's menu or one of its
+// submenus. It is called automatically for windows c is attached to.
+func (c *CommandMenu) Refresh(hmenu HMENU) {
+	for _, mi := range c.states[hmenu] {
+		if mi.enabled != nil {
+			flags := uint32(MF_BYPOSITION | MF_ENABLED)
+			if !mi.enabled() {
+				flags = MF_BYPOSITION | MF_GRAYED
+			}
+			EnableMenuItem(mi.menu, mi.pos, flags)
+		}
+
+		if mi.checked != nil {
+			flags := uint32(MF_BYPOSITION | MF_UNCHECKED)
+			if mi.checked() {
+				flags = MF_BYPOSITION | MF_CHECKED
+			}
+			CheckMenuItem(mi.menu, mi.pos, flags)
+		}
+	}
+
+	for _, r := range c.radios[hmenu] {
+		if r.selected == nil {
+			continue
+		}
+
+		id := r.selected()
+		for i, rid := range r.ids {
+			if rid == id {
+				r.check(i)
+				break
+			}
+		}
+	}
+}
+
+// Invoke runs the command id as if its item had been chosen and reports
+// whether id belongs to c.
+func (c *CommandMenu) Invoke(id uint16) bool {
+	mi := c.items[id]
+	if mi == nil {
+		return false
+	}
+
+	if r := mi.radio; r != nil {
+		for i, rid := range r.ids {
+			if rid == id {
+				r.check(i)
+				break
+			}
+		}
+	}
+
+	if mi.handler != nil {
+		mi.handler()
+	}
+
+	return true
+}
+
+// Attach routes the WM_COMMAND messages of c's items, from the menu or an
+// accelerator, to their handlers and keeps the item state up to date while
+// w shows the menu. To show a menu bar, also pass it to SetMenu or
+// CreateWindowParams.Menu.
+func (c *CommandMenu) Attach(w *Window) {
+	w.Handle(WM_COMMAND, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		cmd := CrackCommandMsg(wParam, lParam)
+		if cmd.Control != 0 || cmd.Code > 1 {
+			return 0, false
+		}
+
+		return 0, c.Invoke(cmd.ID)
+	})
+
+	w.Handle(WM_INITMENU, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		if HMENU(wParam) == c.hmenu {
+			c.Refresh(c.hmenu)
+		}
+		return 0, false
+	})
+
+	w.Handle(WM_INITMENUPOPUP, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		c.Refresh(CrackInitMenuPopupMsg(wParam, lParam).Menu)
+		return 0, false
+	})
+}
+
+// TrackPopup shows a menu created with CreatePopup at the screen position
+// x, y and runs the handler of the chosen item. w must be attached to c.
+func (c *CommandMenu) TrackPopup(w *Window, x, y int32) {
+	// Without this, the menu does not close when clicking elsewhere if w
+	// is not the foreground window, e.g. for notification icons.
+	SetForegroundWindow(w.HWND())
+
+	id := TrackPopupMenu(c.hmenu, TPM_RETURNCMD|TPM_RIGHTBUTTON, x, y, 0, w.HWND(), nil)
+
+	PostMessage(w.HWND(), WM_NULL, 0, 0)
+
+	if id != 0 {
+		c.Invoke(uint16(id))
+	}
+}
+
+// Destroy destroys the menu and its submenus. A menu bar assigned to a
+// window is destroyed with the window and must not be destroyed again.
+func (c *CommandMenu) Destroy() error {
+	if c.hmenu == 0 {
+		return nil
+	}
+
+	if !DestroyMenu(c.hmenu) {
+		return errors.New("DestroyMenu failed")
+	}
+	c.hmenu = 0
+
+	return nil
+}
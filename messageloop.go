@@ -0,0 +1,221 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"runtime"
+)
+
+// MessageFilter gets a chance to process a message before it is translated
+// and dispatched. It returns true if it consumed the message.
+type MessageFilter func(msg *MSG) bool
+
+// MessageLoop runs the message pump of a UI thread.
+//
+// A MessageLoop is bound to the OS thread of the goroutine that created it,
+// which it locks with runtime.LockOSThread. Run must be called from the same
+// goroutine, and so must all window creation and handler registration. Use
+// Post to run code on that thread from other goroutines.
+type MessageLoop struct {
+	threadID uint32
+	filters  []MessageFilter
+	accels   []loopAccelerators
+	dialogs  []HWND
+	idle     []func()
+
+	postWindow *MessageWindow
+	disposed   bool
+}
+
+type loopAccelerators struct {
+	hwnd   HWND
+	haccel HACCEL
+}
+
+// NewMessageLoop locks the calling goroutine to its OS thread and returns a
+// MessageLoop for it.
+func NewMessageLoop() (*MessageLoop, error) {
+	runtime.LockOSThread()
+
+	// A message-only window, unlike a thread message, also receives posted
+	// closures while a modal loop such as a menu or MessageBox is running.
+	postWindow, err := NewMessageWindow()
+	if err != nil {
+		runtime.UnlockOSThread()
+		return nil, err
+	}
+
+	return &MessageLoop{
+		threadID:   GetCurrentThreadId(),
+		postWindow: postWindow,
+	}, nil
+}
+
+// ThreadID returns the id of the thread the loop runs on.
+func (l *MessageLoop) ThreadID() uint32 {
+	return l.threadID
+}
+
+// AddFilter adds a filter that runs before accelerators, IsDialogMessage,
+// TranslateMessage and DispatchMessage. Filters run in the order they were
+// added.
+func (l *MessageLoop) AddFilter(f MessageFilter) {
+	l.filters = append(l.filters, f)
+}
+
+// AddAccelerators makes the loop translate the accelerators of haccel for
+// messages to hwnd and its descendants, sending their WM_COMMAND to hwnd.
+// Accelerators are translated after the filters and before
+// IsDialogMessage.
+func (l *MessageLoop) AddAccelerators(hwnd HWND, haccel HACCEL) {
+	l.accels = append(l.accels, loopAccelerators{hwnd, haccel})
+}
+
+// RemoveAccelerators undoes AddAccelerators for hwnd.
+func (l *MessageLoop) RemoveAccelerators(hwnd HWND) {
+	for i := 0; i < len(l.accels); i++ {
+		if l.accels[i].hwnd == hwnd {
+			l.accels = append(l.accels[:i], l.accels[i+1:]...)
+			i--
+		}
+	}
+}
+
+// AddDialog makes the loop call IsDialogMessage for the modeless dialog or
+// WS_EX_CONTROLPARENT window hwnd, so that keyboard navigation works.
+func (l *MessageLoop) AddDialog(hwnd HWND) {
+	l.dialogs = append(l.dialogs, hwnd)
+}
+
+// RemoveDialog undoes AddDialog. Call it when the dialog is destroyed.
+func (l *MessageLoop) RemoveDialog(hwnd HWND) {
+	for i, d := range l.dialogs {
+		if d == hwnd {
+			l.dialogs = append(l.dialogs[:i], l.dialogs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Post schedules f to run on the loop thread. It is safe to call from any
+// goroutine and does not wait for f to run. It fails with
+// ErrMessageWindowDestroyed once the loop has been disposed.
+func (l *MessageLoop) Post(f func()) error {
+	return l.postWindow.BeginInvoke(f)
+}
+
+// Invoke runs f on the loop thread and waits for it to return, propagating
+// panics like MessageWindow.Invoke. It is safe to call from any goroutine.
+func (l *MessageLoop) Invoke(f func()) error {
+	return l.postWindow.Invoke(f)
+}
+
+// RunWhenIdle queues f to run when the message queue is empty. Queued
+// functions run one at a time, in order, and pending input is processed
+// between them. It must be called on the loop thread; use Post from other
+// goroutines.
+func (l *MessageLoop) RunWhenIdle(f func()) {
+	l.idle = append(l.idle, f)
+}
+
+// runIdle runs the next function queued with RunWhenIdle if there are no
+// messages, and reports whether it did.
+func (l *MessageLoop) runIdle() bool {
+	if len(l.idle) == 0 {
+		return false
+	}
+
+	var msg MSG
+	if PeekMessage(&msg, 0, 0, 0, PM_NOREMOVE) {
+		return false
+	}
+
+	f := l.idle[0]
+	l.idle[0] = nil
+	l.idle = l.idle[1:]
+
+	f()
+
+	return true
+}
+
+// Quit makes Run return exitCode. It is safe to call from any goroutine.
+func (l *MessageLoop) Quit(exitCode int32) error {
+	return l.Post(func() {
+		PostQuitMessage(exitCode)
+	})
+}
+
+// PreTranslate runs the filters, TranslateAccelerator and IsDialogMessage
+// for msg and reports whether the message was consumed. Custom message
+// pumps, e.g. modal loops, should call it before TranslateMessage and
+// DispatchMessage.
+func (l *MessageLoop) PreTranslate(msg *MSG) bool {
+	for _, f := range l.filters {
+		if f(msg) {
+			return true
+		}
+	}
+
+	for _, a := range l.accels {
+		if (msg.HWnd == a.hwnd || IsChild(a.hwnd, msg.HWnd)) && TranslateAccelerator(a.hwnd, a.haccel, msg) {
+			return true
+		}
+	}
+
+	for _, d := range l.dialogs {
+		if IsDialogMessage(d, msg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Run pumps messages until WM_QUIT is received and returns its exit code.
+// Whenever the queue is empty, it runs the functions queued with
+// RunWhenIdle.
+func (l *MessageLoop) Run() int {
+	if GetCurrentThreadId() != l.threadID {
+		panic("win: MessageLoop.Run called on a different thread than NewMessageLoop")
+	}
+
+	var msg MSG
+	for {
+		if l.runIdle() {
+			continue
+		}
+
+		switch GetMessage(&msg, 0, 0, 0) {
+		case 0:
+			return int(msg.WParam)
+
+		case -1:
+			return -1
+		}
+
+		if l.PreTranslate(&msg) {
+			continue
+		}
+
+		TranslateMessage(&msg)
+		DispatchMessage(&msg)
+	}
+}
+
+// Dispose destroys the window used by Post and undoes the thread lock taken
+// by NewMessageLoop. It must be called on the loop thread.
+func (l *MessageLoop) Dispose() {
+	if l.disposed {
+		return
+	}
+	l.disposed = true
+
+	l.postWindow.Destroy()
+
+	runtime.UnlockOSThread()
+}
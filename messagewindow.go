@@ -0,0 +1,352 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"sync"
+	"syscall"
+)
+
+var ErrMessageWindowDestroyed = errors.New("message window has been destroyed")
+
+// MessageWindow is an invisible message-only window. It receives messages
+// sent or posted to it, e.g. tray icon callbacks, clipboard or device
+// notifications, and runs functions passed to Invoke and BeginInvoke from
+// any goroutine on its thread.
+//
+// Message-only windows do not receive broadcasts such as WM_POWERBROADCAST,
+// WM_SETTINGCHANGE or WM_QUERYENDSESSION. For those, NewBroadcastWindow and
+// StartBroadcastWindow create a MessageWindow that is a hidden top-level
+// window instead.
+type MessageWindow struct {
+	*Window
+
+	threadID  uint32
+	invokeMsg uint32
+
+	// loop and done are set for windows started with StartMessageWindow.
+	loop      *MessageLoop
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu    sync.Mutex
+	hwnd  HWND // 0 once destroyed
+	queue []*invocation
+}
+
+type invocation struct {
+	f    func()
+	done chan invocationResult // nil for BeginInvoke
+}
+
+type invocationResult struct {
+	err      error
+	panicked bool
+	value    interface{}
+}
+
+const messageWindowClassName = "win.MessageWindow"
+
+var (
+	messageWindowClassOnce sync.Once
+	messageWindowClassErr  error
+	messageWindowInvokeMsg uint32
+)
+
+func registerMessageWindowClass() error {
+	messageWindowClassOnce.Do(func() {
+		if _, messageWindowClassErr = RegisterWindowClass(&WindowClass{Name: messageWindowClassName}); messageWindowClassErr != nil {
+			return
+		}
+
+		name, err := syscall.UTF16PtrFromString(messageWindowClassName + ".Invoke")
+		if err != nil {
+			messageWindowClassErr = err
+			return
+		}
+
+		if messageWindowInvokeMsg = RegisterWindowMessage(name); messageWindowInvokeMsg == 0 {
+			messageWindowClassErr = errors.New("RegisterWindowMessage failed")
+		}
+	})
+
+	return messageWindowClassErr
+}
+
+// NewMessageWindow creates a message-only window on the calling thread,
+// which must stay locked to the goroutine and pump messages, e.g. with a
+// MessageLoop. Use StartMessageWindow to run it on a thread of its own.
+func NewMessageWindow() (*MessageWindow, error) {
+	return newMessageWindow(HWND_MESSAGE)
+}
+
+// NewBroadcastWindow is like NewMessageWindow but creates a hidden
+// top-level window, which also receives broadcast messages.
+func NewBroadcastWindow() (*MessageWindow, error) {
+	return newMessageWindow(0)
+}
+
+func newMessageWindow(parent HWND) (*MessageWindow, error) {
+	if err := registerMessageWindowClass(); err != nil {
+		return nil, err
+	}
+
+	mw := &MessageWindow{
+		Window:    NewWindow(),
+		threadID:  GetCurrentThreadId(),
+		invokeMsg: messageWindowInvokeMsg,
+	}
+
+	mw.Handle(mw.invokeMsg, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		mw.runQueue()
+		return 0, true
+	})
+
+	mw.Handle(WM_DESTROY, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		mw.mu.Lock()
+		queue := mw.queue
+		mw.hwnd = 0
+		mw.queue = nil
+		mw.mu.Unlock()
+
+		for _, inv := range queue {
+			if inv.done != nil {
+				inv.done <- invocationResult{err: ErrMessageWindowDestroyed}
+			}
+		}
+
+		return 0, false
+	})
+
+	// WS_EX_TOOLWINDOW keeps a top-level window out of the taskbar and
+	// Alt+Tab, should it ever be shown.
+	params := &CreateWindowParams{
+		ClassName: messageWindowClassName,
+		Parent:    parent,
+	}
+	if parent == 0 {
+		params.ExStyle = WS_EX_TOOLWINDOW
+	}
+
+	if err := mw.Create(params); err != nil {
+		return nil, err
+	}
+
+	mw.mu.Lock()
+	mw.hwnd = mw.Window.HWND()
+	mw.mu.Unlock()
+
+	return mw, nil
+}
+
+// StartMessageWindow creates a message-only window on a new thread running
+// a MessageLoop. setup, if not nil, runs on that thread before the loop
+// starts and is the place to register handlers; if it returns an error,
+// the window is destroyed and the error returned. Call Close to stop the
+// thread.
+func StartMessageWindow(setup func(mw *MessageWindow) error) (*MessageWindow, error) {
+	return startMessageWindow(HWND_MESSAGE, setup)
+}
+
+// StartBroadcastWindow is like StartMessageWindow but creates a hidden
+// top-level window, which also receives broadcast messages.
+func StartBroadcastWindow(setup func(mw *MessageWindow) error) (*MessageWindow, error) {
+	return startMessageWindow(0, setup)
+}
+
+func startMessageWindow(parent HWND, setup func(mw *MessageWindow) error) (*MessageWindow, error) {
+	type result struct {
+		mw   *MessageWindow
+		loop *MessageLoop
+		err  error
+	}
+
+	started := make(chan result, 1)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		loop, err := NewMessageLoop()
+		if err != nil {
+			started <- result{err: err}
+			return
+		}
+		defer loop.Dispose()
+
+		mw, err := newMessageWindow(parent)
+		if err != nil {
+			started <- result{err: err}
+			return
+		}
+		defer mw.Destroy()
+
+		if setup != nil {
+			if err := setup(mw); err != nil {
+				started <- result{err: err}
+				return
+			}
+		}
+
+		started <- result{mw: mw, loop: loop}
+
+		loop.Run()
+	}()
+
+	r := <-started
+	if r.err != nil {
+		<-done
+		return nil, r.err
+	}
+
+	r.mw.loop = r.loop
+	r.mw.done = done
+
+	return r.mw, nil
+}
+
+// HWND returns the window handle, or 0 once the window has been destroyed.
+// Unlike the Window methods, it is safe to call from any goroutine.
+func (mw *MessageWindow) HWND() HWND {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	return mw.hwnd
+}
+
+// ThreadID returns the id of the thread the window runs on.
+func (mw *MessageWindow) ThreadID() uint32 {
+	return mw.threadID
+}
+
+// BeginInvoke schedules f to run on the window thread and returns without
+// waiting for it. Functions run in the order they were scheduled. Like a
+// panic in a message handler, a panic in f crashes the program.
+func (mw *MessageWindow) BeginInvoke(f func()) error {
+	return mw.post(&invocation{f: f})
+}
+
+// Invoke runs f on the window thread and waits for it to return. Called on
+// the window thread, it runs f directly. If f panics, Invoke panics with
+// the same value in the calling goroutine. It fails if the window is
+// destroyed before f runs.
+func (mw *MessageWindow) Invoke(f func()) error {
+	if GetCurrentThreadId() == mw.threadID {
+		if mw.HWND() == 0 {
+			return ErrMessageWindowDestroyed
+		}
+
+		f()
+		return nil
+	}
+
+	done := make(chan invocationResult, 1)
+	if err := mw.post(&invocation{f: f, done: done}); err != nil {
+		return err
+	}
+
+	r := <-done
+	if r.panicked {
+		panic(r.value)
+	}
+
+	return r.err
+}
+
+func (mw *MessageWindow) post(inv *invocation) error {
+	mw.mu.Lock()
+	hwnd := mw.hwnd
+	if hwnd != 0 {
+		mw.queue = append(mw.queue, inv)
+	}
+	mw.mu.Unlock()
+
+	if hwnd == 0 {
+		return ErrMessageWindowDestroyed
+	}
+
+	if PostMessage(hwnd, mw.invokeMsg, 0, 0) == 0 {
+		// Do not run f later when the caller has been told it failed.
+		mw.mu.Lock()
+		for i, queued := range mw.queue {
+			if queued == inv {
+				mw.queue = append(mw.queue[:i], mw.queue[i+1:]...)
+				break
+			}
+		}
+		mw.mu.Unlock()
+
+		return errors.New("PostMessage failed")
+	}
+
+	return nil
+}
+
+// runQueue runs the scheduled functions one at a time, so that those still
+// queued when one of them destroys the window fail instead of running.
+func (mw *MessageWindow) runQueue() {
+	for {
+		mw.mu.Lock()
+		if len(mw.queue) == 0 {
+			mw.mu.Unlock()
+			return
+		}
+		inv := mw.queue[0]
+		mw.queue[0] = nil
+		mw.queue = mw.queue[1:]
+		mw.mu.Unlock()
+
+		inv.run()
+	}
+}
+
+func (inv *invocation) run() {
+	if inv.done == nil {
+		inv.f()
+		return
+	}
+
+	panicked := true
+	defer func() {
+		if panicked {
+			inv.done <- invocationResult{panicked: true, value: recover()}
+		} else {
+			inv.done <- invocationResult{}
+		}
+	}()
+
+	inv.f()
+	panicked = false
+}
+
+// Close destroys the window. For windows started with StartMessageWindow,
+// it also stops their thread and, unless called on it, waits for it to
+// exit. It is safe to call from any goroutine.
+func (mw *MessageWindow) Close() error {
+	if mw.loop != nil {
+		var err error
+
+		mw.closeOnce.Do(func() {
+			err = mw.loop.Quit(0)
+			if err == nil && GetCurrentThreadId() != mw.threadID {
+				<-mw.done
+			}
+		})
+
+		return err
+	}
+
+	err := mw.Invoke(func() {
+		mw.Destroy()
+	})
+	if err == ErrMessageWindowDestroyed {
+		return nil
+	}
+
+	return err
+}
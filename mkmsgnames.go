@@ -0,0 +1,216 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build ignore
+
+// mkmsgnames generates zmsgnames.go, the tables used by MessageName,
+// WinEventName and friends, from the message, notification and event
+// constants of this package.
+//
+// Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// group describes a set of message constants sharing a name prefix. Only
+// constants whose value lies in [min, max) are messages; the others are
+// flags, return codes or unrelated constants with the same prefix.
+type group struct {
+	key      string
+	prefix   string
+	min, max uint64
+}
+
+var messageGroups = []group{
+	{"CB", "CB_", 0x0140, 0x0400},
+	{"DTM", "DTM_", 0x1000, 0x8000},
+	{"EM", "EM_", 0x00B0, 0x8000},
+	{"HDM", "HDM_", 0x1200, 0x8000},
+	{"LB", "LB_", 0x0180, 0x0400},
+	{"LVM", "LVM_", 0x1000, 0x8000},
+	{"SB", "SB_", 0x0400, 0x8000},
+	{"TB", "TB_", 0x0400, 0x8000},
+	{"TCM", "TCM_", 0x1300, 0x8000},
+	{"TTM", "TTM_", 0x0400, 0x8000},
+	{"TVM", "TVM_", 0x1100, 0x8000},
+	{"UDM", "UDM_", 0x0400, 0x8000},
+}
+
+var notificationPrefixes = []string{
+	"DTN_", "HDN_", "LVN_", "NM_", "SBN_", "TBN_", "TCN_", "TTN_", "TVN_", "UDN_",
+}
+
+// Range markers and aliases are never reported as names.
+func skip(name string) bool {
+	return strings.HasSuffix(name, "FIRST") ||
+		strings.HasSuffix(name, "FIRST2") ||
+		strings.HasSuffix(name, "LAST") ||
+		name == "WM_WININICHANGE"
+}
+
+// skipEvent reports whether name is an EVENT_* range marker rather than an
+// event.
+func skipEvent(name string) bool {
+	return strings.HasSuffix(name, "_START") ||
+		strings.HasSuffix(name, "_END") ||
+		name == "EVENT_MIN" ||
+		name == "EVENT_MAX"
+}
+
+const header = `// Code generated by mkmsgnames.go; DO NOT EDIT.
+
+// +build windows
+
+`
+
+type nopImporter struct{}
+
+func (nopImporter) Import(path string) (*types.Package, error) {
+	return types.NewPackage(path, filepath.Base(path)), nil
+}
+
+func main() {
+	ctx := build.Default
+	ctx.GOOS = "windows"
+	ctx.GOARCH = "amd64"
+
+	pkg, err := ctx.ImportDir(".", 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range pkg.GoFiles {
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+		files = append(files, f)
+	}
+
+	// Imported packages are stubbed out, which produces type errors for
+	// function bodies. They do not affect constant evaluation.
+	conf := types.Config{Importer: nopImporter{}, Error: func(error) {}}
+	tpkg, _ := conf.Check("win", fset, files, nil)
+
+	wm := make(map[uint32]string)
+	notifications := make(map[uint32]string)
+	events := make(map[uint32]string)
+	controls := make(map[string]map[uint32]string)
+
+	add := func(m map[uint32]string, value uint32, name string) {
+		if old, ok := m[value]; !ok || name < old {
+			m[value] = name
+		}
+	}
+
+	scope := tpkg.Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || skip(name) || c.Val().Kind() != constant.Int {
+			continue
+		}
+
+		v, ok := constant.Uint64Val(c.Val())
+		if !ok {
+			i, ok := constant.Int64Val(c.Val())
+			if !ok {
+				continue
+			}
+			v = uint64(uint32(i))
+		}
+
+		if strings.HasPrefix(name, "EVENT_") {
+			if !skipEvent(name) && v <= 0xFFFFFFFF {
+				add(events, uint32(v), name)
+			}
+			continue
+		}
+
+		if strings.HasPrefix(name, "WM_") {
+			if v < 0x10000 {
+				add(wm, uint32(v), name)
+			}
+			continue
+		}
+
+		for _, prefix := range notificationPrefixes {
+			if strings.HasPrefix(name, prefix) && v >= 0x80000000 && v <= 0xFFFFFFFF {
+				add(notifications, uint32(v), name)
+			}
+		}
+
+		for _, g := range messageGroups {
+			if strings.HasPrefix(name, g.prefix) && v >= g.min && v < g.max {
+				if controls[g.key] == nil {
+					controls[g.key] = make(map[uint32]string)
+				}
+				add(controls[g.key], uint32(v), name)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("package win\n\n")
+
+	writeMap := func(m map[uint32]string) {
+		values := make([]uint32, 0, len(m))
+		for v := range m {
+			values = append(values, v)
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+		buf.WriteString("{\n")
+		for _, v := range values {
+			fmt.Fprintf(&buf, "0x%04X: %q,\n", v, m[v])
+		}
+		buf.WriteString("}")
+	}
+
+	buf.WriteString("var messageNames = map[uint32]string")
+	writeMap(wm)
+	buf.WriteString("\n\nvar notificationNames = map[uint32]string")
+	writeMap(notifications)
+	buf.WriteString("\n\nvar eventNames = map[uint32]string")
+	writeMap(events)
+	buf.WriteString("\n\nvar controlMessageNames = map[string]map[uint32]string{\n")
+	for _, g := range messageGroups {
+		if controls[g.key] == nil {
+			continue
+		}
+		fmt.Fprintf(&buf, "%q: ", g.key)
+		writeMap(controls[g.key])
+		buf.WriteString(",\n")
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The header is added after formatting, since newer versions of
+	// go/format would add a //go:build line to it.
+	src = append([]byte(header), src...)
+
+	if err := ioutil.WriteFile("zmsgnames.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,659 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"unsafe"
+)
+
+// This file contains crackers that decode the wParam and lParam of window
+// messages into typed structs, and Pack methods that do the reverse for
+// SendMessage and PostMessage. None of them call into Windows. Messages
+// passing only values and handles are in msgparam.go.
+//
+// Pointers in cracked messages point into memory owned by the sender and
+// are only valid for the duration of the message. The crackers convert
+// lParam to a pointer where the message defines it to hold one; the sender
+// keeps the memory alive until the window procedure returns.
+//
+// Pack methods of messages holding pointers return the addresses as
+// integers, which do not keep the pointed-to values alive. Callers must
+// keep them reachable until the message has been sent, e.g. with
+// runtime.KeepAlive after the SendMessage call.
+
+// CreateMsg is used for WM_CREATE and WM_NCCREATE.
+type CreateMsg struct {
+	CreateStruct *CREATESTRUCT
+}
+
+func CrackCreateMsg(wParam, lParam uintptr) CreateMsg {
+	return CreateMsg{(*CREATESTRUCT)(unsafe.Pointer(lParam))}
+}
+
+func (m CreateMsg) Pack() (wParam, lParam uintptr) {
+	return 0, uintptr(unsafe.Pointer(m.CreateStruct))
+}
+
+// SizingMsg is used for WM_SIZING and WM_MOVING. Rect is in screen
+// coordinates and may be modified by the handler.
+type SizingMsg struct {
+	Edge uint32 // WMSZ_*, unused for WM_MOVING
+	Rect *RECT
+}
+
+func CrackSizingMsg(wParam, lParam uintptr) SizingMsg {
+	return SizingMsg{uint32(wParam), (*RECT)(unsafe.Pointer(lParam))}
+}
+
+func (m SizingMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Edge), uintptr(unsafe.Pointer(m.Rect))
+}
+
+// MinMaxInfoMsg is used for WM_GETMINMAXINFO.
+type MinMaxInfoMsg struct {
+	Info *MINMAXINFO
+}
+
+func CrackMinMaxInfoMsg(wParam, lParam uintptr) MinMaxInfoMsg {
+	return MinMaxInfoMsg{(*MINMAXINFO)(unsafe.Pointer(lParam))}
+}
+
+func (m MinMaxInfoMsg) Pack() (wParam, lParam uintptr) {
+	return 0, uintptr(unsafe.Pointer(m.Info))
+}
+
+// WindowPosMsg is used for WM_WINDOWPOSCHANGING and WM_WINDOWPOSCHANGED.
+type WindowPosMsg struct {
+	Pos *WINDOWPOS
+}
+
+func CrackWindowPosMsg(wParam, lParam uintptr) WindowPosMsg {
+	return WindowPosMsg{(*WINDOWPOS)(unsafe.Pointer(lParam))}
+}
+
+func (m WindowPosMsg) Pack() (wParam, lParam uintptr) {
+	return 0, uintptr(unsafe.Pointer(m.Pos))
+}
+
+// NCCalcSizeMsg is used for WM_NCCALCSIZE. If CalcValidRects is true,
+// Params is set, otherwise Rect is.
+type NCCalcSizeMsg struct {
+	CalcValidRects bool
+	Params         *NCCALCSIZE_PARAMS
+	Rect           *RECT
+}
+
+func CrackNCCalcSizeMsg(wParam, lParam uintptr) NCCalcSizeMsg {
+	if wParam != 0 {
+		return NCCalcSizeMsg{CalcValidRects: true, Params: (*NCCALCSIZE_PARAMS)(unsafe.Pointer(lParam))}
+	}
+
+	return NCCalcSizeMsg{Rect: (*RECT)(unsafe.Pointer(lParam))}
+}
+
+func (m NCCalcSizeMsg) Pack() (wParam, lParam uintptr) {
+	if m.CalcValidRects {
+		return 1, uintptr(unsafe.Pointer(m.Params))
+	}
+
+	return 0, uintptr(unsafe.Pointer(m.Rect))
+}
+
+// StyleMsg is used for WM_STYLECHANGING and WM_STYLECHANGED.
+type StyleMsg struct {
+	Index int32 // GWL_STYLE or GWL_EXSTYLE
+	Style *STYLESTRUCT
+}
+
+func CrackStyleMsg(wParam, lParam uintptr) StyleMsg {
+	return StyleMsg{int32(wParam), (*STYLESTRUCT)(unsafe.Pointer(lParam))}
+}
+
+func (m StyleMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Index), uintptr(unsafe.Pointer(m.Style))
+}
+
+// DPIChangedMsg is used for WM_DPICHANGED. Suggested is the new window
+// rectangle in screen coordinates proposed by the system.
+type DPIChangedMsg struct {
+	DPIX      int32
+	DPIY      int32
+	Suggested *RECT
+}
+
+func CrackDPIChangedMsg(wParam, lParam uintptr) DPIChangedMsg {
+	return DPIChangedMsg{
+		DPIX:      int32(LOWORD(uint32(wParam))),
+		DPIY:      int32(HIWORD(uint32(wParam))),
+		Suggested: (*RECT)(unsafe.Pointer(lParam)),
+	}
+}
+
+func (m DPIChangedMsg) Pack() (wParam, lParam uintptr) {
+	return MAKEWPARAM(uint16(m.DPIX), uint16(m.DPIY)), uintptr(unsafe.Pointer(m.Suggested))
+}
+
+// TextMsg is used for WM_SETTEXT and WM_DEVMODECHANGE.
+type TextMsg struct {
+	Text *uint16
+}
+
+func CrackTextMsg(wParam, lParam uintptr) TextMsg {
+	return TextMsg{(*uint16)(unsafe.Pointer(lParam))}
+}
+
+func (m TextMsg) Pack() (wParam, lParam uintptr) {
+	return 0, uintptr(unsafe.Pointer(m.Text))
+}
+
+// TextBufferMsg is used for WM_GETTEXT and WM_ASKCBFORMATNAME. Size is the
+// size of Buffer in characters, including the terminating NUL.
+type TextBufferMsg struct {
+	Size   uint32
+	Buffer *uint16
+}
+
+func CrackTextBufferMsg(wParam, lParam uintptr) TextBufferMsg {
+	return TextBufferMsg{uint32(wParam), (*uint16)(unsafe.Pointer(lParam))}
+}
+
+func (m TextBufferMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Size), uintptr(unsafe.Pointer(m.Buffer))
+}
+
+// SettingChangeMsg is used for WM_SETTINGCHANGE.
+type SettingChangeMsg struct {
+	Action uint32 // SPI_*
+	Area   *uint16
+}
+
+func CrackSettingChangeMsg(wParam, lParam uintptr) SettingChangeMsg {
+	return SettingChangeMsg{uint32(wParam), (*uint16)(unsafe.Pointer(lParam))}
+}
+
+func (m SettingChangeMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Action), uintptr(unsafe.Pointer(m.Area))
+}
+
+// GetDlgCodeMsg is used for WM_GETDLGCODE. Msg is nil when the dialog
+// manager is only querying the control type.
+type GetDlgCodeMsg struct {
+	VirtualKey uint16
+	Msg        *MSG
+}
+
+func CrackGetDlgCodeMsg(wParam, lParam uintptr) GetDlgCodeMsg {
+	return GetDlgCodeMsg{uint16(wParam), (*MSG)(unsafe.Pointer(lParam))}
+}
+
+func (m GetDlgCodeMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.VirtualKey), uintptr(unsafe.Pointer(m.Msg))
+}
+
+// NotifyMsg is used for WM_NOTIFY.
+type NotifyMsg struct {
+	ID  uintptr
+	Hdr *NMHDR
+}
+
+func CrackNotifyMsg(wParam, lParam uintptr) NotifyMsg {
+	return NotifyMsg{wParam, (*NMHDR)(unsafe.Pointer(lParam))}
+}
+
+func (m NotifyMsg) Pack() (wParam, lParam uintptr) {
+	return m.ID, uintptr(unsafe.Pointer(m.Hdr))
+}
+
+// ParentNotifyMsg is used for WM_PARENTNOTIFY. For WM_CREATE and
+// WM_DESTROY events, Extra is the child id and Child is set, for mouse
+// events Extra is the XBUTTON* for WM_XBUTTONDOWN and X and Y hold the
+// cursor position in client coordinates.
+type ParentNotifyMsg struct {
+	Event uint16
+	Extra uint16
+	Child HWND
+	X, Y  int32
+}
+
+func CrackParentNotifyMsg(wParam, lParam uintptr) ParentNotifyMsg {
+	m := ParentNotifyMsg{
+		Event: LOWORD(uint32(wParam)),
+		Extra: HIWORD(uint32(wParam)),
+	}
+
+	if m.Event == WM_CREATE || m.Event == WM_DESTROY {
+		m.Child = HWND(lParam)
+	} else {
+		m.X, m.Y = GET_X_LPARAM(lParam), GET_Y_LPARAM(lParam)
+	}
+
+	return m
+}
+
+func (m ParentNotifyMsg) Pack() (wParam, lParam uintptr) {
+	wParam = MAKEWPARAM(m.Event, m.Extra)
+
+	if m.Event == WM_CREATE || m.Event == WM_DESTROY {
+		return wParam, uintptr(m.Child)
+	}
+
+	return wParam, pointLParam(m.X, m.Y)
+}
+
+// MenuGetObjectMsg is used for WM_MENUGETOBJECT.
+type MenuGetObjectMsg struct {
+	Info *MENUGETOBJECTINFO
+}
+
+func CrackMenuGetObjectMsg(wParam, lParam uintptr) MenuGetObjectMsg {
+	return MenuGetObjectMsg{(*MENUGETOBJECTINFO)(unsafe.Pointer(lParam))}
+}
+
+func (m MenuGetObjectMsg) Pack() (wParam, lParam uintptr) {
+	return 0, uintptr(unsafe.Pointer(m.Info))
+}
+
+// NextMenuMsg is used for WM_NEXTMENU.
+type NextMenuMsg struct {
+	VirtualKey uint32
+	Info       *MDINEXTMENU
+}
+
+func CrackNextMenuMsg(wParam, lParam uintptr) NextMenuMsg {
+	return NextMenuMsg{uint32(wParam), (*MDINEXTMENU)(unsafe.Pointer(lParam))}
+}
+
+func (m NextMenuMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.VirtualKey), uintptr(unsafe.Pointer(m.Info))
+}
+
+// DrawItemMsg is used for WM_DRAWITEM.
+type DrawItemMsg struct {
+	ID   uint32
+	Item *DRAWITEMSTRUCT
+}
+
+func CrackDrawItemMsg(wParam, lParam uintptr) DrawItemMsg {
+	return DrawItemMsg{uint32(wParam), (*DRAWITEMSTRUCT)(unsafe.Pointer(lParam))}
+}
+
+func (m DrawItemMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.ID), uintptr(unsafe.Pointer(m.Item))
+}
+
+// MeasureItemMsg is used for WM_MEASUREITEM.
+type MeasureItemMsg struct {
+	ID   uint32
+	Item *MEASUREITEMSTRUCT
+}
+
+func CrackMeasureItemMsg(wParam, lParam uintptr) MeasureItemMsg {
+	return MeasureItemMsg{uint32(wParam), (*MEASUREITEMSTRUCT)(unsafe.Pointer(lParam))}
+}
+
+func (m MeasureItemMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.ID), uintptr(unsafe.Pointer(m.Item))
+}
+
+// DeleteItemMsg is used for WM_DELETEITEM.
+type DeleteItemMsg struct {
+	ID   uint32
+	Item *DELETEITEMSTRUCT
+}
+
+func CrackDeleteItemMsg(wParam, lParam uintptr) DeleteItemMsg {
+	return DeleteItemMsg{uint32(wParam), (*DELETEITEMSTRUCT)(unsafe.Pointer(lParam))}
+}
+
+func (m DeleteItemMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.ID), uintptr(unsafe.Pointer(m.Item))
+}
+
+// CompareItemMsg is used for WM_COMPAREITEM.
+type CompareItemMsg struct {
+	ID   uint32
+	Item *COMPAREITEMSTRUCT
+}
+
+func CrackCompareItemMsg(wParam, lParam uintptr) CompareItemMsg {
+	return CompareItemMsg{uint32(wParam), (*COMPAREITEMSTRUCT)(unsafe.Pointer(lParam))}
+}
+
+func (m CompareItemMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.ID), uintptr(unsafe.Pointer(m.Item))
+}
+
+// CopyDataMsg is used for WM_COPYDATA.
+type CopyDataMsg struct {
+	Sender HWND
+	Data   *COPYDATASTRUCT
+}
+
+func CrackCopyDataMsg(wParam, lParam uintptr) CopyDataMsg {
+	return CopyDataMsg{HWND(wParam), (*COPYDATASTRUCT)(unsafe.Pointer(lParam))}
+}
+
+func (m CopyDataMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Sender), uintptr(unsafe.Pointer(m.Data))
+}
+
+// HelpMsg is used for WM_HELP.
+type HelpMsg struct {
+	Info *HELPINFO
+}
+
+func CrackHelpMsg(wParam, lParam uintptr) HelpMsg {
+	return HelpMsg{(*HELPINFO)(unsafe.Pointer(lParam))}
+}
+
+func (m HelpMsg) Pack() (wParam, lParam uintptr) {
+	return 0, uintptr(unsafe.Pointer(m.Info))
+}
+
+// MDICreateMsg is used for WM_MDICREATE.
+type MDICreateMsg struct {
+	Create *MDICREATESTRUCT
+}
+
+func CrackMDICreateMsg(wParam, lParam uintptr) MDICreateMsg {
+	return MDICreateMsg{(*MDICREATESTRUCT)(unsafe.Pointer(lParam))}
+}
+
+func (m MDICreateMsg) Pack() (wParam, lParam uintptr) {
+	return 0, uintptr(unsafe.Pointer(m.Create))
+}
+
+// MDIGetActiveMsg is used for WM_MDIGETACTIVE. Maximized may be nil.
+type MDIGetActiveMsg struct {
+	Maximized *BOOL
+}
+
+func CrackMDIGetActiveMsg(wParam, lParam uintptr) MDIGetActiveMsg {
+	return MDIGetActiveMsg{(*BOOL)(unsafe.Pointer(lParam))}
+}
+
+func (m MDIGetActiveMsg) Pack() (wParam, lParam uintptr) {
+	return 0, uintptr(unsafe.Pointer(m.Maximized))
+}
+
+// CrackMessage returns the cracked parameters of any WM_* message defined
+// in this package, e.g. a SizeMsg for WM_SIZE. Messages in the WM_USER and
+// WM_APP ranges, obsolete messages and unknown messages yield a RawMsg.
+func CrackMessage(msg uint32, wParam, lParam uintptr) MessagePacker {
+	switch msg {
+	case WM_CANCELJOURNAL, WM_CANCELMODE, WM_CHILDACTIVATE, WM_CLEAR, WM_CLIPBOARDUPDATE,
+		WM_CLOSE, WM_COPY, WM_CUT, WM_DESTROY, WM_DESTROYCLIPBOARD, WM_DRAWCLIPBOARD,
+		WM_ENTERSIZEMOVE, WM_EXITSIZEMOVE, WM_FONTCHANGE, WM_GETFONT, WM_GETHOTKEY,
+		WM_GETTEXTLENGTH, WM_MDIICONARRANGE, WM_MDIREFRESHMENU, WM_MOUSELEAVE,
+		WM_NCDESTROY, WM_NCMOUSELEAVE, WM_NULL, WM_PAINT, WM_PAINTICON, WM_PASTE,
+		WM_QUERYDRAGICON, WM_QUERYNEWPALETTE, WM_QUERYOPEN, WM_QUERYUISTATE, WM_QUEUESYNC,
+		WM_RENDERALLFORMATS, WM_SYNCPAINT, WM_SYSCOLORCHANGE, WM_THEMECHANGED,
+		WM_TIMECHANGE, WM_UNDO, WM_USERCHANGED:
+		return CrackEmptyMsg(wParam, lParam)
+
+	case WM_COMPACTING, WM_MDICASCADE, WM_MDITILE, WM_RENDERFORMAT:
+		return CrackValueMsg(wParam, lParam)
+
+	case WM_ENABLE, WM_ENTERMENULOOP, WM_EXITMENULOOP, WM_SETREDRAW:
+		return CrackBoolMsg(wParam, lParam)
+
+	case WM_KILLFOCUS, WM_MDIDESTROY, WM_MDIMAXIMIZE, WM_MDIRESTORE, WM_PALETTECHANGED,
+		WM_PALETTEISCHANGING, WM_SETFOCUS:
+		return CrackWindowMsg(wParam, lParam)
+
+	case WM_MOVE, WM_NCHITTEST:
+		return CrackPointMsg(wParam, lParam)
+
+	case WM_DEVICECHANGE, WM_INPUT_DEVICE_CHANGE, WM_POWERBROADCAST:
+		return CrackEventMsg(wParam, lParam)
+
+	case WM_ACTIVATE:
+		return CrackActivateMsg(wParam, lParam)
+
+	case WM_ACTIVATEAPP:
+		return CrackActivateAppMsg(wParam, lParam)
+
+	case WM_NCACTIVATE:
+		return CrackNCActivateMsg(wParam, lParam)
+
+	case WM_CAPTURECHANGED:
+		return CrackCaptureChangedMsg(wParam, lParam)
+
+	case WM_SHOWWINDOW:
+		return CrackShowWindowMsg(wParam, lParam)
+
+	case WM_ENDSESSION, WM_QUERYENDSESSION:
+		return CrackEndSessionMsg(wParam, lParam)
+
+	case WM_QUIT:
+		return CrackQuitMsg(wParam, lParam)
+
+	case WM_CREATE, WM_NCCREATE:
+		return CrackCreateMsg(wParam, lParam)
+
+	case WM_INITDIALOG:
+		return CrackInitDialogMsg(wParam, lParam)
+
+	case WM_NEXTDLGCTL:
+		return CrackNextDlgCtlMsg(wParam, lParam)
+
+	case WM_SIZE:
+		return CrackSizeMsg(wParam, lParam)
+
+	case WM_SIZING, WM_MOVING:
+		return CrackSizingMsg(wParam, lParam)
+
+	case WM_GETMINMAXINFO:
+		return CrackMinMaxInfoMsg(wParam, lParam)
+
+	case WM_WINDOWPOSCHANGING, WM_WINDOWPOSCHANGED:
+		return CrackWindowPosMsg(wParam, lParam)
+
+	case WM_NCCALCSIZE:
+		return CrackNCCalcSizeMsg(wParam, lParam)
+
+	case WM_STYLECHANGING, WM_STYLECHANGED:
+		return CrackStyleMsg(wParam, lParam)
+
+	case WM_DPICHANGED:
+		return CrackDPIChangedMsg(wParam, lParam)
+
+	case WM_DISPLAYCHANGE:
+		return CrackDisplayChangeMsg(wParam, lParam)
+
+	case WM_ERASEBKGND, WM_ICONERASEBKGND:
+		return CrackDCMsg(wParam, lParam)
+
+	case WM_NCPAINT:
+		return CrackNCPaintMsg(wParam, lParam)
+
+	case WM_PRINT, WM_PRINTCLIENT:
+		return CrackPrintMsg(wParam, lParam)
+
+	case WM_CTLCOLORBTN, WM_CTLCOLORDLG, WM_CTLCOLOREDIT, WM_CTLCOLORLISTBOX,
+		WM_CTLCOLORMSGBOX, WM_CTLCOLORSCROLLBAR, WM_CTLCOLORSTATIC:
+		return CrackCtlColorMsg(wParam, lParam)
+
+	case WM_SETFONT:
+		return CrackSetFontMsg(wParam, lParam)
+
+	case WM_SETICON:
+		return CrackSetIconMsg(wParam, lParam)
+
+	case WM_GETICON:
+		return CrackGetIconMsg(wParam, lParam)
+
+	case WM_SETTEXT, WM_DEVMODECHANGE:
+		return CrackTextMsg(wParam, lParam)
+
+	case WM_GETTEXT, WM_ASKCBFORMATNAME:
+		return CrackTextBufferMsg(wParam, lParam)
+
+	case WM_SETTINGCHANGE:
+		return CrackSettingChangeMsg(wParam, lParam)
+
+	case WM_KEYDOWN, WM_KEYUP, WM_SYSKEYDOWN, WM_SYSKEYUP:
+		return CrackKeyMsg(wParam, lParam)
+
+	case WM_CHAR, WM_DEADCHAR, WM_SYSCHAR, WM_SYSDEADCHAR, WM_UNICHAR:
+		return CrackCharMsg(wParam, lParam)
+
+	case WM_HOTKEY:
+		return CrackHotKeyMsg(wParam, lParam)
+
+	case WM_SETHOTKEY:
+		return CrackSetHotKeyMsg(wParam, lParam)
+
+	case WM_GETDLGCODE:
+		return CrackGetDlgCodeMsg(wParam, lParam)
+
+	case WM_VKEYTOITEM, WM_CHARTOITEM:
+		return CrackListBoxKeyMsg(wParam, lParam)
+
+	case WM_INPUTLANGCHANGE, WM_INPUTLANGCHANGEREQUEST:
+		return CrackInputLangMsg(wParam, lParam)
+
+	case WM_MOUSEMOVE, WM_MOUSEHOVER,
+		WM_LBUTTONDOWN, WM_LBUTTONUP, WM_LBUTTONDBLCLK,
+		WM_RBUTTONDOWN, WM_RBUTTONUP, WM_RBUTTONDBLCLK,
+		WM_MBUTTONDOWN, WM_MBUTTONUP, WM_MBUTTONDBLCLK,
+		WM_XBUTTONDOWN, WM_XBUTTONUP, WM_XBUTTONDBLCLK:
+		return CrackMouseMsg(wParam, lParam)
+
+	case WM_MOUSEWHEEL, WM_MOUSEHWHEEL:
+		return CrackMouseWheelMsg(wParam, lParam)
+
+	case WM_NCMOUSEMOVE, WM_NCMOUSEHOVER,
+		WM_NCLBUTTONDOWN, WM_NCLBUTTONUP, WM_NCLBUTTONDBLCLK,
+		WM_NCRBUTTONDOWN, WM_NCRBUTTONUP, WM_NCRBUTTONDBLCLK,
+		WM_NCMBUTTONDOWN, WM_NCMBUTTONUP, WM_NCMBUTTONDBLCLK,
+		WM_NCXBUTTONDOWN, WM_NCXBUTTONUP, WM_NCXBUTTONDBLCLK:
+		return CrackNCMouseMsg(wParam, lParam)
+
+	case WM_MOUSEACTIVATE:
+		return CrackMouseActivateMsg(wParam, lParam)
+
+	case WM_SETCURSOR:
+		return CrackSetCursorMsg(wParam, lParam)
+
+	case WM_CONTEXTMENU:
+		return CrackContextMenuMsg(wParam, lParam)
+
+	case WM_COMMAND:
+		return CrackCommandMsg(wParam, lParam)
+
+	case WM_SYSCOMMAND:
+		return CrackSysCommandMsg(wParam, lParam)
+
+	case WM_NOTIFY:
+		return CrackNotifyMsg(wParam, lParam)
+
+	case WM_NOTIFYFORMAT:
+		return CrackNotifyFormatMsg(wParam, lParam)
+
+	case WM_PARENTNOTIFY:
+		return CrackParentNotifyMsg(wParam, lParam)
+
+	case WM_HSCROLL, WM_VSCROLL:
+		return CrackScrollMsg(wParam, lParam)
+
+	case WM_TIMER:
+		return CrackTimerMsg(wParam, lParam)
+
+	case WM_INITMENU:
+		return CrackMenuMsg(wParam, lParam)
+
+	case WM_INITMENUPOPUP:
+		return CrackInitMenuPopupMsg(wParam, lParam)
+
+	case WM_UNINITMENUPOPUP:
+		return CrackUninitMenuPopupMsg(wParam, lParam)
+
+	case WM_MENUSELECT:
+		return CrackMenuSelectMsg(wParam, lParam)
+
+	case WM_MENUCOMMAND, WM_MENURBUTTONUP, WM_MENUDRAG:
+		return CrackMenuItemMsg(wParam, lParam)
+
+	case WM_MENUGETOBJECT:
+		return CrackMenuGetObjectMsg(wParam, lParam)
+
+	case WM_MENUCHAR:
+		return CrackMenuCharMsg(wParam, lParam)
+
+	case WM_NEXTMENU:
+		return CrackNextMenuMsg(wParam, lParam)
+
+	case WM_ENTERIDLE:
+		return CrackEnterIdleMsg(wParam, lParam)
+
+	case WM_CHANGEUISTATE, WM_UPDATEUISTATE:
+		return CrackUIStateMsg(wParam, lParam)
+
+	case WM_APPCOMMAND:
+		return CrackAppCommandMsg(wParam, lParam)
+
+	case WM_GETOBJECT:
+		return CrackGetObjectMsg(wParam, lParam)
+
+	case WM_DRAWITEM:
+		return CrackDrawItemMsg(wParam, lParam)
+
+	case WM_MEASUREITEM:
+		return CrackMeasureItemMsg(wParam, lParam)
+
+	case WM_DELETEITEM:
+		return CrackDeleteItemMsg(wParam, lParam)
+
+	case WM_COMPAREITEM:
+		return CrackCompareItemMsg(wParam, lParam)
+
+	case WM_COPYDATA:
+		return CrackCopyDataMsg(wParam, lParam)
+
+	case WM_HELP:
+		return CrackHelpMsg(wParam, lParam)
+
+	case WM_TCARD:
+		return CrackTCardMsg(wParam, lParam)
+
+	case WM_SPOOLERSTATUS:
+		return CrackSpoolerStatusMsg(wParam, lParam)
+
+	case WM_DROPFILES:
+		return CrackDropFilesMsg(wParam, lParam)
+
+	case WM_INPUT:
+		return CrackInputMsg(wParam, lParam)
+
+	case WM_PAINTCLIPBOARD, WM_SIZECLIPBOARD:
+		return CrackClipboardViewerMsg(wParam, lParam)
+
+	case WM_HSCROLLCLIPBOARD, WM_VSCROLLCLIPBOARD:
+		return CrackClipboardScrollMsg(wParam, lParam)
+
+	case WM_CHANGECBCHAIN:
+		return CrackChangeCBChainMsg(wParam, lParam)
+
+	case WM_MDIACTIVATE:
+		return CrackMDIActivateMsg(wParam, lParam)
+
+	case WM_MDICREATE:
+		return CrackMDICreateMsg(wParam, lParam)
+
+	case WM_MDIGETACTIVE:
+		return CrackMDIGetActiveMsg(wParam, lParam)
+
+	case WM_MDINEXT:
+		return CrackMDINextMsg(wParam, lParam)
+
+	case WM_MDISETMENU:
+		return CrackMDISetMenuMsg(wParam, lParam)
+	}
+
+	return CrackRawMsg(wParam, lParam)
+}
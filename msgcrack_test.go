@@ -0,0 +1,49 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestCrackPackPointerRoundTrip(t *testing.T) {
+	rect := &RECT{1, 2, 3, 4}
+	info := new(MINMAXINFO)
+	pos := new(WINDOWPOS)
+	params := new(NCCALCSIZE_PARAMS)
+	text := []uint16{'a', 0}
+
+	tests := []struct {
+		msg   uint32
+		value MessagePacker
+	}{
+		{WM_SIZING, SizingMsg{8, rect}}, // WMSZ_BOTTOMRIGHT
+		{WM_GETMINMAXINFO, MinMaxInfoMsg{info}},
+		{WM_WINDOWPOSCHANGED, WindowPosMsg{pos}},
+		{WM_NCCALCSIZE, NCCalcSizeMsg{CalcValidRects: true, Params: params}},
+		{WM_NCCALCSIZE, NCCalcSizeMsg{Rect: rect}},
+		{WM_DPICHANGED, DPIChangedMsg{144, 120, rect}},
+		{WM_SETTEXT, TextMsg{&text[0]}},
+		{WM_GETTEXT, TextBufferMsg{2, &text[0]}},
+	}
+
+	for _, tt := range tests {
+		wParam, lParam := tt.value.Pack()
+
+		if got := CrackMessage(tt.msg, wParam, lParam); got != tt.value {
+			t.Errorf("CrackMessage(%#x, %#v.Pack()) = %#v", tt.msg, tt.value, got)
+		}
+	}
+
+	// The packed addresses do not keep the values alive.
+	runtime.KeepAlive(rect)
+	runtime.KeepAlive(info)
+	runtime.KeepAlive(pos)
+	runtime.KeepAlive(params)
+	runtime.KeepAlive(text)
+}
@@ -0,0 +1,108 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+//go:generate go run mkmsgnames.go
+
+package win
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+)
+
+// classMessageGroups maps lower case window class names to the keys of
+// controlMessageNames. Class names are compared case-insensitively, like
+// Windows does.
+var classMessageGroups = map[string]string{
+	"combobox":           "CB",
+	"combolbox":          "LB",
+	"edit":               "EM",
+	"listbox":            "LB",
+	"msctls_statusbar32": "SB",
+	"msctls_updown32":    "UDM",
+	"richedit20a":        "EM",
+	"richedit20w":        "EM",
+	"richedit50w":        "EM",
+	"sysdatetimepick32":  "DTM",
+	"sysheader32":        "HDM",
+	"syslistview32":      "LVM",
+	"systabcontrol32":    "TCM",
+	"systreeview32":      "TVM",
+	"toolbarwindow32":    "TB",
+	"tooltips_class32":   "TTM",
+}
+
+// MessageName returns the name of the window message msg, e.g.
+// "WM_LBUTTONDOWN". Messages without a name are reported relative to
+// WM_USER or WM_APP, registered messages by their registered name, and
+// everything else in hex.
+//
+// Control messages such as LVM_* overlap numerically with each other; use
+// ClassMessageName or WindowMessageName to name them.
+func MessageName(msg uint32) string {
+	if name, ok := messageNames[msg]; ok {
+		return name
+	}
+
+	switch {
+	case msg >= WM_USER && msg < WM_APP:
+		return fmt.Sprintf("WM_USER+0x%X", msg-WM_USER)
+
+	case msg >= WM_APP && msg < 0xC000:
+		return fmt.Sprintf("WM_APP+0x%X", msg-WM_APP)
+
+	case msg >= 0xC000 && msg <= 0xFFFF:
+		// Registered window messages share their atom table with
+		// registered clipboard formats.
+		var buf [256]uint16
+		if n := GetClipboardFormatName(msg, &buf[0], int32(len(buf))); n > 0 {
+			return fmt.Sprintf("%q", syscall.UTF16ToString(buf[:n]))
+		}
+	}
+
+	return fmt.Sprintf("0x%04X", msg)
+}
+
+// ClassMessageName is like MessageName, but first looks msg up among the
+// messages of the control with the window class className.
+func ClassMessageName(className string, msg uint32) string {
+	if group, ok := classMessageGroups[strings.ToLower(className)]; ok {
+		if name, ok := controlMessageNames[group][msg]; ok {
+			return name
+		}
+	}
+
+	return MessageName(msg)
+}
+
+// WindowMessageName is like ClassMessageName, using the class of hwnd.
+func WindowMessageName(hwnd HWND, msg uint32) string {
+	return ClassMessageName(WindowClassName(hwnd), msg)
+}
+
+// NotificationName returns the name of the WM_NOTIFY code, e.g.
+// "LVN_ITEMCHANGED", or the code as a signed number if it is unknown.
+func NotificationName(code uint32) string {
+	if name, ok := notificationNames[code]; ok {
+		return name
+	}
+
+	return fmt.Sprint(int32(code))
+}
+
+// WindowClassName returns the class name of hwnd, or an empty string if it
+// cannot be retrieved.
+func WindowClassName(hwnd HWND) string {
+	var buf [256]uint16
+
+	n, err := GetClassName(hwnd, &buf[0], len(buf))
+	if err != nil {
+		return ""
+	}
+
+	return syscall.UTF16ToString(buf[:n])
+}
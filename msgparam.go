@@ -0,0 +1,1126 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+// The crackers and packers in this file are for messages whose parameters
+// are values or handles. They do not depend on Windows, unlike those in
+// msgcrack.go, which pass pointers.
+
+// WM_KEYDOWN, WM_KEYUP, WM_CHAR etc. lParam flags (HIWORD)
+const (
+	KF_EXTENDED = 0x0100
+	KF_DLGMODE  = 0x0800
+	KF_MENUMODE = 0x1000
+	KF_ALTDOWN  = 0x2000
+	KF_REPEAT   = 0x4000
+	KF_UP       = 0x8000
+)
+
+// WM_APPCOMMAND device values
+const (
+	FAPPCOMMAND_MOUSE = 0x8000
+	FAPPCOMMAND_KEY   = 0
+	FAPPCOMMAND_OEM   = 0x1000
+	FAPPCOMMAND_MASK  = 0xF000
+)
+
+func MAKEWPARAM(lo, hi uint16) uintptr {
+	return uintptr(MAKELONG(lo, hi))
+}
+
+func MAKELPARAM(lo, hi uint16) uintptr {
+	return uintptr(MAKELONG(lo, hi))
+}
+
+func GET_WHEEL_DELTA_WPARAM(wParam uintptr) int16 {
+	return int16(HIWORD(uint32(wParam)))
+}
+
+func GET_KEYSTATE_WPARAM(wParam uintptr) uint16 {
+	return LOWORD(uint32(wParam))
+}
+
+func GET_XBUTTON_WPARAM(wParam uintptr) uint16 {
+	return HIWORD(uint32(wParam))
+}
+
+func GET_NCHITTEST_WPARAM(wParam uintptr) int16 {
+	return int16(LOWORD(uint32(wParam)))
+}
+
+func GET_APPCOMMAND_LPARAM(lParam uintptr) uint16 {
+	return HIWORD(uint32(lParam)) &^ FAPPCOMMAND_MASK
+}
+
+func GET_DEVICE_LPARAM(lParam uintptr) uint16 {
+	return HIWORD(uint32(lParam)) & FAPPCOMMAND_MASK
+}
+
+func GET_KEYSTATE_LPARAM(lParam uintptr) uint16 {
+	return LOWORD(uint32(lParam))
+}
+
+func GET_RAWINPUT_CODE_WPARAM(wParam uintptr) uint32 {
+	return uint32(wParam & 0xff)
+}
+
+func GET_X_LPARAM(lp uintptr) int32 {
+	return int32(int16(LOWORD(uint32(lp))))
+}
+
+func GET_Y_LPARAM(lp uintptr) int32 {
+	return int32(int16(HIWORD(uint32(lp))))
+}
+
+// MessagePacker is implemented by all cracked message types. For messages
+// holding pointers, Pack returns addresses that do not keep the pointed-to
+// values alive; see msgcrack.go.
+type MessagePacker interface {
+	Pack() (wParam, lParam uintptr)
+}
+
+func boolParam(b bool) uintptr {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func pointLParam(x, y int32) uintptr {
+	return MAKELPARAM(uint16(x), uint16(y))
+}
+
+// EmptyMsg is used for messages without parameters, e.g. WM_CLOSE.
+type EmptyMsg struct{}
+
+func CrackEmptyMsg(wParam, lParam uintptr) EmptyMsg {
+	return EmptyMsg{}
+}
+
+func (m EmptyMsg) Pack() (wParam, lParam uintptr) {
+	return 0, 0
+}
+
+// RawMsg holds the parameters of messages whose meaning is defined by the
+// application, e.g. WM_USER and WM_APP, or that are obsolete.
+type RawMsg struct {
+	WParam uintptr
+	LParam uintptr
+}
+
+func CrackRawMsg(wParam, lParam uintptr) RawMsg {
+	return RawMsg{wParam, lParam}
+}
+
+func (m RawMsg) Pack() (wParam, lParam uintptr) {
+	return m.WParam, m.LParam
+}
+
+// ValueMsg is used for messages whose only parameter is a number in wParam,
+// e.g. WM_COMPACTING, WM_RENDERFORMAT and WM_MDITILE.
+type ValueMsg struct {
+	Value uint32
+}
+
+func CrackValueMsg(wParam, lParam uintptr) ValueMsg {
+	return ValueMsg{uint32(wParam)}
+}
+
+func (m ValueMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Value), 0
+}
+
+// BoolMsg is used for messages whose only parameter is a BOOL in wParam,
+// e.g. WM_ENABLE and WM_SETREDRAW.
+type BoolMsg struct {
+	Value bool
+}
+
+func CrackBoolMsg(wParam, lParam uintptr) BoolMsg {
+	return BoolMsg{wParam != 0}
+}
+
+func (m BoolMsg) Pack() (wParam, lParam uintptr) {
+	return boolParam(m.Value), 0
+}
+
+// WindowMsg is used for messages whose only parameter is a window in
+// wParam, e.g. WM_SETFOCUS, WM_KILLFOCUS and WM_PALETTECHANGED.
+type WindowMsg struct {
+	Window HWND
+}
+
+func CrackWindowMsg(wParam, lParam uintptr) WindowMsg {
+	return WindowMsg{HWND(wParam)}
+}
+
+func (m WindowMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Window), 0
+}
+
+// PointMsg is used for WM_MOVE, in client coordinates, and WM_NCHITTEST, in
+// screen coordinates.
+type PointMsg struct {
+	X, Y int32
+}
+
+func CrackPointMsg(wParam, lParam uintptr) PointMsg {
+	return PointMsg{GET_X_LPARAM(lParam), GET_Y_LPARAM(lParam)}
+}
+
+func (m PointMsg) Pack() (wParam, lParam uintptr) {
+	return 0, pointLParam(m.X, m.Y)
+}
+
+// EventMsg is used for WM_DEVICECHANGE, WM_INPUT_DEVICE_CHANGE and
+// WM_POWERBROADCAST.
+type EventMsg struct {
+	Event uint32
+	Data  uintptr
+}
+
+func CrackEventMsg(wParam, lParam uintptr) EventMsg {
+	return EventMsg{uint32(wParam), lParam}
+}
+
+func (m EventMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Event), m.Data
+}
+
+// ActivateMsg is used for WM_ACTIVATE.
+type ActivateMsg struct {
+	State     uint16 // WA_*
+	Minimized bool
+	Other     HWND
+}
+
+func CrackActivateMsg(wParam, lParam uintptr) ActivateMsg {
+	return ActivateMsg{
+		State:     LOWORD(uint32(wParam)),
+		Minimized: HIWORD(uint32(wParam)) != 0,
+		Other:     HWND(lParam),
+	}
+}
+
+func (m ActivateMsg) Pack() (wParam, lParam uintptr) {
+	return MAKEWPARAM(m.State, uint16(boolParam(m.Minimized))), uintptr(m.Other)
+}
+
+// ActivateAppMsg is used for WM_ACTIVATEAPP.
+type ActivateAppMsg struct {
+	Active   bool
+	ThreadID uint32
+}
+
+func CrackActivateAppMsg(wParam, lParam uintptr) ActivateAppMsg {
+	return ActivateAppMsg{wParam != 0, uint32(lParam)}
+}
+
+func (m ActivateAppMsg) Pack() (wParam, lParam uintptr) {
+	return boolParam(m.Active), uintptr(m.ThreadID)
+}
+
+// NCActivateMsg is used for WM_NCACTIVATE. Region is the update region of
+// the non-client area, or -1 if it should not be redrawn.
+type NCActivateMsg struct {
+	Active bool
+	Region HRGN
+}
+
+func CrackNCActivateMsg(wParam, lParam uintptr) NCActivateMsg {
+	return NCActivateMsg{wParam != 0, HRGN(lParam)}
+}
+
+func (m NCActivateMsg) Pack() (wParam, lParam uintptr) {
+	return boolParam(m.Active), uintptr(m.Region)
+}
+
+// CaptureChangedMsg is used for WM_CAPTURECHANGED.
+type CaptureChangedMsg struct {
+	Window HWND // The window gaining the mouse capture
+}
+
+func CrackCaptureChangedMsg(wParam, lParam uintptr) CaptureChangedMsg {
+	return CaptureChangedMsg{HWND(lParam)}
+}
+
+func (m CaptureChangedMsg) Pack() (wParam, lParam uintptr) {
+	return 0, uintptr(m.Window)
+}
+
+// ShowWindowMsg is used for WM_SHOWWINDOW.
+type ShowWindowMsg struct {
+	Show   bool
+	Status uint32 // SW_PARENTCLOSING etc., or 0 for ShowWindow
+}
+
+func CrackShowWindowMsg(wParam, lParam uintptr) ShowWindowMsg {
+	return ShowWindowMsg{wParam != 0, uint32(lParam)}
+}
+
+func (m ShowWindowMsg) Pack() (wParam, lParam uintptr) {
+	return boolParam(m.Show), uintptr(m.Status)
+}
+
+// EndSessionMsg is used for WM_ENDSESSION and WM_QUERYENDSESSION.
+type EndSessionMsg struct {
+	Ending bool   // Always false for WM_QUERYENDSESSION
+	Flags  uint32 // ENDSESSION_*
+}
+
+func CrackEndSessionMsg(wParam, lParam uintptr) EndSessionMsg {
+	return EndSessionMsg{wParam != 0, uint32(lParam)}
+}
+
+func (m EndSessionMsg) Pack() (wParam, lParam uintptr) {
+	return boolParam(m.Ending), uintptr(m.Flags)
+}
+
+// QuitMsg is used for WM_QUIT.
+type QuitMsg struct {
+	ExitCode int32
+}
+
+func CrackQuitMsg(wParam, lParam uintptr) QuitMsg {
+	return QuitMsg{int32(wParam)}
+}
+
+func (m QuitMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.ExitCode), 0
+}
+
+// InitDialogMsg is used for WM_INITDIALOG.
+type InitDialogMsg struct {
+	Focus HWND
+	Param uintptr
+}
+
+func CrackInitDialogMsg(wParam, lParam uintptr) InitDialogMsg {
+	return InitDialogMsg{HWND(wParam), lParam}
+}
+
+func (m InitDialogMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Focus), m.Param
+}
+
+// NextDlgCtlMsg is used for WM_NEXTDLGCTL. If Handle is true, Control
+// receives the focus, otherwise the next or, if Previous is true, the
+// previous control does.
+type NextDlgCtlMsg struct {
+	Handle   bool
+	Control  HWND
+	Previous bool
+}
+
+func CrackNextDlgCtlMsg(wParam, lParam uintptr) NextDlgCtlMsg {
+	if LOWORD(uint32(lParam)) != 0 {
+		return NextDlgCtlMsg{Handle: true, Control: HWND(wParam)}
+	}
+
+	return NextDlgCtlMsg{Previous: wParam != 0}
+}
+
+func (m NextDlgCtlMsg) Pack() (wParam, lParam uintptr) {
+	if m.Handle {
+		return uintptr(m.Control), 1
+	}
+
+	return boolParam(m.Previous), 0
+}
+
+// SizeMsg is used for WM_SIZE.
+type SizeMsg struct {
+	Type   uint32 // SIZE_*
+	Width  int32
+	Height int32
+}
+
+func CrackSizeMsg(wParam, lParam uintptr) SizeMsg {
+	return SizeMsg{
+		Type:   uint32(wParam),
+		Width:  int32(LOWORD(uint32(lParam))),
+		Height: int32(HIWORD(uint32(lParam))),
+	}
+}
+
+func (m SizeMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Type), MAKELPARAM(uint16(m.Width), uint16(m.Height))
+}
+
+// DisplayChangeMsg is used for WM_DISPLAYCHANGE.
+type DisplayChangeMsg struct {
+	BitsPerPixel uint32
+	Width        int32
+	Height       int32
+}
+
+func CrackDisplayChangeMsg(wParam, lParam uintptr) DisplayChangeMsg {
+	return DisplayChangeMsg{
+		BitsPerPixel: uint32(wParam),
+		Width:        int32(LOWORD(uint32(lParam))),
+		Height:       int32(HIWORD(uint32(lParam))),
+	}
+}
+
+func (m DisplayChangeMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.BitsPerPixel), MAKELPARAM(uint16(m.Width), uint16(m.Height))
+}
+
+// DCMsg is used for WM_ERASEBKGND and WM_ICONERASEBKGND.
+type DCMsg struct {
+	HDC HDC
+}
+
+func CrackDCMsg(wParam, lParam uintptr) DCMsg {
+	return DCMsg{HDC(wParam)}
+}
+
+func (m DCMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.HDC), 0
+}
+
+// NCPaintMsg is used for WM_NCPAINT. Region is 1 if the whole window frame
+// needs to be painted.
+type NCPaintMsg struct {
+	Region HRGN
+}
+
+func CrackNCPaintMsg(wParam, lParam uintptr) NCPaintMsg {
+	return NCPaintMsg{HRGN(wParam)}
+}
+
+func (m NCPaintMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Region), 0
+}
+
+// PrintMsg is used for WM_PRINT and WM_PRINTCLIENT.
+type PrintMsg struct {
+	HDC   HDC
+	Flags uint32 // PRF_*
+}
+
+func CrackPrintMsg(wParam, lParam uintptr) PrintMsg {
+	return PrintMsg{HDC(wParam), uint32(lParam)}
+}
+
+func (m PrintMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.HDC), uintptr(m.Flags)
+}
+
+// CtlColorMsg is used for the WM_CTLCOLOR* messages.
+type CtlColorMsg struct {
+	HDC     HDC
+	Control HWND
+}
+
+func CrackCtlColorMsg(wParam, lParam uintptr) CtlColorMsg {
+	return CtlColorMsg{HDC(wParam), HWND(lParam)}
+}
+
+func (m CtlColorMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.HDC), uintptr(m.Control)
+}
+
+// SetFontMsg is used for WM_SETFONT.
+type SetFontMsg struct {
+	Font   HFONT
+	Redraw bool
+}
+
+func CrackSetFontMsg(wParam, lParam uintptr) SetFontMsg {
+	return SetFontMsg{HFONT(wParam), LOWORD(uint32(lParam)) != 0}
+}
+
+func (m SetFontMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Font), boolParam(m.Redraw)
+}
+
+// SetIconMsg is used for WM_SETICON.
+type SetIconMsg struct {
+	Type uint32 // ICON_BIG, ICON_SMALL or ICON_SMALL2
+	Icon HICON
+}
+
+func CrackSetIconMsg(wParam, lParam uintptr) SetIconMsg {
+	return SetIconMsg{uint32(wParam), HICON(lParam)}
+}
+
+func (m SetIconMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Type), uintptr(m.Icon)
+}
+
+// GetIconMsg is used for WM_GETICON.
+type GetIconMsg struct {
+	Type uint32
+	DPI  uint32
+}
+
+func CrackGetIconMsg(wParam, lParam uintptr) GetIconMsg {
+	return GetIconMsg{uint32(wParam), uint32(lParam)}
+}
+
+func (m GetIconMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Type), uintptr(m.DPI)
+}
+
+// KeyFlags holds the information packed into the lParam of keyboard
+// messages.
+type KeyFlags struct {
+	RepeatCount  uint16
+	ScanCode     uint8
+	Extended     bool
+	AltDown      bool
+	PreviousDown bool
+	Released     bool
+}
+
+func CrackKeyFlags(lParam uintptr) KeyFlags {
+	flags := HIWORD(uint32(lParam))
+
+	return KeyFlags{
+		RepeatCount:  LOWORD(uint32(lParam)),
+		ScanCode:     LOBYTE(flags),
+		Extended:     flags&KF_EXTENDED != 0,
+		AltDown:      flags&KF_ALTDOWN != 0,
+		PreviousDown: flags&KF_REPEAT != 0,
+		Released:     flags&KF_UP != 0,
+	}
+}
+
+func (f KeyFlags) Pack() uintptr {
+	flags := uint16(f.ScanCode)
+	if f.Extended {
+		flags |= KF_EXTENDED
+	}
+	if f.AltDown {
+		flags |= KF_ALTDOWN
+	}
+	if f.PreviousDown {
+		flags |= KF_REPEAT
+	}
+	if f.Released {
+		flags |= KF_UP
+	}
+
+	return MAKELPARAM(f.RepeatCount, flags)
+}
+
+// KeyMsg is used for WM_KEYDOWN, WM_KEYUP, WM_SYSKEYDOWN and WM_SYSKEYUP.
+type KeyMsg struct {
+	VirtualKey uint16
+	KeyFlags
+}
+
+func CrackKeyMsg(wParam, lParam uintptr) KeyMsg {
+	return KeyMsg{uint16(wParam), CrackKeyFlags(lParam)}
+}
+
+func (m KeyMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.VirtualKey), m.KeyFlags.Pack()
+}
+
+// CharMsg is used for WM_CHAR, WM_DEADCHAR, WM_SYSCHAR, WM_SYSDEADCHAR and
+// WM_UNICHAR. Char is a UTF-16 code unit, except for WM_UNICHAR where it is
+// a UTF-32 code point or UNICODE_NOCHAR.
+type CharMsg struct {
+	Char uint32
+	KeyFlags
+}
+
+func CrackCharMsg(wParam, lParam uintptr) CharMsg {
+	return CharMsg{uint32(wParam), CrackKeyFlags(lParam)}
+}
+
+func (m CharMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Char), m.KeyFlags.Pack()
+}
+
+// HotKeyMsg is used for WM_HOTKEY.
+type HotKeyMsg struct {
+	ID         int32
+	Modifiers  uint16 // MOD_*
+	VirtualKey uint16
+}
+
+func CrackHotKeyMsg(wParam, lParam uintptr) HotKeyMsg {
+	return HotKeyMsg{
+		ID:         int32(wParam),
+		Modifiers:  LOWORD(uint32(lParam)),
+		VirtualKey: HIWORD(uint32(lParam)),
+	}
+}
+
+func (m HotKeyMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.ID), MAKELPARAM(m.Modifiers, m.VirtualKey)
+}
+
+// SetHotKeyMsg is used for WM_SETHOTKEY.
+type SetHotKeyMsg struct {
+	VirtualKey byte
+	Modifiers  byte // HOTKEYF_*
+}
+
+func CrackSetHotKeyMsg(wParam, lParam uintptr) SetHotKeyMsg {
+	return SetHotKeyMsg{LOBYTE(uint16(wParam)), HIBYTE(uint16(wParam))}
+}
+
+func (m SetHotKeyMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(MAKEWORD(m.VirtualKey, m.Modifiers)), 0
+}
+
+// ListBoxKeyMsg is used for WM_VKEYTOITEM, where Key is a virtual key, and
+// WM_CHARTOITEM, where Key is a character.
+type ListBoxKeyMsg struct {
+	Key     uint16
+	Caret   uint16
+	ListBox HWND
+}
+
+func CrackListBoxKeyMsg(wParam, lParam uintptr) ListBoxKeyMsg {
+	return ListBoxKeyMsg{
+		Key:     LOWORD(uint32(wParam)),
+		Caret:   HIWORD(uint32(wParam)),
+		ListBox: HWND(lParam),
+	}
+}
+
+func (m ListBoxKeyMsg) Pack() (wParam, lParam uintptr) {
+	return MAKEWPARAM(m.Key, m.Caret), uintptr(m.ListBox)
+}
+
+// InputLangMsg is used for WM_INPUTLANGCHANGE, where Param is the character
+// set, and WM_INPUTLANGCHANGEREQUEST, where Param holds INPUTLANGCHANGE_*
+// flags.
+type InputLangMsg struct {
+	Param  uint32
+	Layout HKL
+}
+
+func CrackInputLangMsg(wParam, lParam uintptr) InputLangMsg {
+	return InputLangMsg{uint32(wParam), HKL(lParam)}
+}
+
+func (m InputLangMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Param), uintptr(m.Layout)
+}
+
+// MouseMsg is used for client area mouse messages such as WM_MOUSEMOVE and
+// WM_LBUTTONDOWN. X and Y are in client coordinates. XButton is set for the
+// WM_XBUTTON* messages only.
+type MouseMsg struct {
+	X, Y    int32
+	Keys    uint16 // MK_*
+	XButton uint16 // XBUTTON1 or XBUTTON2
+}
+
+func CrackMouseMsg(wParam, lParam uintptr) MouseMsg {
+	return MouseMsg{
+		X:       GET_X_LPARAM(lParam),
+		Y:       GET_Y_LPARAM(lParam),
+		Keys:    GET_KEYSTATE_WPARAM(wParam),
+		XButton: GET_XBUTTON_WPARAM(wParam),
+	}
+}
+
+func (m MouseMsg) Pack() (wParam, lParam uintptr) {
+	return MAKEWPARAM(m.Keys, m.XButton), pointLParam(m.X, m.Y)
+}
+
+// MouseWheelMsg is used for WM_MOUSEWHEEL and WM_MOUSEHWHEEL. X and Y are
+// in screen coordinates. Delta is a multiple or fraction of WHEEL_DELTA.
+type MouseWheelMsg struct {
+	X, Y  int32
+	Keys  uint16
+	Delta int16
+}
+
+func CrackMouseWheelMsg(wParam, lParam uintptr) MouseWheelMsg {
+	return MouseWheelMsg{
+		X:     GET_X_LPARAM(lParam),
+		Y:     GET_Y_LPARAM(lParam),
+		Keys:  GET_KEYSTATE_WPARAM(wParam),
+		Delta: GET_WHEEL_DELTA_WPARAM(wParam),
+	}
+}
+
+func (m MouseWheelMsg) Pack() (wParam, lParam uintptr) {
+	return MAKEWPARAM(m.Keys, uint16(m.Delta)), pointLParam(m.X, m.Y)
+}
+
+// NCMouseMsg is used for non-client area mouse messages such as
+// WM_NCMOUSEMOVE and WM_NCLBUTTONDOWN. X and Y are in screen coordinates.
+type NCMouseMsg struct {
+	X, Y    int32
+	HitTest int16 // HT*
+	XButton uint16
+}
+
+func CrackNCMouseMsg(wParam, lParam uintptr) NCMouseMsg {
+	return NCMouseMsg{
+		X:       GET_X_LPARAM(lParam),
+		Y:       GET_Y_LPARAM(lParam),
+		HitTest: GET_NCHITTEST_WPARAM(wParam),
+		XButton: GET_XBUTTON_WPARAM(wParam),
+	}
+}
+
+func (m NCMouseMsg) Pack() (wParam, lParam uintptr) {
+	return MAKEWPARAM(uint16(m.HitTest), m.XButton), pointLParam(m.X, m.Y)
+}
+
+// MouseActivateMsg is used for WM_MOUSEACTIVATE.
+type MouseActivateMsg struct {
+	TopLevel HWND
+	HitTest  int16
+	Message  uint16 // The mouse message that caused the activation
+}
+
+func CrackMouseActivateMsg(wParam, lParam uintptr) MouseActivateMsg {
+	return MouseActivateMsg{
+		TopLevel: HWND(wParam),
+		HitTest:  int16(LOWORD(uint32(lParam))),
+		Message:  HIWORD(uint32(lParam)),
+	}
+}
+
+func (m MouseActivateMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.TopLevel), MAKELPARAM(uint16(m.HitTest), m.Message)
+}
+
+// SetCursorMsg is used for WM_SETCURSOR. Message is 0 when the cursor is
+// updated without mouse input, e.g. while a menu is active.
+type SetCursorMsg struct {
+	Window  HWND
+	HitTest int16
+	Message uint16
+}
+
+func CrackSetCursorMsg(wParam, lParam uintptr) SetCursorMsg {
+	return SetCursorMsg{
+		Window:  HWND(wParam),
+		HitTest: int16(LOWORD(uint32(lParam))),
+		Message: HIWORD(uint32(lParam)),
+	}
+}
+
+func (m SetCursorMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Window), MAKELPARAM(uint16(m.HitTest), m.Message)
+}
+
+// ContextMenuMsg is used for WM_CONTEXTMENU. X and Y are in screen
+// coordinates, or both -1 if the menu was requested with the keyboard.
+type ContextMenuMsg struct {
+	Window HWND
+	X, Y   int32
+}
+
+func CrackContextMenuMsg(wParam, lParam uintptr) ContextMenuMsg {
+	return ContextMenuMsg{HWND(wParam), GET_X_LPARAM(lParam), GET_Y_LPARAM(lParam)}
+}
+
+func (m ContextMenuMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Window), pointLParam(m.X, m.Y)
+}
+
+// CommandMsg is used for WM_COMMAND. Code is 0 for menus and 1 for
+// accelerators, in which case Control is 0.
+type CommandMsg struct {
+	ID      uint16
+	Code    uint16
+	Control HWND
+}
+
+func CrackCommandMsg(wParam, lParam uintptr) CommandMsg {
+	return CommandMsg{
+		ID:      LOWORD(uint32(wParam)),
+		Code:    HIWORD(uint32(wParam)),
+		Control: HWND(lParam),
+	}
+}
+
+func (m CommandMsg) Pack() (wParam, lParam uintptr) {
+	return MAKEWPARAM(m.ID, m.Code), uintptr(m.Control)
+}
+
+// SysCommandMsg is used for WM_SYSCOMMAND. The low four bits of Command are
+// used internally by Windows; mask them with 0xFFF0 before comparing to
+// SC_* values. X and Y are in screen coordinates if the command was chosen
+// with the mouse.
+type SysCommandMsg struct {
+	Command uint32
+	X, Y    int32
+}
+
+func CrackSysCommandMsg(wParam, lParam uintptr) SysCommandMsg {
+	return SysCommandMsg{uint32(wParam), GET_X_LPARAM(lParam), GET_Y_LPARAM(lParam)}
+}
+
+func (m SysCommandMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Command), pointLParam(m.X, m.Y)
+}
+
+// NotifyFormatMsg is used for WM_NOTIFYFORMAT.
+type NotifyFormatMsg struct {
+	From    HWND
+	Command uint32 // NF_QUERY or NF_REQUERY
+}
+
+func CrackNotifyFormatMsg(wParam, lParam uintptr) NotifyFormatMsg {
+	return NotifyFormatMsg{HWND(wParam), uint32(lParam)}
+}
+
+func (m NotifyFormatMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.From), uintptr(m.Command)
+}
+
+// ScrollMsg is used for WM_HSCROLL and WM_VSCROLL. Pos is only valid for
+// SB_THUMBPOSITION and SB_THUMBTRACK and limited to 16 bits; use
+// GetScrollInfo for the full range. ScrollBar is 0 for window scroll bars.
+type ScrollMsg struct {
+	Request   uint16 // SB_*
+	Pos       uint16
+	ScrollBar HWND
+}
+
+func CrackScrollMsg(wParam, lParam uintptr) ScrollMsg {
+	return ScrollMsg{
+		Request:   LOWORD(uint32(wParam)),
+		Pos:       HIWORD(uint32(wParam)),
+		ScrollBar: HWND(lParam),
+	}
+}
+
+func (m ScrollMsg) Pack() (wParam, lParam uintptr) {
+	return MAKEWPARAM(m.Request, m.Pos), uintptr(m.ScrollBar)
+}
+
+// TimerMsg is used for WM_TIMER.
+type TimerMsg struct {
+	ID   uintptr
+	Proc uintptr
+}
+
+func CrackTimerMsg(wParam, lParam uintptr) TimerMsg {
+	return TimerMsg{wParam, lParam}
+}
+
+func (m TimerMsg) Pack() (wParam, lParam uintptr) {
+	return m.ID, m.Proc
+}
+
+// MenuMsg is used for WM_INITMENU.
+type MenuMsg struct {
+	Menu HMENU
+}
+
+func CrackMenuMsg(wParam, lParam uintptr) MenuMsg {
+	return MenuMsg{HMENU(wParam)}
+}
+
+func (m MenuMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Menu), 0
+}
+
+// InitMenuPopupMsg is used for WM_INITMENUPOPUP.
+type InitMenuPopupMsg struct {
+	Menu       HMENU
+	Index      uint16
+	SystemMenu bool
+}
+
+func CrackInitMenuPopupMsg(wParam, lParam uintptr) InitMenuPopupMsg {
+	return InitMenuPopupMsg{
+		Menu:       HMENU(wParam),
+		Index:      LOWORD(uint32(lParam)),
+		SystemMenu: HIWORD(uint32(lParam)) != 0,
+	}
+}
+
+func (m InitMenuPopupMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Menu), MAKELPARAM(m.Index, uint16(boolParam(m.SystemMenu)))
+}
+
+// UninitMenuPopupMsg is used for WM_UNINITMENUPOPUP.
+type UninitMenuPopupMsg struct {
+	Menu  HMENU
+	Flags uint16 // MF_SYSMENU or 0
+}
+
+func CrackUninitMenuPopupMsg(wParam, lParam uintptr) UninitMenuPopupMsg {
+	return UninitMenuPopupMsg{HMENU(wParam), HIWORD(uint32(lParam))}
+}
+
+func (m UninitMenuPopupMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Menu), MAKELPARAM(0, m.Flags)
+}
+
+// MenuSelectMsg is used for WM_MENUSELECT. Item is a command id, or an
+// index if Flags contains MF_POPUP. Flags is 0xFFFF and Menu 0 when the
+// menu was closed.
+type MenuSelectMsg struct {
+	Item  uint16
+	Flags uint16 // MF_*
+	Menu  HMENU
+}
+
+func CrackMenuSelectMsg(wParam, lParam uintptr) MenuSelectMsg {
+	return MenuSelectMsg{
+		Item:  LOWORD(uint32(wParam)),
+		Flags: HIWORD(uint32(wParam)),
+		Menu:  HMENU(lParam),
+	}
+}
+
+func (m MenuSelectMsg) Pack() (wParam, lParam uintptr) {
+	return MAKEWPARAM(m.Item, m.Flags), uintptr(m.Menu)
+}
+
+// MenuItemMsg is used for WM_MENUCOMMAND, WM_MENURBUTTONUP and WM_MENUDRAG.
+type MenuItemMsg struct {
+	Index uint32
+	Menu  HMENU
+}
+
+func CrackMenuItemMsg(wParam, lParam uintptr) MenuItemMsg {
+	return MenuItemMsg{uint32(wParam), HMENU(lParam)}
+}
+
+func (m MenuItemMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Index), uintptr(m.Menu)
+}
+
+// MenuCharMsg is used for WM_MENUCHAR.
+type MenuCharMsg struct {
+	Char uint16
+	Type uint16 // MF_POPUP or MF_SYSMENU
+	Menu HMENU
+}
+
+func CrackMenuCharMsg(wParam, lParam uintptr) MenuCharMsg {
+	return MenuCharMsg{
+		Char: LOWORD(uint32(wParam)),
+		Type: HIWORD(uint32(wParam)),
+		Menu: HMENU(lParam),
+	}
+}
+
+func (m MenuCharMsg) Pack() (wParam, lParam uintptr) {
+	return MAKEWPARAM(m.Char, m.Type), uintptr(m.Menu)
+}
+
+// EnterIdleMsg is used for WM_ENTERIDLE.
+type EnterIdleMsg struct {
+	Reason uint32 // MSGF_DIALOGBOX or MSGF_MENU
+	Owner  HWND
+}
+
+func CrackEnterIdleMsg(wParam, lParam uintptr) EnterIdleMsg {
+	return EnterIdleMsg{uint32(wParam), HWND(lParam)}
+}
+
+func (m EnterIdleMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Reason), uintptr(m.Owner)
+}
+
+// UIStateMsg is used for WM_CHANGEUISTATE and WM_UPDATEUISTATE.
+type UIStateMsg struct {
+	Action uint16 // UIS_*
+	Flags  uint16 // UISF_*
+}
+
+func CrackUIStateMsg(wParam, lParam uintptr) UIStateMsg {
+	return UIStateMsg{LOWORD(uint32(wParam)), HIWORD(uint32(wParam))}
+}
+
+func (m UIStateMsg) Pack() (wParam, lParam uintptr) {
+	return MAKEWPARAM(m.Action, m.Flags), 0
+}
+
+// AppCommandMsg is used for WM_APPCOMMAND.
+type AppCommandMsg struct {
+	Window  HWND
+	Command uint16 // APPCOMMAND_*
+	Device  uint16 // FAPPCOMMAND_*
+	Keys    uint16 // MK_*
+}
+
+func CrackAppCommandMsg(wParam, lParam uintptr) AppCommandMsg {
+	return AppCommandMsg{
+		Window:  HWND(wParam),
+		Command: GET_APPCOMMAND_LPARAM(lParam),
+		Device:  GET_DEVICE_LPARAM(lParam),
+		Keys:    GET_KEYSTATE_LPARAM(lParam),
+	}
+}
+
+func (m AppCommandMsg) Pack() (wParam, lParam uintptr) {
+	cmd := m.Command&^FAPPCOMMAND_MASK | m.Device&FAPPCOMMAND_MASK
+
+	return uintptr(m.Window), MAKELPARAM(m.Keys, cmd)
+}
+
+// GetObjectMsg is used for WM_GETOBJECT.
+type GetObjectMsg struct {
+	Flags    uint32
+	ObjectID int32 // OBJID_*
+}
+
+func CrackGetObjectMsg(wParam, lParam uintptr) GetObjectMsg {
+	return GetObjectMsg{uint32(wParam), int32(lParam)}
+}
+
+func (m GetObjectMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Flags), uintptr(m.ObjectID)
+}
+
+// TCardMsg is used for WM_TCARD.
+type TCardMsg struct {
+	Action uint32
+	Data   uintptr
+}
+
+func CrackTCardMsg(wParam, lParam uintptr) TCardMsg {
+	return TCardMsg{uint32(wParam), lParam}
+}
+
+func (m TCardMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Action), m.Data
+}
+
+// SpoolerStatusMsg is used for WM_SPOOLERSTATUS.
+type SpoolerStatusMsg struct {
+	Status uint32
+	Jobs   uint16
+}
+
+func CrackSpoolerStatusMsg(wParam, lParam uintptr) SpoolerStatusMsg {
+	return SpoolerStatusMsg{uint32(wParam), LOWORD(uint32(lParam))}
+}
+
+func (m SpoolerStatusMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Status), uintptr(m.Jobs)
+}
+
+// DropFilesMsg is used for WM_DROPFILES.
+type DropFilesMsg struct {
+	Drop HDROP
+}
+
+func CrackDropFilesMsg(wParam, lParam uintptr) DropFilesMsg {
+	return DropFilesMsg{HDROP(wParam)}
+}
+
+func (m DropFilesMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Drop), 0
+}
+
+// InputMsg is used for WM_INPUT.
+type InputMsg struct {
+	Code  uint32 // RIM_INPUT or RIM_INPUTSINK
+	Input HRAWINPUT
+}
+
+func CrackInputMsg(wParam, lParam uintptr) InputMsg {
+	return InputMsg{GET_RAWINPUT_CODE_WPARAM(wParam), HRAWINPUT(lParam)}
+}
+
+func (m InputMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Code), uintptr(m.Input)
+}
+
+// ClipboardViewerMsg is used for WM_PAINTCLIPBOARD and WM_SIZECLIPBOARD.
+// Data is a global memory handle to a PAINTSTRUCT or RECT, respectively.
+type ClipboardViewerMsg struct {
+	Viewer HWND
+	Data   HGLOBAL
+}
+
+func CrackClipboardViewerMsg(wParam, lParam uintptr) ClipboardViewerMsg {
+	return ClipboardViewerMsg{HWND(wParam), HGLOBAL(lParam)}
+}
+
+func (m ClipboardViewerMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Viewer), uintptr(m.Data)
+}
+
+// ClipboardScrollMsg is used for WM_HSCROLLCLIPBOARD and
+// WM_VSCROLLCLIPBOARD.
+type ClipboardScrollMsg struct {
+	Viewer  HWND
+	Request uint16 // SB_*
+	Pos     uint16
+}
+
+func CrackClipboardScrollMsg(wParam, lParam uintptr) ClipboardScrollMsg {
+	return ClipboardScrollMsg{
+		Viewer:  HWND(wParam),
+		Request: LOWORD(uint32(lParam)),
+		Pos:     HIWORD(uint32(lParam)),
+	}
+}
+
+func (m ClipboardScrollMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Viewer), MAKELPARAM(m.Request, m.Pos)
+}
+
+// ChangeCBChainMsg is used for WM_CHANGECBCHAIN.
+type ChangeCBChainMsg struct {
+	Removed HWND
+	Next    HWND
+}
+
+func CrackChangeCBChainMsg(wParam, lParam uintptr) ChangeCBChainMsg {
+	return ChangeCBChainMsg{HWND(wParam), HWND(lParam)}
+}
+
+func (m ChangeCBChainMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Removed), uintptr(m.Next)
+}
+
+// MDIActivateMsg is used for WM_MDIACTIVATE. When sent to the MDI client,
+// Deactivated holds the child to activate. When received by a child,
+// Deactivated and Activated hold the children losing and gaining
+// activation.
+type MDIActivateMsg struct {
+	Deactivated HWND
+	Activated   HWND
+}
+
+func CrackMDIActivateMsg(wParam, lParam uintptr) MDIActivateMsg {
+	return MDIActivateMsg{HWND(wParam), HWND(lParam)}
+}
+
+func (m MDIActivateMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Deactivated), uintptr(m.Activated)
+}
+
+// MDINextMsg is used for WM_MDINEXT.
+type MDINextMsg struct {
+	Child    HWND
+	Previous bool
+}
+
+func CrackMDINextMsg(wParam, lParam uintptr) MDINextMsg {
+	return MDINextMsg{HWND(wParam), lParam != 0}
+}
+
+func (m MDINextMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Child), boolParam(m.Previous)
+}
+
+// MDISetMenuMsg is used for WM_MDISETMENU.
+type MDISetMenuMsg struct {
+	Frame  HMENU
+	Window HMENU
+}
+
+func CrackMDISetMenuMsg(wParam, lParam uintptr) MDISetMenuMsg {
+	return MDISetMenuMsg{HMENU(wParam), HMENU(lParam)}
+}
+
+func (m MDISetMenuMsg) Pack() (wParam, lParam uintptr) {
+	return uintptr(m.Frame), uintptr(m.Window)
+}
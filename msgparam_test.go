@@ -0,0 +1,126 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"testing"
+)
+
+func TestCrackPackRoundTrip(t *testing.T) {
+	tests := []struct {
+		msg   MessagePacker
+		crack func(wParam, lParam uintptr) MessagePacker
+	}{
+		{EmptyMsg{}, func(w, l uintptr) MessagePacker { return CrackEmptyMsg(w, l) }},
+		{RawMsg{^uintptr(0), 1}, func(w, l uintptr) MessagePacker { return CrackRawMsg(w, l) }},
+		{ValueMsg{0xFFFFFFFF}, func(w, l uintptr) MessagePacker { return CrackValueMsg(w, l) }},
+		{BoolMsg{true}, func(w, l uintptr) MessagePacker { return CrackBoolMsg(w, l) }},
+		{WindowMsg{0x1234}, func(w, l uintptr) MessagePacker { return CrackWindowMsg(w, l) }},
+		{PointMsg{-1, -32768}, func(w, l uintptr) MessagePacker { return CrackPointMsg(w, l) }},
+		{PointMsg{32767, 0}, func(w, l uintptr) MessagePacker { return CrackPointMsg(w, l) }},
+		{EventMsg{0x8000, 0xABCD}, func(w, l uintptr) MessagePacker { return CrackEventMsg(w, l) }},
+		{ActivateMsg{2, true, 0x10}, func(w, l uintptr) MessagePacker { return CrackActivateMsg(w, l) }},
+		{ActivateAppMsg{true, 42}, func(w, l uintptr) MessagePacker { return CrackActivateAppMsg(w, l) }},
+		{NCActivateMsg{false, HRGN(^uintptr(0))}, func(w, l uintptr) MessagePacker { return CrackNCActivateMsg(w, l) }},
+		{ShowWindowMsg{true, 3}, func(w, l uintptr) MessagePacker { return CrackShowWindowMsg(w, l) }},
+		{EndSessionMsg{true, 0x80000000}, func(w, l uintptr) MessagePacker { return CrackEndSessionMsg(w, l) }},
+		{QuitMsg{-1}, func(w, l uintptr) MessagePacker { return CrackQuitMsg(w, l) }},
+		{NextDlgCtlMsg{Handle: true, Control: 0x20}, func(w, l uintptr) MessagePacker { return CrackNextDlgCtlMsg(w, l) }},
+		{NextDlgCtlMsg{Previous: true}, func(w, l uintptr) MessagePacker { return CrackNextDlgCtlMsg(w, l) }},
+		{SizeMsg{2, 65535, 1}, func(w, l uintptr) MessagePacker { return CrackSizeMsg(w, l) }},
+		{DisplayChangeMsg{32, 3840, 2160}, func(w, l uintptr) MessagePacker { return CrackDisplayChangeMsg(w, l) }},
+		{SetFontMsg{0x30, true}, func(w, l uintptr) MessagePacker { return CrackSetFontMsg(w, l) }},
+		{KeyMsg{'A', KeyFlags{RepeatCount: 1, ScanCode: 0x1E}}, func(w, l uintptr) MessagePacker { return CrackKeyMsg(w, l) }},
+		{KeyMsg{0x2E, KeyFlags{65535, 0x53, true, true, true, true}}, func(w, l uintptr) MessagePacker { return CrackKeyMsg(w, l) }},
+		{CharMsg{0x10FFFF, KeyFlags{RepeatCount: 3, AltDown: true}}, func(w, l uintptr) MessagePacker { return CrackCharMsg(w, l) }},
+		{HotKeyMsg{-2, 0x4003, 0x70}, func(w, l uintptr) MessagePacker { return CrackHotKeyMsg(w, l) }},
+		{SetHotKeyMsg{0x41, 0x06}, func(w, l uintptr) MessagePacker { return CrackSetHotKeyMsg(w, l) }},
+		{ListBoxKeyMsg{0x28, 7, 0x40}, func(w, l uintptr) MessagePacker { return CrackListBoxKeyMsg(w, l) }},
+		{MouseMsg{-10, -20, 0x0009, 0}, func(w, l uintptr) MessagePacker { return CrackMouseMsg(w, l) }},
+		{MouseMsg{5, 6, 0x0020, 2}, func(w, l uintptr) MessagePacker { return CrackMouseMsg(w, l) }},
+		{MouseWheelMsg{-1920, 1080, 0x0004, -120}, func(w, l uintptr) MessagePacker { return CrackMouseWheelMsg(w, l) }},
+		{MouseWheelMsg{0, 0, 0, 7}, func(w, l uintptr) MessagePacker { return CrackMouseWheelMsg(w, l) }},
+		{NCMouseMsg{-5, 100, -2, 1}, func(w, l uintptr) MessagePacker { return CrackNCMouseMsg(w, l) }},
+		{MouseActivateMsg{0x50, -2, 0x0201}, func(w, l uintptr) MessagePacker { return CrackMouseActivateMsg(w, l) }},
+		{SetCursorMsg{0x60, 1, 0}, func(w, l uintptr) MessagePacker { return CrackSetCursorMsg(w, l) }},
+		{ContextMenuMsg{0x70, -1, -1}, func(w, l uintptr) MessagePacker { return CrackContextMenuMsg(w, l) }},
+		{CommandMsg{0x8001, 1, 0}, func(w, l uintptr) MessagePacker { return CrackCommandMsg(w, l) }},
+		{SysCommandMsg{0xF060, -3, 4}, func(w, l uintptr) MessagePacker { return CrackSysCommandMsg(w, l) }},
+		{ScrollMsg{5, 0xFFFF, 0x80}, func(w, l uintptr) MessagePacker { return CrackScrollMsg(w, l) }},
+		{InitMenuPopupMsg{0x90, 3, true}, func(w, l uintptr) MessagePacker { return CrackInitMenuPopupMsg(w, l) }},
+		{MenuSelectMsg{0xFFFF, 0xFFFF, 0}, func(w, l uintptr) MessagePacker { return CrackMenuSelectMsg(w, l) }},
+		{MenuCharMsg{'x', 0x0010, 0xA0}, func(w, l uintptr) MessagePacker { return CrackMenuCharMsg(w, l) }},
+		{UIStateMsg{1, 3}, func(w, l uintptr) MessagePacker { return CrackUIStateMsg(w, l) }},
+		{AppCommandMsg{0xB0, 46, FAPPCOMMAND_MOUSE, 0x0001}, func(w, l uintptr) MessagePacker { return CrackAppCommandMsg(w, l) }},
+		{GetObjectMsg{0, -4}, func(w, l uintptr) MessagePacker { return CrackGetObjectMsg(w, l) }},
+		{ClipboardScrollMsg{0xC0, 4, 300}, func(w, l uintptr) MessagePacker { return CrackClipboardScrollMsg(w, l) }},
+		{MDINextMsg{0xD0, true}, func(w, l uintptr) MessagePacker { return CrackMDINextMsg(w, l) }},
+	}
+
+	for _, tt := range tests {
+		wParam, lParam := tt.msg.Pack()
+
+		if got := tt.crack(wParam, lParam); got != tt.msg {
+			t.Errorf("Crack(%#v.Pack()) = %#v", tt.msg, got)
+		}
+	}
+}
+
+func TestCrackSignExtension(t *testing.T) {
+	// Coordinates on monitors left of or above the primary one are
+	// negative 16-bit values, which must not be read as large positive
+	// ones.
+	if x, y := GET_X_LPARAM(MAKELPARAM(0xFFFF, 0x8000)), GET_Y_LPARAM(MAKELPARAM(0xFFFF, 0x8000)); x != -1 || y != -32768 {
+		t.Errorf("GET_X_LPARAM, GET_Y_LPARAM = %d, %d, want -1, -32768", x, y)
+	}
+
+	// Bits above the low 32 are ignored, as for an lParam sign extended
+	// by the system on 64-bit Windows.
+	if m := CrackPointMsg(0, ^uintptr(0)); m.X != -1 || m.Y != -1 {
+		t.Errorf("CrackPointMsg(0, ^0) = %+v, want -1, -1", m)
+	}
+
+	if wParam, lParam := (PointMsg{-2, 3}).Pack(); wParam != 0 || lParam != 0x0003FFFE {
+		t.Errorf("PointMsg.Pack = %#x, %#x, want 0, 0x3fffe", wParam, lParam)
+	}
+
+	m := CrackMouseWheelMsg(MAKEWPARAM(0x0008, 0xFF88), 0)
+	if m.Delta != -120 || m.Keys != 0x0008 {
+		t.Errorf("CrackMouseWheelMsg: Delta, Keys = %d, %#x, want -120, 0x8", m.Delta, m.Keys)
+	}
+
+	if wParam, _ := (MouseWheelMsg{Delta: -240}).Pack(); GET_WHEEL_DELTA_WPARAM(wParam) != -240 || wParam != 0xFF100000 {
+		t.Errorf("MouseWheelMsg.Pack wParam = %#x, want 0xff100000", wParam)
+	}
+}
+
+func TestKeyFlags(t *testing.T) {
+	tests := []struct {
+		lParam uintptr
+		flags  KeyFlags
+	}{
+		{0x001E0001, KeyFlags{RepeatCount: 1, ScanCode: 0x1E}},
+		{0x01480001, KeyFlags{RepeatCount: 1, ScanCode: 0x48, Extended: true}},
+		{0x20380001, KeyFlags{RepeatCount: 1, ScanCode: 0x38, AltDown: true}},
+		{0x401E0005, KeyFlags{RepeatCount: 5, ScanCode: 0x1E, PreviousDown: true}},
+		{0xC11D0001, KeyFlags{RepeatCount: 1, ScanCode: 0x1D, Extended: true, PreviousDown: true, Released: true}},
+		{0xE1FFFFFF, KeyFlags{0xFFFF, 0xFF, true, true, true, true}},
+	}
+
+	for _, tt := range tests {
+		if got := CrackKeyFlags(tt.lParam); got != tt.flags {
+			t.Errorf("CrackKeyFlags(%#x) = %+v, want %+v", tt.lParam, got, tt.flags)
+		}
+
+		if got := tt.flags.Pack(); got != tt.lParam {
+			t.Errorf("%+v.Pack() = %#x, want %#x", tt.flags, got, tt.lParam)
+		}
+	}
+
+	// KF_DLGMODE and KF_MENUMODE are not represented and dropped.
+	if got := CrackKeyFlags(MAKELPARAM(1, KF_DLGMODE|KF_MENUMODE|KF_UP)); got != (KeyFlags{RepeatCount: 1, Released: true}) {
+		t.Errorf("CrackKeyFlags with KF_DLGMODE|KF_MENUMODE = %+v", got)
+	}
+}
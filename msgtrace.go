@@ -0,0 +1,172 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// TraceEvent describes a message seen by a MessageTracer.
+type TraceEvent struct {
+	Time   time.Time
+	HWND   HWND
+	Class  string
+	Msg    uint32
+	Name   string
+	WParam uintptr
+	LParam uintptr
+
+	// Posted is true for messages seen in the message loop and false for
+	// messages seen by a window procedure.
+	Posted bool
+
+	// Params holds the cracked parameters of WM_* messages and is nil for
+	// control messages.
+	Params MessagePacker
+
+	// Notification is the name of the notification code for WM_NOTIFY.
+	Notification string
+}
+
+// String formats e as a single line, similar to Spy++.
+func (e *TraceEvent) String() string {
+	kind := "S"
+	if e.Posted {
+		kind = "P"
+	}
+
+	s := fmt.Sprintf("%s %s <%08X> %s %s",
+		e.Time.Format("15:04:05.000"), kind, e.HWND, e.Class, e.Name)
+
+	switch {
+	case e.Notification != "":
+		s += " " + e.Notification
+
+	case e.Params != nil:
+		if _, raw := e.Params.(RawMsg); !raw {
+			return s + fmt.Sprintf(" %+v", e.Params)
+		}
+	}
+
+	return s + fmt.Sprintf(" wParam:%08X lParam:%08X", e.WParam, e.LParam)
+}
+
+// MessageTracer logs the messages of windows and message loops it is
+// attached to. It is opt-in and meant for debugging; decoding every message
+// is not free.
+//
+// The package targets Go versions without log/slog. To log through slog,
+// use NewMessageTracerFunc with a function that calls Logger.LogAttrs.
+type MessageTracer struct {
+	mu      sync.Mutex
+	w       io.Writer
+	f       func(e *TraceEvent)
+	filter  func(hwnd HWND, msg uint32) bool
+	classes map[HWND]string
+}
+
+// NewMessageTracer returns a MessageTracer writing one line per message
+// to w.
+func NewMessageTracer(w io.Writer) *MessageTracer {
+	return &MessageTracer{w: w, classes: make(map[HWND]string)}
+}
+
+// NewMessageTracerFunc returns a MessageTracer calling f for each message.
+// The event must not be retained after f returns.
+func NewMessageTracerFunc(f func(e *TraceEvent)) *MessageTracer {
+	return &MessageTracer{f: f, classes: make(map[HWND]string)}
+}
+
+// SetFilter makes the tracer skip messages for which f returns false.
+func (t *MessageTracer) SetFilter(f func(hwnd HWND, msg uint32) bool) {
+	t.mu.Lock()
+	t.filter = f
+	t.mu.Unlock()
+}
+
+// TraceWindow logs all messages reaching the window procedure of w. It does
+// not change how messages are handled.
+func (t *MessageTracer) TraceWindow(w *Window) {
+	w.HandleAll(func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		t.Trace(w.HWND(), msg, wParam, lParam, false)
+		return 0, false
+	})
+}
+
+// TraceLoop logs all messages retrieved by l, before they are translated
+// and dispatched.
+func (t *MessageTracer) TraceLoop(l *MessageLoop) {
+	l.AddFilter(func(msg *MSG) bool {
+		t.Trace(msg.HWnd, msg.Message, msg.WParam, msg.LParam, true)
+		return false
+	})
+}
+
+// Trace logs a single message. It can be called from a custom window
+// procedure or message pump.
+func (t *MessageTracer) Trace(hwnd HWND, msg uint32, wParam, lParam uintptr, posted bool) {
+	t.mu.Lock()
+
+	if t.filter != nil && !t.filter(hwnd, msg) {
+		t.mu.Unlock()
+		return
+	}
+
+	e := TraceEvent{
+		Time:   time.Now(),
+		HWND:   hwnd,
+		Class:  t.className(hwnd),
+		Msg:    msg,
+		WParam: wParam,
+		LParam: lParam,
+		Posted: posted,
+	}
+
+	e.Name = ClassMessageName(e.Class, msg)
+
+	if name, ok := messageNames[msg]; ok && e.Name == name {
+		e.Params = CrackMessage(msg, wParam, lParam)
+
+		if msg == WM_NOTIFY && lParam != 0 {
+			e.Notification = NotificationName(CrackNotifyMsg(wParam, lParam).Hdr.Code)
+		}
+	}
+
+	if msg == WM_NCDESTROY {
+		// The handle may be reused for a window of another class.
+		delete(t.classes, hwnd)
+	}
+
+	// The lock is not held while logging, so that f may send messages to
+	// traced windows.
+	t.mu.Unlock()
+
+	if t.f != nil {
+		t.f(&e)
+	}
+	if t.w != nil {
+		fmt.Fprintln(t.w, e.String())
+	}
+}
+
+func (t *MessageTracer) className(hwnd HWND) string {
+	if hwnd == 0 {
+		return "(thread)"
+	}
+
+	if class, ok := t.classes[hwnd]; ok {
+		return class
+	}
+
+	class := WindowClassName(hwnd)
+	t.classes[hwnd] = class
+
+	return class
+}
@@ -6,6 +6,97 @@
 
 package win
 
+import (
+	"syscall"
+	"unsafe"
+)
+
+// DVASPECT constants
+const (
+	DVASPECT_CONTENT   = 1
+	DVASPECT_THUMBNAIL = 2
+	DVASPECT_ICON      = 4
+	DVASPECT_DOCPRINT  = 8
+)
+
+// TYMED constants
+const (
+	TYMED_NULL     = 0
+	TYMED_HGLOBAL  = 1
+	TYMED_FILE     = 2
+	TYMED_ISTREAM  = 4
+	TYMED_ISTORAGE = 8
+	TYMED_GDI      = 16
+	TYMED_MFPICT   = 32
+	TYMED_ENHMF    = 64
+)
+
+// DATADIR constants
+const (
+	DATADIR_GET = 1
+	DATADIR_SET = 2
+)
+
+// IDataObject result codes
+const (
+	DV_E_FORMATETC           = 0x80040064
+	DV_E_TYMED               = 0x80040069
+	DV_E_DVASPECT            = 0x8004006B
+	DV_E_LINDEX              = 0x80040068
+	DATA_S_SAMEFORMATETC     = 0x00040130
+	OLE_E_ADVISENOTSUPPORTED = 0x80040003
+)
+
+var (
+	IID_IDataObject    = IID{0x0000010E, 0x0000, 0x0000, [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+	IID_IEnumFORMATETC = IID{0x00000103, 0x0000, 0x0000, [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+)
+
+type FORMATETC struct {
+	CfFormat uint16
+	Ptd      uintptr
+	DwAspect uint32
+	Lindex   int32
+	Tymed    uint32
+}
+
+type STGMEDIUM struct {
+	Tymed          uint32
+	Data           uintptr // HGLOBAL, file name, IStream, ... depending on Tymed
+	PUnkForRelease *IUnknown
+}
+
+type IEnumFORMATETCVtbl struct {
+	IUnknownVtbl
+	Next  uintptr
+	Skip  uintptr
+	Reset uintptr
+	Clone uintptr
+}
+
+type IEnumFORMATETC struct {
+	LpVtbl *IEnumFORMATETCVtbl
+}
+
+func (obj *IEnumFORMATETC) Release() uint32 {
+	ret, _, _ := syscall.Syscall(obj.LpVtbl.Release, 1,
+		uintptr(unsafe.Pointer(obj)),
+		0,
+		0)
+	return uint32(ret)
+}
+
+func (obj *IEnumFORMATETC) Next(celt uint32, rgelt *FORMATETC, pceltFetched *uint32) HRESULT {
+	ret, _, _ := syscall.Syscall6(obj.LpVtbl.Next, 4,
+		uintptr(unsafe.Pointer(obj)),
+		uintptr(celt),
+		uintptr(unsafe.Pointer(rgelt)),
+		uintptr(unsafe.Pointer(pceltFetched)),
+		0,
+		0)
+	return HRESULT(ret)
+}
+
 type IDataObjectVtbl struct {
 	IUnknownVtbl
 	GetData               uintptr
@@ -23,6 +114,46 @@ type IDataObject struct {
 	LpVtbl *IDataObjectVtbl
 }
 
+func (obj *IDataObject) AddRef() uint32 {
+	ret, _, _ := syscall.Syscall(obj.LpVtbl.AddRef, 1,
+		uintptr(unsafe.Pointer(obj)),
+		0,
+		0)
+	return uint32(ret)
+}
+
+func (obj *IDataObject) Release() uint32 {
+	ret, _, _ := syscall.Syscall(obj.LpVtbl.Release, 1,
+		uintptr(unsafe.Pointer(obj)),
+		0,
+		0)
+	return uint32(ret)
+}
+
+func (obj *IDataObject) GetData(pformatetcIn *FORMATETC, pmedium *STGMEDIUM) HRESULT {
+	ret, _, _ := syscall.Syscall(obj.LpVtbl.GetData, 3,
+		uintptr(unsafe.Pointer(obj)),
+		uintptr(unsafe.Pointer(pformatetcIn)),
+		uintptr(unsafe.Pointer(pmedium)))
+	return HRESULT(ret)
+}
+
+func (obj *IDataObject) QueryGetData(pformatetc *FORMATETC) HRESULT {
+	ret, _, _ := syscall.Syscall(obj.LpVtbl.QueryGetData, 2,
+		uintptr(unsafe.Pointer(obj)),
+		uintptr(unsafe.Pointer(pformatetc)),
+		0)
+	return HRESULT(ret)
+}
+
+func (obj *IDataObject) EnumFormatEtc(dwDirection uint32, ppenumFormatEtc **IEnumFORMATETC) HRESULT {
+	ret, _, _ := syscall.Syscall(obj.LpVtbl.EnumFormatEtc, 3,
+		uintptr(unsafe.Pointer(obj)),
+		uintptr(dwDirection),
+		uintptr(unsafe.Pointer(ppenumFormatEtc)))
+	return HRESULT(ret)
+}
+
 type IStorageVtbl struct {
 	IUnknownVtbl
 	CreateStream    uintptr
@@ -66,6 +66,25 @@ const (
 	OLECLOSE_PROMPTSAVE  = 2
 )
 
+// DROPEFFECT constants
+const (
+	DROPEFFECT_NONE   = 0
+	DROPEFFECT_COPY   = 1
+	DROPEFFECT_MOVE   = 2
+	DROPEFFECT_LINK   = 4
+	DROPEFFECT_SCROLL = 0x80000000
+)
+
+// Drag and drop result codes
+const (
+	DRAGDROP_S_DROP              = 0x00040100
+	DRAGDROP_S_CANCEL            = 0x00040101
+	DRAGDROP_S_USEDEFAULTCURSORS = 0x00040102
+	DRAGDROP_E_NOTREGISTERED     = 0x80040100
+	DRAGDROP_E_ALREADYREGISTERED = 0x80040101
+	DRAGDROP_E_INVALIDHWND       = 0x80040102
+)
+
 type IID syscall.GUID
 type CLSID syscall.GUID
 type REFIID *IID
@@ -74,6 +93,8 @@ type REFCLSID *CLSID
 var (
 	IID_IClassFactory             = IID{0x00000001, 0x0000, 0x0000, [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
 	IID_IConnectionPointContainer = IID{0xB196B284, 0xBAB4, 0x101A, [8]byte{0xB6, 0x9C, 0x00, 0xAA, 0x00, 0x34, 0x1D, 0x07}}
+	IID_IDropSource               = IID{0x00000121, 0x0000, 0x0000, [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+	IID_IDropTarget               = IID{0x00000122, 0x0000, 0x0000, [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
 	IID_IOleClientSite            = IID{0x00000118, 0x0000, 0x0000, [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
 	IID_IOleInPlaceObject         = IID{0x00000113, 0x0000, 0x0000, [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
 	IID_IOleInPlaceSite           = IID{0x00000119, 0x0000, 0x0000, [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
@@ -198,6 +219,28 @@ func (cpc *IConnectionPointContainer) FindConnectionPoint(riid REFIID, ppCP **IC
 	return HRESULT(ret)
 }
 
+type IDropSourceVtbl struct {
+	IUnknownVtbl
+	QueryContinueDrag uintptr
+	GiveFeedback      uintptr
+}
+
+type IDropSource struct {
+	LpVtbl *IDropSourceVtbl
+}
+
+type IDropTargetVtbl struct {
+	IUnknownVtbl
+	DragEnter uintptr
+	DragOver  uintptr
+	DragLeave uintptr
+	Drop      uintptr
+}
+
+type IDropTarget struct {
+	LpVtbl *IDropTargetVtbl
+}
+
 type IOleClientSiteVtbl struct {
 	QueryInterface         uintptr
 	AddRef                 uintptr
@@ -438,9 +481,13 @@ var (
 	coInitializeEx        *windows.LazyProc
 	coTaskMemFree         *windows.LazyProc
 	coUninitialize        *windows.LazyProc
+	doDragDrop            *windows.LazyProc
 	oleInitialize         *windows.LazyProc
 	oleSetContainedObject *windows.LazyProc
 	oleUninitialize       *windows.LazyProc
+	registerDragDrop      *windows.LazyProc
+	releaseStgMedium      *windows.LazyProc
+	revokeDragDrop        *windows.LazyProc
 )
 
 func init() {
@@ -453,9 +500,13 @@ func init() {
 	coInitializeEx = libole32.NewProc("CoInitializeEx")
 	coTaskMemFree = libole32.NewProc("CoTaskMemFree")
 	coUninitialize = libole32.NewProc("CoUninitialize")
+	doDragDrop = libole32.NewProc("DoDragDrop")
 	oleInitialize = libole32.NewProc("OleInitialize")
 	oleSetContainedObject = libole32.NewProc("OleSetContainedObject")
 	oleUninitialize = libole32.NewProc("OleUninitialize")
+	registerDragDrop = libole32.NewProc("RegisterDragDrop")
+	releaseStgMedium = libole32.NewProc("ReleaseStgMedium")
+	revokeDragDrop = libole32.NewProc("RevokeDragDrop")
 }
 
 func CoCreateInstance(rclsid REFCLSID, pUnkOuter *IUnknown, dwClsContext uint32, riid REFIID, ppv *unsafe.Pointer) HRESULT {
@@ -505,6 +556,18 @@ func CoTaskMemFree(pv uintptr) {
 		0)
 }
 
+func DoDragDrop(pDataObj *IDataObject, pDropSource *IDropSource, dwOKEffects uint32, pdwEffect *uint32) HRESULT {
+	ret, _, _ := syscall.Syscall6(doDragDrop.Addr(), 4,
+		uintptr(unsafe.Pointer(pDataObj)),
+		uintptr(unsafe.Pointer(pDropSource)),
+		uintptr(dwOKEffects),
+		uintptr(unsafe.Pointer(pdwEffect)),
+		0,
+		0)
+
+	return HRESULT(ret)
+}
+
 func OleInitialize() HRESULT {
 	ret, _, _ := syscall.Syscall(oleInitialize.Addr(), 1, // WTF, why does 0 not work here?
 		0,
@@ -529,3 +592,28 @@ func OleUninitialize() {
 		0,
 		0)
 }
+
+func RegisterDragDrop(hwnd HWND, pDropTarget *IDropTarget) HRESULT {
+	ret, _, _ := syscall.Syscall(registerDragDrop.Addr(), 2,
+		uintptr(hwnd),
+		uintptr(unsafe.Pointer(pDropTarget)),
+		0)
+
+	return HRESULT(ret)
+}
+
+func ReleaseStgMedium(pmedium *STGMEDIUM) {
+	syscall.Syscall(releaseStgMedium.Addr(), 1,
+		uintptr(unsafe.Pointer(pmedium)),
+		0,
+		0)
+}
+
+func RevokeDragDrop(hwnd HWND) HRESULT {
+	ret, _, _ := syscall.Syscall(revokeDragDrop.Addr(), 1,
+		uintptr(hwnd),
+		0,
+		0)
+
+	return HRESULT(ret)
+}
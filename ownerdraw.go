@@ -0,0 +1,199 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"fmt"
+)
+
+// MeasureItem is a decoded WM_MEASUREITEM request. The handler sets Width
+// and Height, which start out with the values proposed by the system.
+type MeasureItem struct {
+	CtlType uint32 // ODT_*
+	CtlID   uint32
+	ItemID  int32
+	Data    uintptr
+
+	Width  int32
+	Height int32
+
+	// DPI is the DPI of the owner window, for scaling the size.
+	DPI DPI
+}
+
+// DrawItem is a decoded WM_DRAWITEM request.
+type DrawItem struct {
+	CtlType uint32 // ODT_*
+	CtlID   uint32
+	ItemID  int32  // -1 for the focus of empty list and combo boxes
+	Action  uint32 // ODA_*
+	State   uint32 // ODS_*
+	Control HWND   // the HMENU for menus
+	HDC     HDC
+	Rect    RECT
+	Data    uintptr
+
+	// NoFocusRect, set by the handler, suppresses the focus rectangle,
+	// e.g. because the handler shows the focus itself.
+	NoFocusRect bool
+
+	graphics *GpGraphics
+}
+
+// Selected reports whether the item is selected, or highlighted in menus.
+func (d *DrawItem) Selected() bool {
+	return d.State&ODS_SELECTED != 0
+}
+
+// Focused reports whether the item has the keyboard focus.
+func (d *DrawItem) Focused() bool {
+	return d.State&ODS_FOCUS != 0
+}
+
+// Disabled reports whether the item is disabled or, for menus, grayed.
+func (d *DrawItem) Disabled() bool {
+	return d.State&(ODS_DISABLED|ODS_GRAYED) != 0
+}
+
+// Checked reports whether the menu item is checked.
+func (d *DrawItem) Checked() bool {
+	return d.State&ODS_CHECKED != 0
+}
+
+// HotLight reports whether the item is under the mouse.
+func (d *DrawItem) HotLight() bool {
+	return d.State&ODS_HOTLIGHT != 0
+}
+
+// Default reports whether the item is the default one.
+func (d *DrawItem) Default() bool {
+	return d.State&ODS_DEFAULT != 0
+}
+
+// ComboBoxEdit reports whether the item is drawn in the selection field of
+// a combo box rather than in its list.
+func (d *DrawItem) ComboBoxEdit() bool {
+	return d.State&ODS_COMBOBOXEDIT != 0
+}
+
+// NoAccel reports whether keyboard accelerator cues are hidden.
+func (d *DrawItem) NoAccel() bool {
+	return d.State&ODS_NOACCEL != 0
+}
+
+// Menu returns the menu containing the item of a menu.
+func (d *DrawItem) Menu() HMENU {
+	return HMENU(d.Control)
+}
+
+// Graphics returns GDI+ graphics for HDC with anti-aliasing enabled. It is
+// created on first use and deleted when the handler returns. GdiplusStartup
+// must have been called.
+func (d *DrawItem) Graphics() (*GpGraphics, error) {
+	if d.graphics != nil {
+		return d.graphics, nil
+	}
+
+	var g *GpGraphics
+	if status := GdipCreateFromHDC(d.HDC, &g); status != Ok {
+		return nil, fmt.Errorf("GdipCreateFromHDC failed: %v", status)
+	}
+
+	GdipSetSmoothingMode(g, SmoothingModeAntiAlias)
+
+	d.graphics = g
+
+	return g, nil
+}
+
+// OwnerDraw draws owner-drawn menu items, list box and combo box items,
+// buttons or other controls.
+type OwnerDraw struct {
+	// Measure, if not nil, sets the size of items. Variable height list and
+	// combo boxes and menus ask for each item, fixed height ones once.
+	Measure func(item *MeasureItem)
+
+	// Draw paints the whole item within item.Rect, whatever item.Action
+	// is. The DC state is restored when it returns. Unless suppressed, the
+	// focus rectangle is drawn afterwards with DrawFocusRect.
+	Draw func(item *DrawItem)
+}
+
+// OnOwnerDraw makes w handle WM_MEASUREITEM and WM_DRAWITEM with od for
+// its owner drawn child control ctlID of type ctlType (ODT_*). For menus,
+// pass ODT_MENU; ctlID is ignored then.
+func (w *Window) OnOwnerDraw(ctlType, ctlID uint32, od *OwnerDraw) {
+	matches := func(typ, id uint32) bool {
+		return typ == ctlType && (ctlType == ODT_MENU || id == ctlID)
+	}
+
+	w.Handle(WM_MEASUREITEM, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		mis := CrackMeasureItemMsg(wParam, lParam).Item
+		if od.Measure == nil || !matches(mis.CtlType, mis.CtlID) {
+			return 0, false
+		}
+
+		item := MeasureItem{
+			CtlType: mis.CtlType,
+			CtlID:   mis.CtlID,
+			ItemID:  mis.ItemID,
+			Data:    mis.ItemData,
+			Width:   int32(mis.ItemWidth),
+			Height:  int32(mis.ItemHeight),
+			DPI:     WindowDPI(w.HWND()),
+		}
+
+		od.Measure(&item)
+
+		mis.ItemWidth = uint32(item.Width)
+		mis.ItemHeight = uint32(item.Height)
+
+		return TRUE, true
+	})
+
+	w.Handle(WM_DRAWITEM, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		dis := CrackDrawItemMsg(wParam, lParam).Item
+		if od.Draw == nil || !matches(dis.CtlType, dis.CtlID) {
+			return 0, false
+		}
+
+		item := DrawItem{
+			CtlType: dis.CtlType,
+			CtlID:   dis.CtlID,
+			ItemID:  dis.ItemID,
+			Action:  dis.ItemAction,
+			State:   dis.ItemState,
+			Control: dis.HwndItem,
+			HDC:     dis.HDC,
+			Rect:    dis.RcItem,
+			Data:    dis.ItemData,
+		}
+
+		saved := SaveDC(item.HDC)
+
+		od.Draw(&item)
+
+		// GDI+ must be done before drawing with GDI again.
+		if item.graphics != nil {
+			GdipDeleteGraphics(item.graphics)
+			item.graphics = nil
+		}
+
+		if saved != 0 {
+			RestoreDC(item.HDC, saved)
+		}
+
+		// The item has been painted over, so the focus rectangle, which
+		// inverts pixels, is drawn for the current state rather than
+		// toggled as usual for ODA_FOCUS.
+		if item.Focused() && !item.NoFocusRect && item.State&ODS_NOFOCUSRECT == 0 {
+			DrawFocusRect(item.HDC, &item.Rect)
+		}
+
+		return TRUE, true
+	})
+}
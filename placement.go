@@ -0,0 +1,172 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+// Monitor describes a display monitor. Rectangles are in virtual screen
+// coordinates.
+type Monitor struct {
+	Handle   HMONITOR `json:"-"`
+	Device   string   `json:"device"`
+	Bounds   RECT     `json:"bounds"`
+	WorkArea RECT     `json:"workArea"`
+	Primary  bool     `json:"primary,omitempty"`
+	DPI      DPI      `json:"dpi"`
+}
+
+// Placement is the restorable position of a top-level window, e.g. to save
+// it between sessions. Normal is the restored window rectangle in screen
+// coordinates, valid at DPI on the monitor described by Monitor and
+// MonitorBounds.
+type Placement struct {
+	Normal        RECT   `json:"normal"`
+	Maximized     bool   `json:"maximized,omitempty"`
+	DPI           DPI    `json:"dpi"`
+	Monitor       string `json:"monitor,omitempty"`
+	MonitorBounds RECT   `json:"monitorBounds"`
+}
+
+// Fit returns p adjusted to monitors, so that the window is entirely
+// within a work area:
+//
+// If the monitor of p still exists, possibly at another position, the
+// window keeps its position relative to it. Otherwise it goes to the
+// monitor it overlaps most, or the nearest one. The size is scaled to the
+// DPI of that monitor and reduced to fit its work area.
+func (p *Placement) Fit(monitors []Monitor) *Placement {
+	fit := *p
+
+	r := p.Normal
+	if len(monitors) == 0 || r.Right <= r.Left || r.Bottom <= r.Top {
+		return &fit
+	}
+
+	var m *Monitor
+	if p.Monitor != "" {
+		for i := range monitors {
+			if monitors[i].Device == p.Monitor {
+				m = &monitors[i]
+
+				dx := m.Bounds.Left - p.MonitorBounds.Left
+				dy := m.Bounds.Top - p.MonitorBounds.Top
+				r = RECT{r.Left + dx, r.Top + dy, r.Right + dx, r.Bottom + dy}
+				break
+			}
+		}
+	}
+	if m == nil {
+		m = bestMonitor(r, monitors)
+	}
+
+	if p.DPI > 0 && m.DPI > 0 && p.DPI != m.DPI {
+		r.Right = r.Left + m.DPI.ScaleFrom(r.Right-r.Left, p.DPI)
+		r.Bottom = r.Top + m.DPI.ScaleFrom(r.Bottom-r.Top, p.DPI)
+	}
+
+	fit.Normal = clampRect(r, m.WorkArea)
+	fit.DPI = m.DPI
+	fit.Monitor = m.Device
+	fit.MonitorBounds = m.Bounds
+
+	return &fit
+}
+
+// bestMonitor returns the monitor r overlaps most or, if r is off-screen,
+// the one nearest to its center, like MonitorFromRect does.
+func bestMonitor(r RECT, monitors []Monitor) *Monitor {
+	var best *Monitor
+	var bestArea int64
+
+	for i := range monitors {
+		if a := rectArea(intersectRect(r, monitors[i].Bounds)); a > bestArea {
+			best, bestArea = &monitors[i], a
+		}
+	}
+	if best != nil {
+		return best
+	}
+
+	cx := int64(r.Left) + int64(r.Right-r.Left)/2
+	cy := int64(r.Top) + int64(r.Bottom-r.Top)/2
+
+	var bestDist int64 = -1
+	for i := range monitors {
+		b := monitors[i].Bounds
+
+		dx := distanceToRange(cx, int64(b.Left), int64(b.Right))
+		dy := distanceToRange(cy, int64(b.Top), int64(b.Bottom))
+
+		if d := dx*dx + dy*dy; bestDist < 0 || d < bestDist {
+			best, bestDist = &monitors[i], d
+		}
+	}
+
+	return best
+}
+
+func distanceToRange(v, min, max int64) int64 {
+	switch {
+	case v < min:
+		return min - v
+
+	case v >= max:
+		return v - max + 1
+	}
+
+	return 0
+}
+
+func intersectRect(a, b RECT) RECT {
+	r := RECT{a.Left, a.Top, a.Right, a.Bottom}
+
+	if b.Left > r.Left {
+		r.Left = b.Left
+	}
+	if b.Top > r.Top {
+		r.Top = b.Top
+	}
+	if b.Right < r.Right {
+		r.Right = b.Right
+	}
+	if b.Bottom < r.Bottom {
+		r.Bottom = b.Bottom
+	}
+
+	return r
+}
+
+func rectArea(r RECT) int64 {
+	if r.Right <= r.Left || r.Bottom <= r.Top {
+		return 0
+	}
+
+	return int64(r.Right-r.Left) * int64(r.Bottom-r.Top)
+}
+
+// clampRect moves r into bounds, shrinking it if it is larger.
+func clampRect(r, bounds RECT) RECT {
+	w, h := r.Right-r.Left, r.Bottom-r.Top
+	if max := bounds.Right - bounds.Left; w > max {
+		w = max
+	}
+	if max := bounds.Bottom - bounds.Top; h > max {
+		h = max
+	}
+
+	x, y := r.Left, r.Top
+	if x+w > bounds.Right {
+		x = bounds.Right - w
+	}
+	if x < bounds.Left {
+		x = bounds.Left
+	}
+	if y+h > bounds.Bottom {
+		y = bounds.Bottom - h
+	}
+	if y < bounds.Top {
+		y = bounds.Top
+	}
+
+	return RECT{x, y, x + w, y + h}
+}
@@ -0,0 +1,228 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var (
+	testPrimaryMonitor = Monitor{
+		Device:   `\\.\DISPLAY1`,
+		Bounds:   RECT{0, 0, 1920, 1080},
+		WorkArea: RECT{0, 0, 1920, 1040},
+		Primary:  true,
+		DPI:      96,
+	}
+	testSecondMonitor = Monitor{
+		Device:   `\\.\DISPLAY2`,
+		Bounds:   RECT{1920, 0, 4480, 1440},
+		WorkArea: RECT{1920, 0, 4480, 1400},
+		DPI:      144,
+	}
+)
+
+func TestPlacementFit(t *testing.T) {
+	onSecond := Placement{
+		Normal:        RECT{2000, 100, 2800, 700},
+		DPI:           144,
+		Monitor:       testSecondMonitor.Device,
+		MonitorBounds: testSecondMonitor.Bounds,
+	}
+
+	// The second monitor, moved left of and above the primary one.
+	movedSecond := testSecondMonitor
+	movedSecond.Bounds = RECT{-2560, -200, 0, 1240}
+	movedSecond.WorkArea = RECT{-2560, -200, 0, 1200}
+
+	// The primary monitor, at 125%.
+	scaledPrimary := testPrimaryMonitor
+	scaledPrimary.DPI = 120
+
+	both := []Monitor{testPrimaryMonitor, testSecondMonitor}
+
+	tests := []struct {
+		name     string
+		p        Placement
+		monitors []Monitor
+		want     Placement
+	}{
+		{
+			"unchanged",
+			onSecond,
+			both,
+			onSecond,
+		},
+		{
+			// The window goes to the nearest monitor, scaled from 144 to
+			// 96 DPI, and is moved into its work area.
+			"removed monitor",
+			onSecond,
+			[]Monitor{testPrimaryMonitor},
+			Placement{
+				Normal:        RECT{1387, 100, 1920, 500},
+				DPI:           96,
+				Monitor:       testPrimaryMonitor.Device,
+				MonitorBounds: testPrimaryMonitor.Bounds,
+			},
+		},
+		{
+			// The window keeps its position relative to the monitor.
+			"rearranged monitor",
+			onSecond,
+			[]Monitor{testPrimaryMonitor, movedSecond},
+			Placement{
+				Normal:        RECT{-2480, -100, -1680, 500},
+				DPI:           144,
+				Monitor:       movedSecond.Device,
+				MonitorBounds: movedSecond.Bounds,
+			},
+		},
+		{
+			// The monitor nearest to the center of the window is the
+			// second one.
+			"off-screen",
+			Placement{Normal: RECT{5000, 3000, 5400, 3300}, DPI: 96},
+			both,
+			Placement{
+				Normal:        RECT{3880, 950, 4480, 1400},
+				DPI:           144,
+				Monitor:       testSecondMonitor.Device,
+				MonitorBounds: testSecondMonitor.Bounds,
+			},
+		},
+		{
+			"off-screen unknown monitor",
+			Placement{
+				Normal:        RECT{-900, -700, -500, -400},
+				DPI:           96,
+				Monitor:       `\\.\DISPLAY3`,
+				MonitorBounds: RECT{-1000, -800, 0, 0},
+			},
+			both,
+			Placement{
+				Normal:        RECT{0, 0, 400, 300},
+				DPI:           96,
+				Monitor:       testPrimaryMonitor.Device,
+				MonitorBounds: testPrimaryMonitor.Bounds,
+			},
+		},
+		{
+			// The window goes to the monitor it overlaps most.
+			"overlapping",
+			Placement{Normal: RECT{1800, 100, 2400, 500}},
+			both,
+			Placement{
+				Normal:        RECT{1920, 100, 2520, 500},
+				DPI:           144,
+				Monitor:       testSecondMonitor.Device,
+				MonitorBounds: testSecondMonitor.Bounds,
+			},
+		},
+		{
+			"larger than work area",
+			Placement{
+				Normal:        RECT{-100, -50, 2500, 1500},
+				Maximized:     true,
+				DPI:           96,
+				Monitor:       testPrimaryMonitor.Device,
+				MonitorBounds: testPrimaryMonitor.Bounds,
+			},
+			both,
+			Placement{
+				Normal:        RECT{0, 0, 1920, 1040},
+				Maximized:     true,
+				DPI:           96,
+				Monitor:       testPrimaryMonitor.Device,
+				MonitorBounds: testPrimaryMonitor.Bounds,
+			},
+		},
+		{
+			// The size is scaled from 96 to 120 DPI, the position kept.
+			"DPI change",
+			Placement{
+				Normal:        RECT{100, 100, 500, 400},
+				DPI:           96,
+				Monitor:       testPrimaryMonitor.Device,
+				MonitorBounds: testPrimaryMonitor.Bounds,
+			},
+			[]Monitor{scaledPrimary, testSecondMonitor},
+			Placement{
+				Normal:        RECT{100, 100, 600, 475},
+				DPI:           120,
+				Monitor:       testPrimaryMonitor.Device,
+				MonitorBounds: testPrimaryMonitor.Bounds,
+			},
+		},
+		{
+			"no monitors",
+			onSecond,
+			nil,
+			onSecond,
+		},
+		{
+			"empty rectangle",
+			Placement{Normal: RECT{10, 10, 10, 20}},
+			both,
+			Placement{Normal: RECT{10, 10, 10, 20}},
+		},
+	}
+
+	for _, tt := range tests {
+		p := tt.p
+
+		if got := p.Fit(tt.monitors); !reflect.DeepEqual(*got, tt.want) {
+			t.Errorf("%s: Fit = %+v, want %+v", tt.name, *got, tt.want)
+		}
+		if p != tt.p {
+			t.Errorf("%s: Fit modified the placement", tt.name)
+		}
+	}
+}
+
+func TestClampRect(t *testing.T) {
+	bounds := RECT{-100, 0, 100, 50}
+
+	tests := []struct {
+		r, want RECT
+	}{
+		{RECT{-10, 10, 10, 20}, RECT{-10, 10, 10, 20}},
+		{RECT{90, 45, 110, 55}, RECT{80, 40, 100, 50}},
+		{RECT{-150, -20, -130, -10}, RECT{-100, 0, -80, 10}},
+		{RECT{-300, -300, 300, 300}, RECT{-100, 0, 100, 50}},
+		{RECT{50, 10, 450, 20}, RECT{-100, 10, 100, 20}},
+	}
+
+	for _, tt := range tests {
+		if got := clampRect(tt.r, bounds); got != tt.want {
+			t.Errorf("clampRect(%v) = %v, want %v", tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestPlacementJSON(t *testing.T) {
+	p := Placement{
+		Normal:        RECT{-10, 20, 300, 400},
+		Maximized:     true,
+		DPI:           144,
+		Monitor:       `\\.\DISPLAY2`,
+		MonitorBounds: RECT{-1920, 0, 0, 1080},
+	}
+
+	data, err := json.Marshal(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Placement
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != p {
+		t.Errorf("json round trip of %s = %+v, want %+v", data, got, p)
+	}
+}
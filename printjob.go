@@ -0,0 +1,332 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	ErrNoDefaultPrinter  = errors.New("no default printer")
+	ErrPrintJobCanceled  = errors.New("print job canceled")
+	ErrPrintJobAbandoned = errors.New("print job has been closed")
+)
+
+// DevModePatch holds the DEVMODE members a PrintJob overrides.
+// Zero values leave the driver defaults untouched.
+type DevModePatch struct {
+	Orientation int16 // DMORIENT_*
+	PaperSize   int16 // DMPAPER_*
+	Copies      int16
+	Duplex      int16 // DMDUP_*
+	Color       int16 // DMCOLOR_*
+}
+
+func (p *DevModePatch) apply(dm *DEVMODE) {
+	if p == nil {
+		return
+	}
+
+	if p.Orientation != 0 {
+		dm.DmOrientation = p.Orientation
+		dm.DmFields |= DM_ORIENTATION
+	}
+	if p.PaperSize != 0 {
+		dm.DmPaperSize = p.PaperSize
+		dm.DmFields |= DM_PAPERSIZE
+	}
+	if p.Copies != 0 {
+		dm.DmCopies = p.Copies
+		dm.DmFields |= DM_COPIES
+	}
+	if p.Duplex != 0 {
+		dm.DmDuplex = p.Duplex
+		dm.DmFields |= DM_DUPLEX
+	}
+	if p.Color != 0 {
+		dm.DmColor = p.Color
+		dm.DmFields |= DM_COLOR
+	}
+}
+
+// PrintArea describes the printable area of a page.
+//
+// Printable is in device units with its origin at the top left corner of the
+// printable area, which is where GDI places (0, 0) on a printer DC. Offset is
+// the distance of that corner from the physical paper edge.
+type PrintArea struct {
+	Printable RECT
+	Offset    POINT
+	Physical  SIZE
+	DPI       POINT
+
+	WidthMM, HeightMM           float64
+	OffsetXMM, OffsetYMM        float64
+	PaperWidthMM, PaperHeightMM float64
+}
+
+// ToMM converts a horizontal and vertical device unit distance to millimeters.
+func (a *PrintArea) ToMM(x, y int32) (float64, float64) {
+	return deviceUnitsToMM(x, a.DPI.X), deviceUnitsToMM(y, a.DPI.Y)
+}
+
+// FromMM converts millimeters to horizontal and vertical device units.
+func (a *PrintArea) FromMM(x, y float64) (int32, int32) {
+	return int32(x*float64(a.DPI.X)/25.4 + 0.5), int32(y*float64(a.DPI.Y)/25.4 + 0.5)
+}
+
+func deviceUnitsToMM(v, dpi int32) float64 {
+	if dpi == 0 {
+		return 0
+	}
+
+	return float64(v) * 25.4 / float64(dpi)
+}
+
+// PrintAreaFromDC queries the printable area of the page currently
+// selected into hdc.
+func PrintAreaFromDC(hdc HDC) PrintArea {
+	var a PrintArea
+
+	a.DPI = POINT{GetDeviceCaps(hdc, LOGPIXELSX), GetDeviceCaps(hdc, LOGPIXELSY)}
+	a.Offset = POINT{GetDeviceCaps(hdc, PHYSICALOFFSETX), GetDeviceCaps(hdc, PHYSICALOFFSETY)}
+	a.Physical = SIZE{GetDeviceCaps(hdc, PHYSICALWIDTH), GetDeviceCaps(hdc, PHYSICALHEIGHT)}
+	a.Printable = RECT{0, 0, GetDeviceCaps(hdc, HORZRES), GetDeviceCaps(hdc, VERTRES)}
+
+	a.WidthMM, a.HeightMM = a.ToMM(a.Printable.Right, a.Printable.Bottom)
+	a.OffsetXMM, a.OffsetYMM = a.ToMM(a.Offset.X, a.Offset.Y)
+	a.PaperWidthMM, a.PaperHeightMM = a.ToMM(a.Physical.CX, a.Physical.CY)
+
+	return a
+}
+
+// PrintPage is passed to a PrintPageFunc for every page of a PrintJob.
+type PrintPage struct {
+	HDC   HDC
+	Index int
+	Area  PrintArea
+}
+
+// PrintPageFunc renders a single page. It returns whether more pages follow.
+// A non-nil error aborts the job.
+type PrintPageFunc func(page *PrintPage) (more bool, err error)
+
+// PrintJob ties a printer DC to the DEVMODE it was created with and drives
+// the StartDoc/StartPage/EndPage/EndDoc sequence.
+type PrintJob struct {
+	printerName string
+	devMode     []byte
+	hdc         HDC
+	canceled    int32
+}
+
+// NewPrintJob opens a DC for the named printer, or the default printer if
+// printerName is empty, with patch applied on top of the driver defaults.
+func NewPrintJob(printerName string, patch *DevModePatch) (*PrintJob, error) {
+	if printerName == "" {
+		name, err := defaultPrinterName()
+		if err != nil {
+			return nil, err
+		}
+		printerName = name
+	}
+
+	name16, err := syscall.UTF16PtrFromString(printerName)
+	if err != nil {
+		return nil, err
+	}
+
+	devMode, err := printerDevMode(name16, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	hdc := CreateDC(nil, name16, nil, (*DEVMODE)(unsafe.Pointer(&devMode[0])))
+	if hdc == 0 {
+		return nil, errors.New("CreateDC failed")
+	}
+
+	return &PrintJob{
+		printerName: printerName,
+		devMode:     devMode,
+		hdc:         hdc,
+	}, nil
+}
+
+func defaultPrinterName() (string, error) {
+	var size uint32
+	GetDefaultPrinter(nil, &size)
+	if size == 0 {
+		return "", ErrNoDefaultPrinter
+	}
+
+	buf := make([]uint16, size)
+	if !GetDefaultPrinter(&buf[0], &size) {
+		return "", ErrNoDefaultPrinter
+	}
+
+	return syscall.UTF16ToString(buf), nil
+}
+
+// printerDevMode returns the driver DEVMODE of the printer, including the
+// driver private bytes, with patch merged in by the driver.
+func printerDevMode(name16 *uint16, patch *DevModePatch) ([]byte, error) {
+	var hPrinter HANDLE
+	if !OpenPrinter(name16, &hPrinter, nil) {
+		return nil, errors.New("OpenPrinter failed")
+	}
+	defer ClosePrinter(hPrinter)
+
+	size := DocumentProperties(0, hPrinter, name16, nil, nil, 0)
+	if size < int32(unsafe.Sizeof(DEVMODE{})) {
+		return nil, errors.New("DocumentProperties failed")
+	}
+
+	buf := make([]byte, size)
+	dm := (*DEVMODE)(unsafe.Pointer(&buf[0]))
+
+	if DocumentProperties(0, hPrinter, name16, dm, nil, DM_OUT_BUFFER) < 0 {
+		return nil, errors.New("DocumentProperties failed")
+	}
+
+	if patch != nil {
+		if patch.Duplex != 0 && patch.Duplex != DMDUP_SIMPLEX &&
+			DeviceCapabilities(name16, nil, DC_DUPLEX, nil, nil) != 1 {
+			return nil, errors.New("printer does not support duplex")
+		}
+
+		if patch.Copies > 1 {
+			if max := int32(DeviceCapabilities(name16, nil, DC_COPIES, nil, nil)); max > 0 && int32(patch.Copies) > max {
+				return nil, errors.New("too many copies requested")
+			}
+		}
+
+		patch.apply(dm)
+
+		if DocumentProperties(0, hPrinter, name16, dm, dm, DM_IN_BUFFER|DM_OUT_BUFFER) < 0 {
+			return nil, errors.New("DocumentProperties failed")
+		}
+	}
+
+	return buf, nil
+}
+
+// PrinterName returns the name of the printer the job prints to.
+func (j *PrintJob) PrinterName() string {
+	return j.printerName
+}
+
+// DevMode returns the DEVMODE the printer DC was created with. It is
+// followed in memory by DmDriverExtra bytes of driver private data.
+func (j *PrintJob) DevMode() *DEVMODE {
+	return (*DEVMODE)(unsafe.Pointer(&j.devMode[0]))
+}
+
+// HDC returns the printer device context.
+func (j *PrintJob) HDC() HDC {
+	return j.hdc
+}
+
+// Area returns the printable area of the current page.
+func (j *PrintJob) Area() PrintArea {
+	return PrintAreaFromDC(j.hdc)
+}
+
+// Cancel requests the job to be aborted. It is safe to call from any
+// goroutine; the running Print call aborts the document with AbortDoc before
+// the next page is started and returns ErrPrintJobCanceled. Cancel has no
+// effect when no Print call is running, so the job can print again.
+func (j *PrintJob) Cancel() {
+	atomic.StoreInt32(&j.canceled, 1)
+}
+
+func (j *PrintJob) isCanceled() bool {
+	return atomic.LoadInt32(&j.canceled) != 0
+}
+
+// Print spools a document named docName, calling render once per page until
+// it reports no more pages, returns an error or the job is canceled.
+func (j *PrintJob) Print(docName string, render PrintPageFunc) error {
+	if j.hdc == 0 {
+		return ErrPrintJobAbandoned
+	}
+
+	// Cancellations of earlier documents do not apply to this one.
+	atomic.StoreInt32(&j.canceled, 0)
+
+	docName16, err := syscall.UTF16PtrFromString(docName)
+	if err != nil {
+		return err
+	}
+
+	di := DOCINFO{
+		CbSize:      int32(unsafe.Sizeof(DOCINFO{})),
+		LpszDocName: docName16,
+	}
+
+	if StartDoc(j.hdc, &di) <= 0 {
+		return errors.New("StartDoc failed")
+	}
+
+	for index := 0; ; index++ {
+		if j.isCanceled() {
+			AbortDoc(j.hdc)
+			return ErrPrintJobCanceled
+		}
+
+		if StartPage(j.hdc) <= 0 {
+			AbortDoc(j.hdc)
+			return errors.New("StartPage failed")
+		}
+
+		page := &PrintPage{
+			HDC:   j.hdc,
+			Index: index,
+			Area:  j.Area(),
+		}
+
+		more, err := render(page)
+		if err != nil {
+			AbortDoc(j.hdc)
+			return err
+		}
+
+		if EndPage(j.hdc) <= 0 {
+			AbortDoc(j.hdc)
+			return errors.New("EndPage failed")
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	if EndDoc(j.hdc) <= 0 {
+		return errors.New("EndDoc failed")
+	}
+
+	return nil
+}
+
+// Close releases the printer DC.
+func (j *PrintJob) Close() error {
+	if j.hdc == 0 {
+		return nil
+	}
+
+	ok := DeleteDC(j.hdc)
+	j.hdc = 0
+
+	if !ok {
+		return errors.New("DeleteDC failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// RawInputDevice describes a device returned by RawInputDevices.
+type RawInputDevice struct {
+	Handle HANDLE
+	Type   uint32 // RIM_TYPE*
+	Name   string
+	Info   RID_DEVICE_INFO
+}
+
+// RawInputDevices enumerates the raw input devices attached to the system.
+// Devices that are removed during enumeration are skipped.
+func RawInputDevices() ([]RawInputDevice, error) {
+	var list []RAWINPUTDEVICELIST
+	size := uint32(unsafe.Sizeof(RAWINPUTDEVICELIST{}))
+
+	// The list may grow between the two calls if a device is attached, in
+	// which case the second call fails and is retried.
+	for tries := 0; ; tries++ {
+		var n uint32
+		if GetRawInputDeviceList(nil, &n, size) == ^uint32(0) {
+			return nil, errors.New("GetRawInputDeviceList failed")
+		}
+		if n == 0 {
+			return nil, nil
+		}
+
+		list = make([]RAWINPUTDEVICELIST, n)
+
+		ret := GetRawInputDeviceList(&list[0], &n, size)
+		if ret != ^uint32(0) {
+			list = list[:ret]
+			break
+		}
+
+		if tries == 2 {
+			return nil, errors.New("GetRawInputDeviceList failed")
+		}
+	}
+
+	devices := make([]RawInputDevice, 0, len(list))
+	for _, item := range list {
+		info, err := RawInputDeviceInfo(item.HDevice)
+		if err != nil {
+			continue
+		}
+
+		name, _ := RawInputDeviceName(item.HDevice)
+
+		devices = append(devices, RawInputDevice{
+			Handle: item.HDevice,
+			Type:   item.DwType,
+			Name:   name,
+			Info:   *info,
+		})
+	}
+
+	return devices, nil
+}
+
+// RawInputDeviceName returns the device interface path of hDevice, which
+// can be passed to CreateFile.
+func RawInputDeviceName(hDevice HANDLE) (string, error) {
+	var n uint32
+	if GetRawInputDeviceInfo(hDevice, RIDI_DEVICENAME, nil, &n) == ^uint32(0) || n == 0 {
+		return "", errors.New("GetRawInputDeviceInfo failed")
+	}
+
+	buf := make([]uint16, n)
+	if GetRawInputDeviceInfo(hDevice, RIDI_DEVICENAME, unsafe.Pointer(&buf[0]), &n) == ^uint32(0) {
+		return "", errors.New("GetRawInputDeviceInfo failed")
+	}
+
+	return syscall.UTF16ToString(buf), nil
+}
+
+// RawInputDeviceInfo returns the RIDI_DEVICEINFO of hDevice.
+func RawInputDeviceInfo(hDevice HANDLE) (*RID_DEVICE_INFO, error) {
+	info := &RID_DEVICE_INFO{CbSize: uint32(unsafe.Sizeof(RID_DEVICE_INFO{}))}
+	n := info.CbSize
+
+	if GetRawInputDeviceInfo(hDevice, RIDI_DEVICEINFO, unsafe.Pointer(info), &n) == ^uint32(0) {
+		return nil, errors.New("GetRawInputDeviceInfo failed")
+	}
+
+	return info, nil
+}
+
+// RawInputPreparsedData returns the HID preparsed data of hDevice, as used
+// by the HidP_* functions of hid.dll.
+func RawInputPreparsedData(hDevice HANDLE) ([]byte, error) {
+	var n uint32
+	if GetRawInputDeviceInfo(hDevice, RIDI_PREPARSEDDATA, nil, &n) == ^uint32(0) || n == 0 {
+		return nil, errors.New("GetRawInputDeviceInfo failed")
+	}
+
+	buf := make([]byte, n)
+	if GetRawInputDeviceInfo(hDevice, RIDI_PREPARSEDDATA, unsafe.Pointer(&buf[0]), &n) == ^uint32(0) {
+		return nil, errors.New("GetRawInputDeviceInfo failed")
+	}
+
+	return buf, nil
+}
+
+// RawInput registers a window for raw input and reads and decodes the
+// input it receives.
+type RawInput struct {
+	hwnd HWND
+
+	// parser decodes GetRawInputData results, bufParser GetRawInputBuffer
+	// results. They differ for 32-bit processes on 64-bit Windows, which
+	// get the 64-bit layout from GetRawInputBuffer.
+	parser    RawInputParser
+	bufParser RawInputParser
+
+	buf []uint64
+}
+
+// NewRawInput returns a RawInput delivering WM_INPUT to hwnd.
+func NewRawInput(hwnd HWND) *RawInput {
+	ri := &RawInput{hwnd: hwnd}
+
+	var wow64 bool
+	if windows.IsWow64Process(windows.CurrentProcess(), &wow64) == nil && wow64 {
+		ri.bufParser.PtrSize = 8
+	}
+
+	return ri
+}
+
+// Register starts delivery of input from devices with the given HID usage
+// page and usage, e.g. HID_USAGE_PAGE_GENERIC and HID_USAGE_GENERIC_MOUSE.
+// flags holds RIDEV_* values such as RIDEV_INPUTSINK or RIDEV_DEVNOTIFY.
+func (ri *RawInput) Register(usagePage, usage uint16, flags uint32) error {
+	rid := RAWINPUTDEVICE{
+		UsUsagePage: usagePage,
+		UsUsage:     usage,
+		DwFlags:     flags,
+		HwndTarget:  ri.hwnd,
+	}
+
+	if !RegisterRawInputDevices(&rid, 1, uint32(unsafe.Sizeof(rid))) {
+		return errors.New("RegisterRawInputDevices failed")
+	}
+
+	return nil
+}
+
+// Unregister stops delivery of input from devices with the given HID usage
+// page and usage.
+func (ri *RawInput) Unregister(usagePage, usage uint16) error {
+	rid := RAWINPUTDEVICE{
+		UsUsagePage: usagePage,
+		UsUsage:     usage,
+		DwFlags:     RIDEV_REMOVE,
+	}
+
+	if !RegisterRawInputDevices(&rid, 1, uint32(unsafe.Sizeof(rid))) {
+		return errors.New("RegisterRawInputDevices failed")
+	}
+
+	return nil
+}
+
+// buffer returns an 8 byte aligned buffer of at least size bytes.
+func (ri *RawInput) buffer(size uint32) []byte {
+	words := (int(size) + 7) / 8
+	if len(ri.buf) < words {
+		ri.buf = make([]uint64, words)
+	}
+
+	return (*[1 << 30]byte)(unsafe.Pointer(&ri.buf[0]))[:size:size]
+}
+
+// Read decodes the input of a WM_INPUT message, whose lParam is hRawInput.
+// The window procedure must still pass the message to DefWindowProc.
+func (ri *RawInput) Read(hRawInput HRAWINPUT) (RawInputEvent, error) {
+	hdrSize := uint32(ri.parser.HeaderSize())
+
+	var size uint32
+	if GetRawInputData(hRawInput, RID_INPUT, nil, &size, hdrSize) == ^uint32(0) || size == 0 {
+		return nil, errors.New("GetRawInputData failed")
+	}
+
+	buf := ri.buffer(size)
+
+	n := GetRawInputData(hRawInput, RID_INPUT, unsafe.Pointer(&buf[0]), &size, hdrSize)
+	if n == ^uint32(0) {
+		return nil, errors.New("GetRawInputData failed")
+	}
+
+	return ri.parser.Parse(buf[:n])
+}
+
+// ReadBuffer drains the raw input queued for the calling thread with
+// GetRawInputBuffer. It is an alternative to reading each WM_INPUT message
+// for high-frequency devices, and should be called while handling WM_INPUT.
+func (ri *RawInput) ReadBuffer() ([]RawInputEvent, error) {
+	hdrSize := uint32(unsafe.Sizeof(RAWINPUTHEADER{}))
+
+	var size uint32
+	if GetRawInputBuffer(nil, &size, hdrSize) == ^uint32(0) {
+		return nil, errors.New("GetRawInputBuffer failed")
+	}
+	if size == 0 {
+		return nil, nil
+	}
+
+	// Room for a batch of events per call.
+	size *= 16
+	buf := ri.buffer(size)
+
+	var events []RawInputEvent
+	for {
+		n := size
+		count := GetRawInputBuffer(unsafe.Pointer(&buf[0]), &n, hdrSize)
+		if count == ^uint32(0) {
+			return events, errors.New("GetRawInputBuffer failed")
+		}
+		if count == 0 {
+			return events, nil
+		}
+
+		var err error
+		if events, err = ri.bufParser.AppendBuffer(events, buf, int(count)); err != nil {
+			return events, err
+		}
+	}
+}
+
+// Attach makes w decode every WM_INPUT message it receives and pass the
+// event to f. Input that cannot be read or decoded is skipped. The message
+// is passed on to the default window procedure afterwards, as required.
+func (ri *RawInput) Attach(w *Window, f func(e RawInputEvent)) {
+	w.Handle(WM_INPUT, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		if e, err := ri.Read(CrackInputMsg(wParam, lParam).Input); err == nil {
+			f(e)
+		}
+		return 0, false
+	})
+}
@@ -0,0 +1,285 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"encoding/binary"
+	"errors"
+	"unsafe"
+)
+
+// WM_INPUT wParam values
+const (
+	RIM_INPUT     = 0
+	RIM_INPUTSINK = 1
+)
+
+// Raw input type
+const (
+	RIM_TYPEHID      = 2
+	RIM_TYPEKEYBOARD = 1
+	RIM_TYPEMOUSE    = 0
+)
+
+// Raw input scan code information
+const (
+	RI_KEY_MAKE  = 0
+	RI_KEY_BREAK = 1
+	RI_KEY_E0    = 2
+	RI_KEY_E1    = 4
+)
+
+// Raw input mouse state
+const (
+	MOUSE_MOVE_RELATIVE      = 0x00
+	MOUSE_MOVE_ABSOLUTE      = 0x01
+	MOUSE_VIRTUAL_DESKTOP    = 0x02
+	MOUSE_ATTRIBUTES_CHANGED = 0x04
+)
+
+// Raw input transistion state of mouse buttons
+const (
+	RI_MOUSE_LEFT_BUTTON_DOWN   = 0x0001
+	RI_MOUSE_LEFT_BUTTON_UP     = 0x0002
+	RI_MOUSE_MIDDLE_BUTTON_DOWN = 0x0010
+	RI_MOUSE_MIDDLE_BUTTON_UP   = 0x0020
+	RI_MOUSE_RIGHT_BUTTON_DOWN  = 0x0004
+	RI_MOUSE_RIGHT_BUTTON_UP    = 0x0008
+	RI_MOUSE_BUTTON_1_DOWN      = 0x0001
+	RI_MOUSE_BUTTON_1_UP        = 0x0002
+	RI_MOUSE_BUTTON_2_DOWN      = 0x0004
+	RI_MOUSE_BUTTON_2_UP        = 0x0008
+	RI_MOUSE_BUTTON_3_DOWN      = 0x0010
+	RI_MOUSE_BUTTON_3_UP        = 0x0020
+	RI_MOUSE_BUTTON_4_DOWN      = 0x0040
+	RI_MOUSE_BUTTON_4_UP        = 0x0080
+	RI_MOUSE_BUTTON_5_DOWN      = 0x0100
+	RI_MOUSE_BUTTON_5_UP        = 0x0200
+	RI_MOUSE_WHEEL              = 0x0400
+	RI_MOUSE_HWHEEL             = 0x0800
+)
+
+var ErrInvalidRawInput = errors.New("invalid RAWINPUT data")
+
+// RawInputHeader is the decoded RAWINPUTHEADER of a raw input event.
+type RawInputHeader struct {
+	Type   uint32 // RIM_TYPEMOUSE, RIM_TYPEKEYBOARD or RIM_TYPEHID
+	Size   uint32
+	Device HANDLE
+	WParam uintptr // RIM_INPUT or RIM_INPUTSINK
+}
+
+func (h *RawInputHeader) RawHeader() *RawInputHeader {
+	return h
+}
+
+// RawInputEvent is a decoded raw input event, one of *RawMouseEvent,
+// *RawKeyboardEvent and *RawHIDEvent.
+type RawInputEvent interface {
+	RawHeader() *RawInputHeader
+}
+
+// RawMouseEvent is the decoded RAWMOUSE of a raw input event.
+type RawMouseEvent struct {
+	RawInputHeader
+	Flags            uint16 // MOUSE_MOVE_*
+	ButtonFlags      uint16 // RI_MOUSE_*
+	ButtonData       uint16
+	RawButtons       uint32
+	LastX            int32
+	LastY            int32
+	ExtraInformation uint32
+}
+
+// WheelDelta returns the rotation of the vertical or horizontal wheel, or
+// 0 if the event is not a wheel event.
+func (e *RawMouseEvent) WheelDelta() int16 {
+	if e.ButtonFlags&(RI_MOUSE_WHEEL|RI_MOUSE_HWHEEL) == 0 {
+		return 0
+	}
+
+	return int16(e.ButtonData)
+}
+
+// Absolute reports whether LastX and LastY are absolute coordinates in the
+// range 0..65535, as sent by tablets and remote desktop sessions.
+func (e *RawMouseEvent) Absolute() bool {
+	return e.Flags&MOUSE_MOVE_ABSOLUTE != 0
+}
+
+// RawKeyboardEvent is the decoded RAWKEYBOARD of a raw input event.
+type RawKeyboardEvent struct {
+	RawInputHeader
+	MakeCode         uint16
+	Flags            uint16 // RI_KEY_*
+	VKey             uint16
+	Message          uint32 // WM_KEYDOWN, WM_SYSKEYUP etc.
+	ExtraInformation uint32
+}
+
+// Released reports whether the event is a key release.
+func (e *RawKeyboardEvent) Released() bool {
+	return e.Flags&RI_KEY_BREAK != 0
+}
+
+// RawHIDEvent is the decoded RAWHID of a raw input event. A single event
+// may carry several input reports of ReportSize bytes each.
+type RawHIDEvent struct {
+	RawInputHeader
+	ReportSize uint32
+	Reports    [][]byte
+}
+
+// RawInputParser decodes RAWINPUT structures from memory. It does not call
+// into Windows, so it can also be used on captured data.
+type RawInputParser struct {
+	// PtrSize is the size of handles in the data, 4 or 8. Zero selects the
+	// pointer size of the current process.
+	PtrSize int
+}
+
+func (p RawInputParser) ptrSize() int {
+	if p.PtrSize == 0 {
+		return int(unsafe.Sizeof(uintptr(0)))
+	}
+
+	return p.PtrSize
+}
+
+// HeaderSize returns the size of RAWINPUTHEADER.
+func (p RawInputParser) HeaderSize() int {
+	return 8 + 2*p.ptrSize()
+}
+
+func (p RawInputParser) readPtr(b []byte) uintptr {
+	if p.ptrSize() == 8 {
+		return uintptr(binary.LittleEndian.Uint64(b))
+	}
+
+	return uintptr(binary.LittleEndian.Uint32(b))
+}
+
+// Parse decodes a single RAWINPUT, as returned by GetRawInputData.
+func (p RawInputParser) Parse(data []byte) (RawInputEvent, error) {
+	hdrSize := p.HeaderSize()
+	if len(data) < hdrSize {
+		return nil, ErrInvalidRawInput
+	}
+
+	ps := p.ptrSize()
+	hdr := RawInputHeader{
+		Type:   binary.LittleEndian.Uint32(data[0:]),
+		Size:   binary.LittleEndian.Uint32(data[4:]),
+		Device: HANDLE(p.readPtr(data[8:])),
+		WParam: p.readPtr(data[8+ps:]),
+	}
+
+	if hdr.Size < uint32(hdrSize) || uint64(hdr.Size) > uint64(len(data)) {
+		return nil, ErrInvalidRawInput
+	}
+
+	body := data[hdrSize:hdr.Size]
+
+	switch hdr.Type {
+	case RIM_TYPEMOUSE:
+		// usFlags is followed by 2 bytes of padding, since the button
+		// fields share a union with a ULONG.
+		if len(body) < 24 {
+			return nil, ErrInvalidRawInput
+		}
+
+		return &RawMouseEvent{
+			RawInputHeader:   hdr,
+			Flags:            binary.LittleEndian.Uint16(body[0:]),
+			ButtonFlags:      binary.LittleEndian.Uint16(body[4:]),
+			ButtonData:       binary.LittleEndian.Uint16(body[6:]),
+			RawButtons:       binary.LittleEndian.Uint32(body[8:]),
+			LastX:            int32(binary.LittleEndian.Uint32(body[12:])),
+			LastY:            int32(binary.LittleEndian.Uint32(body[16:])),
+			ExtraInformation: binary.LittleEndian.Uint32(body[20:]),
+		}, nil
+
+	case RIM_TYPEKEYBOARD:
+		if len(body) < 16 {
+			return nil, ErrInvalidRawInput
+		}
+
+		return &RawKeyboardEvent{
+			RawInputHeader:   hdr,
+			MakeCode:         binary.LittleEndian.Uint16(body[0:]),
+			Flags:            binary.LittleEndian.Uint16(body[2:]),
+			VKey:             binary.LittleEndian.Uint16(body[6:]),
+			Message:          binary.LittleEndian.Uint32(body[8:]),
+			ExtraInformation: binary.LittleEndian.Uint32(body[12:]),
+		}, nil
+
+	case RIM_TYPEHID:
+		if len(body) < 8 {
+			return nil, ErrInvalidRawInput
+		}
+
+		size := binary.LittleEndian.Uint32(body[0:])
+		count := binary.LittleEndian.Uint32(body[4:])
+
+		// Reports of size 0 take no room, so their count is not bounded
+		// by the data and must not size the allocation below.
+		if size == 0 && count != 0 {
+			return nil, ErrInvalidRawInput
+		}
+
+		total := uint64(size) * uint64(count)
+		if count > uint32(len(body)-8) || total > uint64(len(body)-8) {
+			return nil, ErrInvalidRawInput
+		}
+
+		// Copy, since the source is usually a reused buffer.
+		raw := append([]byte(nil), body[8:8+total]...)
+
+		e := &RawHIDEvent{
+			RawInputHeader: hdr,
+			ReportSize:     size,
+			Reports:        make([][]byte, count),
+		}
+		for i := range e.Reports {
+			e.Reports[i] = raw[uint32(i)*size : uint32(i+1)*size : uint32(i+1)*size]
+		}
+
+		return e, nil
+	}
+
+	return nil, ErrInvalidRawInput
+}
+
+// ParseBuffer decodes count consecutive RAWINPUT structures, as returned by
+// GetRawInputBuffer. A negative count decodes until data is exhausted.
+func (p RawInputParser) ParseBuffer(data []byte, count int) ([]RawInputEvent, error) {
+	return p.AppendBuffer(nil, data, count)
+}
+
+// AppendBuffer is like ParseBuffer but appends to dst.
+func (p RawInputParser) AppendBuffer(dst []RawInputEvent, data []byte, count int) ([]RawInputEvent, error) {
+	// NEXTRAWINPUTBLOCK aligns each block to the pointer size.
+	align := p.ptrSize()
+
+	for i := 0; count < 0 || i < count; i++ {
+		if count < 0 && len(data) < p.HeaderSize() {
+			break
+		}
+
+		e, err := p.Parse(data)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, e)
+
+		next := (int(e.RawHeader().Size) + align - 1) &^ (align - 1)
+		if next > len(data) {
+			next = len(data)
+		}
+		data = data[next:]
+	}
+
+	return dst, nil
+}
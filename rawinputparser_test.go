@@ -0,0 +1,242 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+// rawInput returns a RAWINPUT of the given type and body with handles of
+// ptrSize bytes.
+func rawInput(ptrSize int, typ uint32, device, wParam uint64, body []byte) []byte {
+	le := binary.LittleEndian
+
+	b := make([]byte, 8+2*ptrSize, 8+2*ptrSize+len(body))
+	le.PutUint32(b[0:], typ)
+	le.PutUint32(b[4:], uint32(len(b)+len(body)))
+	if ptrSize == 8 {
+		le.PutUint64(b[8:], device)
+		le.PutUint64(b[16:], wParam)
+	} else {
+		le.PutUint32(b[8:], uint32(device))
+		le.PutUint32(b[12:], uint32(wParam))
+	}
+
+	return append(b, body...)
+}
+
+func rawMouseBody() []byte {
+	b := make([]byte, 24)
+	le := binary.LittleEndian
+	le.PutUint16(b[0:], MOUSE_MOVE_ABSOLUTE)
+	le.PutUint16(b[4:], RI_MOUSE_WHEEL|RI_MOUSE_LEFT_BUTTON_DOWN)
+	le.PutUint16(b[6:], 0xFF88) // -120
+	le.PutUint32(b[8:], 0x11223344)
+	le.PutUint32(b[12:], 0xFFFFFFFB) // -5
+	le.PutUint32(b[16:], 65535)
+	le.PutUint32(b[20:], 0xCAFE)
+
+	return b
+}
+
+func rawKeyboardBody() []byte {
+	b := make([]byte, 16)
+	le := binary.LittleEndian
+	le.PutUint16(b[0:], 0x1E)
+	le.PutUint16(b[2:], RI_KEY_BREAK|RI_KEY_E0)
+	le.PutUint16(b[6:], 'A')
+	le.PutUint32(b[8:], 0x0101) // WM_KEYUP
+	le.PutUint32(b[12:], 7)
+
+	return b
+}
+
+func rawHIDBody(size, count uint32, data []byte) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint32(b[0:], size)
+	binary.LittleEndian.PutUint32(b[4:], count)
+
+	return append(b, data...)
+}
+
+func TestRawInputParserParse(t *testing.T) {
+	for _, ps := range []int{4, 8} {
+		p := RawInputParser{PtrSize: ps}
+
+		mouse := rawInput(ps, RIM_TYPEMOUSE, 0x1234, RIM_INPUTSINK, rawMouseBody())
+		e, err := p.Parse(mouse)
+		if err != nil {
+			t.Fatalf("PtrSize %d: mouse: %v", ps, err)
+		}
+		wantMouse := &RawMouseEvent{
+			RawInputHeader:   RawInputHeader{RIM_TYPEMOUSE, uint32(len(mouse)), 0x1234, RIM_INPUTSINK},
+			Flags:            MOUSE_MOVE_ABSOLUTE,
+			ButtonFlags:      RI_MOUSE_WHEEL | RI_MOUSE_LEFT_BUTTON_DOWN,
+			ButtonData:       0xFF88,
+			RawButtons:       0x11223344,
+			LastX:            -5,
+			LastY:            65535,
+			ExtraInformation: 0xCAFE,
+		}
+		if !reflect.DeepEqual(e, wantMouse) {
+			t.Errorf("PtrSize %d: mouse = %+v, want %+v", ps, e, wantMouse)
+		}
+		if m := e.(*RawMouseEvent); m.WheelDelta() != -120 || !m.Absolute() {
+			t.Errorf("PtrSize %d: WheelDelta = %d, Absolute = %v", ps, m.WheelDelta(), m.Absolute())
+		}
+
+		keyboard := rawInput(ps, RIM_TYPEKEYBOARD, 0x5678, RIM_INPUT, rawKeyboardBody())
+		e, err = p.Parse(keyboard)
+		if err != nil {
+			t.Fatalf("PtrSize %d: keyboard: %v", ps, err)
+		}
+		wantKeyboard := &RawKeyboardEvent{
+			RawInputHeader:   RawInputHeader{RIM_TYPEKEYBOARD, uint32(len(keyboard)), 0x5678, RIM_INPUT},
+			MakeCode:         0x1E,
+			Flags:            RI_KEY_BREAK | RI_KEY_E0,
+			VKey:             'A',
+			Message:          0x0101,
+			ExtraInformation: 7,
+		}
+		if !reflect.DeepEqual(e, wantKeyboard) {
+			t.Errorf("PtrSize %d: keyboard = %+v, want %+v", ps, e, wantKeyboard)
+		}
+		if !e.(*RawKeyboardEvent).Released() {
+			t.Errorf("PtrSize %d: keyboard not released", ps)
+		}
+
+		hid := rawInput(ps, RIM_TYPEHID, 0x9ABC, RIM_INPUT, rawHIDBody(3, 2, []byte{1, 2, 3, 4, 5, 6}))
+		e, err = p.Parse(hid)
+		if err != nil {
+			t.Fatalf("PtrSize %d: HID: %v", ps, err)
+		}
+		wantHID := &RawHIDEvent{
+			RawInputHeader: RawInputHeader{RIM_TYPEHID, uint32(len(hid)), 0x9ABC, RIM_INPUT},
+			ReportSize:     3,
+			Reports:        [][]byte{{1, 2, 3}, {4, 5, 6}},
+		}
+		if !reflect.DeepEqual(e, wantHID) {
+			t.Errorf("PtrSize %d: HID = %+v, want %+v", ps, e, wantHID)
+		}
+
+		// The reports are copies.
+		hid[len(hid)-1] = 0
+		if e.(*RawHIDEvent).Reports[1][2] != 6 {
+			t.Errorf("PtrSize %d: HID reports share the source", ps)
+		}
+	}
+}
+
+func TestRawInputParserWOW64(t *testing.T) {
+	// A 32-bit process on 64-bit Windows gets 8-byte handles, so its
+	// RAWINPUTHEADER is 24 bytes and the body starts at offset 24.
+	data := rawInput(8, RIM_TYPEKEYBOARD, 0x55667788, RIM_INPUT, rawKeyboardBody())
+
+	if n := (RawInputParser{PtrSize: 8}).HeaderSize(); n != 24 {
+		t.Errorf("HeaderSize = %d, want 24", n)
+	}
+
+	e, err := RawInputParser{PtrSize: 8}.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := e.(*RawKeyboardEvent)
+	if k.MakeCode != 0x1E || k.VKey != 'A' || k.Device != 0x55667788 {
+		t.Errorf("keyboard = %+v", k)
+	}
+
+	// With 4-byte handles, the body of the same data is read from the
+	// middle of the header.
+	e, err = RawInputParser{PtrSize: 4}.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k := e.(*RawKeyboardEvent); k.VKey == 'A' {
+		t.Errorf("PtrSize 4 decoded the WOW64 layout: %+v", k)
+	}
+}
+
+func TestRawInputParserInvalid(t *testing.T) {
+	p := RawInputParser{PtrSize: 8}
+
+	withSize := func(b []byte, size uint32) []byte {
+		binary.LittleEndian.PutUint32(b[4:], size)
+		return b
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"truncated header", rawInput(8, RIM_TYPEMOUSE, 0, 0, nil)[:23]},
+		{"size below header", withSize(rawInput(8, RIM_TYPEMOUSE, 0, 0, rawMouseBody()), 23)},
+		{"size beyond data", withSize(rawInput(8, RIM_TYPEMOUSE, 0, 0, rawMouseBody()), 49)},
+		{"truncated mouse", rawInput(8, RIM_TYPEMOUSE, 0, 0, rawMouseBody()[:23])},
+		{"truncated keyboard", rawInput(8, RIM_TYPEKEYBOARD, 0, 0, rawKeyboardBody()[:15])},
+		{"truncated HID header", rawInput(8, RIM_TYPEHID, 0, 0, rawHIDBody(1, 1, nil)[:7])},
+		{"truncated HID reports", rawInput(8, RIM_TYPEHID, 0, 0, rawHIDBody(3, 2, make([]byte, 5)))},
+		{"HID reports of size 0", rawInput(8, RIM_TYPEHID, 0, 0, rawHIDBody(0, 0xFFFFFFFF, nil))},
+		{"huge HID count", rawInput(8, RIM_TYPEHID, 0, 0, rawHIDBody(0x10000, 0x10000, make([]byte, 16)))},
+		{"unknown type", rawInput(8, 3, 0, 0, rawMouseBody())},
+	}
+
+	for _, tt := range tests {
+		if e, err := p.Parse(tt.data); err != ErrInvalidRawInput {
+			t.Errorf("%s: Parse = %v, %v, want ErrInvalidRawInput", tt.name, e, err)
+		}
+	}
+
+	// Without reports, a size of 0 is fine.
+	e, err := p.Parse(rawInput(8, RIM_TYPEHID, 0, 0, rawHIDBody(0, 0, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := e.(*RawHIDEvent); len(h.Reports) != 0 {
+		t.Errorf("empty HID event has %d reports", len(h.Reports))
+	}
+}
+
+func TestRawInputParserParseBuffer(t *testing.T) {
+	for _, ps := range []int{4, 8} {
+		p := RawInputParser{PtrSize: ps}
+
+		// Blocks are aligned to the pointer size, so the 3-byte HID report
+		// is followed by padding.
+		var data []byte
+		for _, b := range [][]byte{
+			rawInput(ps, RIM_TYPEHID, 1, 0, rawHIDBody(3, 1, []byte{7, 8, 9})),
+			rawInput(ps, RIM_TYPEKEYBOARD, 2, 0, rawKeyboardBody()),
+			rawInput(ps, RIM_TYPEMOUSE, 3, 0, rawMouseBody()),
+		} {
+			data = append(data, b...)
+			for len(data)%ps != 0 {
+				data = append(data, 0xEE)
+			}
+		}
+
+		events, err := p.ParseBuffer(data, -1)
+		if err != nil {
+			t.Fatalf("PtrSize %d: %v", ps, err)
+		}
+		if len(events) != 3 {
+			t.Fatalf("PtrSize %d: %d events, want 3", ps, len(events))
+		}
+		for i, e := range events {
+			if h := e.RawHeader(); h.Device != HANDLE(i+1) {
+				t.Errorf("PtrSize %d: event %d from device %d", ps, i, h.Device)
+			}
+		}
+
+		if events, err := p.ParseBuffer(data, 2); err != nil || len(events) != 2 {
+			t.Errorf("PtrSize %d: ParseBuffer(2) = %d events, %v", ps, len(events), err)
+		}
+		if _, err := p.ParseBuffer(data, 4); err != ErrInvalidRawInput {
+			t.Errorf("PtrSize %d: ParseBuffer(4) err = %v, want ErrInvalidRawInput", ps, err)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// RegionFromHRGN returns the rectangles making up hrgn.
+func RegionFromHRGN(hrgn HRGN) (*Region, error) {
+	size := GetRegionData(hrgn, 0, nil)
+	if size == 0 {
+		return nil, errors.New("GetRegionData failed")
+	}
+
+	buf := make([]byte, size)
+	if GetRegionData(hrgn, size, (*RGNDATA)(unsafe.Pointer(&buf[0]))) == 0 {
+		return nil, errors.New("GetRegionData failed")
+	}
+
+	return UnmarshalRGNDATA(buf)
+}
+
+// HRGN creates a GDI region from r. The caller owns the returned handle and
+// must release it with DeleteObject unless it is passed to SetWindowRgn.
+func (r *Region) HRGN() (HRGN, error) {
+	if len(r.Rects) == 0 {
+		return CreateRectRgn(0, 0, 0, 0), nil
+	}
+
+	data := r.MarshalRGNDATA()
+
+	hrgn := ExtCreateRegion(nil, uint32(len(data)), (*RGNDATA)(unsafe.Pointer(&data[0])))
+	if hrgn == 0 {
+		return 0, errors.New("ExtCreateRegion failed")
+	}
+
+	return hrgn, nil
+}
+
+// RegionBuilder composes a GDI region from simple shapes using CombineRgn.
+// The first error encountered is kept and reported by HRGN.
+type RegionBuilder struct {
+	hrgn HRGN
+	err  error
+}
+
+// NewRegionBuilder returns a builder holding an empty region.
+func NewRegionBuilder() *RegionBuilder {
+	b := new(RegionBuilder)
+
+	if b.hrgn = CreateRectRgn(0, 0, 0, 0); b.hrgn == 0 {
+		b.err = errors.New("CreateRectRgn failed")
+	}
+
+	return b
+}
+
+func (b *RegionBuilder) combine(hrgn HRGN, mode int32) *RegionBuilder {
+	if b.err != nil {
+		if hrgn != 0 {
+			DeleteObject(HGDIOBJ(hrgn))
+		}
+		return b
+	}
+
+	if hrgn == 0 {
+		b.err = errors.New("failed to create region")
+		return b
+	}
+	defer DeleteObject(HGDIOBJ(hrgn))
+
+	if CombineRgn(b.hrgn, b.hrgn, hrgn, mode) == REGIONERROR {
+		b.err = errors.New("CombineRgn failed")
+	}
+
+	return b
+}
+
+// Union adds hrgn to the region. hrgn is deleted.
+func (b *RegionBuilder) Union(hrgn HRGN) *RegionBuilder {
+	return b.combine(hrgn, RGN_OR)
+}
+
+// Intersect intersects the region with hrgn. hrgn is deleted.
+func (b *RegionBuilder) Intersect(hrgn HRGN) *RegionBuilder {
+	return b.combine(hrgn, RGN_AND)
+}
+
+// Subtract removes hrgn from the region. hrgn is deleted.
+func (b *RegionBuilder) Subtract(hrgn HRGN) *RegionBuilder {
+	return b.combine(hrgn, RGN_DIFF)
+}
+
+// Xor combines the region with hrgn, keeping only the non-overlapping parts.
+// hrgn is deleted.
+func (b *RegionBuilder) Xor(hrgn HRGN) *RegionBuilder {
+	return b.combine(hrgn, RGN_XOR)
+}
+
+func (b *RegionBuilder) Rect(rc RECT) *RegionBuilder {
+	return b.Union(CreateRectRgn(rc.Left, rc.Top, rc.Right, rc.Bottom))
+}
+
+func (b *RegionBuilder) RoundRect(rc RECT, ellipseWidth, ellipseHeight int32) *RegionBuilder {
+	return b.Union(CreateRoundRectRgn(rc.Left, rc.Top, rc.Right, rc.Bottom, ellipseWidth, ellipseHeight))
+}
+
+func (b *RegionBuilder) Ellipse(rc RECT) *RegionBuilder {
+	return b.Union(CreateEllipticRgn(rc.Left, rc.Top, rc.Right, rc.Bottom))
+}
+
+// Polygon adds a polygon filled using fillMode, either ALTERNATE or WINDING.
+func (b *RegionBuilder) Polygon(points []POINT, fillMode int32) *RegionBuilder {
+	if len(points) < 3 {
+		return b
+	}
+
+	return b.Union(CreatePolygonRgn(&points[0], int32(len(points)), fillMode))
+}
+
+// PolyPolygon adds a series of possibly overlapping polygons.
+func (b *RegionBuilder) PolyPolygon(polygons [][]POINT, fillMode int32) *RegionBuilder {
+	var points []POINT
+	var counts []int32
+
+	for _, polygon := range polygons {
+		if len(polygon) < 3 {
+			continue
+		}
+
+		points = append(points, polygon...)
+		counts = append(counts, int32(len(polygon)))
+	}
+
+	if len(counts) == 0 {
+		return b
+	}
+
+	return b.Union(CreatePolyPolygonRgn(&points[0], &counts[0], int32(len(counts)), fillMode))
+}
+
+// Region adds the rectangles of r.
+func (b *RegionBuilder) Region(r *Region) *RegionBuilder {
+	hrgn, err := r.HRGN()
+	if err != nil && b.err == nil {
+		b.err = err
+	}
+
+	return b.Union(hrgn)
+}
+
+// Path adds the region enclosed by the path that draw records on hdc
+// between BeginPath and EndPath.
+func (b *RegionBuilder) Path(hdc HDC, draw func(hdc HDC)) *RegionBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if !BeginPath(hdc) {
+		b.err = errors.New("BeginPath failed")
+		return b
+	}
+
+	draw(hdc)
+
+	if !EndPath(hdc) {
+		AbortPath(hdc)
+		b.err = errors.New("EndPath failed")
+		return b
+	}
+
+	return b.Union(PathToRegion(hdc))
+}
+
+// Offset moves the region built so far by dx, dy.
+func (b *RegionBuilder) Offset(dx, dy int32) *RegionBuilder {
+	if b.err == nil && OffsetRgn(b.hrgn, dx, dy) == REGIONERROR {
+		b.err = errors.New("OffsetRgn failed")
+	}
+
+	return b
+}
+
+// HRGN returns the built region and transfers its ownership to the caller.
+func (b *RegionBuilder) HRGN() (HRGN, error) {
+	if b.err != nil {
+		if b.hrgn != 0 {
+			DeleteObject(HGDIOBJ(b.hrgn))
+			b.hrgn = 0
+		}
+		return 0, b.err
+	}
+
+	hrgn := b.hrgn
+	b.hrgn = 0
+
+	return hrgn, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// RGNDATAHEADER types
+const (
+	RDH_RECTANGLES = 1
+)
+
+type RGNDATAHEADER struct {
+	DwSize   uint32
+	IType    uint32
+	NCount   uint32
+	NRgnSize uint32
+	RcBound  RECT
+}
+
+var ErrInvalidRegionData = errors.New("invalid RGNDATA")
+
+const sizeofRGNDATAHEADER = 32
+
+// Region is a region described by a list of rectangles, the same form GDI
+// uses in RGNDATA. Rects are in the order GDI reports them: sorted top to
+// bottom, then left to right, and never overlapping.
+type Region struct {
+	Rects []RECT
+}
+
+// IsEmpty reports whether the region contains no rectangles.
+func (r *Region) IsEmpty() bool {
+	return len(r.Rects) == 0
+}
+
+// Bounds returns the smallest rectangle enclosing the region.
+func (r *Region) Bounds() RECT {
+	if len(r.Rects) == 0 {
+		return RECT{}
+	}
+
+	b := r.Rects[0]
+	for _, rc := range r.Rects[1:] {
+		if rc.Left < b.Left {
+			b.Left = rc.Left
+		}
+		if rc.Top < b.Top {
+			b.Top = rc.Top
+		}
+		if rc.Right > b.Right {
+			b.Right = rc.Right
+		}
+		if rc.Bottom > b.Bottom {
+			b.Bottom = rc.Bottom
+		}
+	}
+
+	return b
+}
+
+// Contains reports whether the point lies inside the region. Like GDI,
+// the right and bottom edges of each rectangle are exclusive.
+func (r *Region) Contains(x, y int32) bool {
+	for _, rc := range r.Rects {
+		if x >= rc.Left && x < rc.Right && y >= rc.Top && y < rc.Bottom {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Offset moves the region by dx, dy.
+func (r *Region) Offset(dx, dy int32) {
+	for i := range r.Rects {
+		r.Rects[i].Left += dx
+		r.Rects[i].Top += dy
+		r.Rects[i].Right += dx
+		r.Rects[i].Bottom += dy
+	}
+}
+
+// MarshalRGNDATA encodes the region as an RDH_RECTANGLES RGNDATA blob, as
+// accepted by ExtCreateRegion.
+func (r *Region) MarshalRGNDATA() []byte {
+	var buf bytes.Buffer
+	buf.Grow(sizeofRGNDATAHEADER + len(r.Rects)*16)
+
+	hdr := RGNDATAHEADER{
+		DwSize:   sizeofRGNDATAHEADER,
+		IType:    RDH_RECTANGLES,
+		NCount:   uint32(len(r.Rects)),
+		NRgnSize: uint32(len(r.Rects) * 16),
+		RcBound:  r.Bounds(),
+	}
+
+	binary.Write(&buf, binary.LittleEndian, &hdr)
+	binary.Write(&buf, binary.LittleEndian, r.Rects)
+
+	return buf.Bytes()
+}
+
+// UnmarshalRGNDATA decodes an RGNDATA blob as returned by GetRegionData.
+func UnmarshalRGNDATA(data []byte) (*Region, error) {
+	if len(data) < sizeofRGNDATAHEADER {
+		return nil, ErrInvalidRegionData
+	}
+
+	var hdr RGNDATAHEADER
+	if err := binary.Read(bytes.NewReader(data[:sizeofRGNDATAHEADER]), binary.LittleEndian, &hdr); err != nil {
+		return nil, err
+	}
+
+	if hdr.DwSize < sizeofRGNDATAHEADER || hdr.IType != RDH_RECTANGLES {
+		return nil, ErrInvalidRegionData
+	}
+
+	if uint64(hdr.DwSize) > uint64(len(data)) {
+		return nil, ErrInvalidRegionData
+	}
+
+	data = data[hdr.DwSize:]
+	if uint64(len(data)) < uint64(hdr.NCount)*16 {
+		return nil, ErrInvalidRegionData
+	}
+
+	rects := make([]RECT, hdr.NCount)
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, rects); err != nil {
+		return nil, err
+	}
+
+	return &Region{Rects: rects}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestRGNDATARoundTrip(t *testing.T) {
+	regions := []*Region{
+		{},
+		{Rects: []RECT{{0, 0, 10, 10}}},
+		{Rects: []RECT{{-5, -5, 5, 0}, {-5, 0, 20, 3}, {10, 3, 20, 8}}},
+	}
+
+	for _, r := range regions {
+		data := r.MarshalRGNDATA()
+
+		if want := sizeofRGNDATAHEADER + 16*len(r.Rects); len(data) != want {
+			t.Errorf("%v: len = %d, want %d", r.Rects, len(data), want)
+		}
+		if bound := r.Bounds(); int32(binary.LittleEndian.Uint32(data[16:])) != bound.Left ||
+			int32(binary.LittleEndian.Uint32(data[28:])) != bound.Bottom {
+			t.Errorf("%v: rcBound does not match Bounds %v", r.Rects, bound)
+		}
+
+		got, err := UnmarshalRGNDATA(data)
+		if err != nil {
+			t.Fatalf("%v: UnmarshalRGNDATA: %v", r.Rects, err)
+		}
+
+		if len(got.Rects) != len(r.Rects) || len(r.Rects) > 0 && !reflect.DeepEqual(got.Rects, r.Rects) {
+			t.Errorf("UnmarshalRGNDATA = %v, want %v", got.Rects, r.Rects)
+		}
+	}
+}
+
+func TestUnmarshalRGNDATALargerHeader(t *testing.T) {
+	// The rectangles follow dwSize bytes of header.
+	r := &Region{Rects: []RECT{{1, 2, 3, 4}}}
+	data := r.MarshalRGNDATA()
+
+	padded := append(append(append([]byte(nil), data[:sizeofRGNDATAHEADER]...), 0, 0, 0, 0), data[sizeofRGNDATAHEADER:]...)
+	binary.LittleEndian.PutUint32(padded, sizeofRGNDATAHEADER+4)
+
+	got, err := UnmarshalRGNDATA(padded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Rects, r.Rects) {
+		t.Errorf("UnmarshalRGNDATA = %v, want %v", got.Rects, r.Rects)
+	}
+}
+
+func TestUnmarshalRGNDATAInvalid(t *testing.T) {
+	valid := (&Region{Rects: []RECT{{0, 0, 1, 1}, {0, 1, 2, 2}}}).MarshalRGNDATA()
+
+	modified := func(offset int, v uint32) []byte {
+		b := append([]byte(nil), valid...)
+		binary.LittleEndian.PutUint32(b[offset:], v)
+		return b
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"truncated header", valid[:sizeofRGNDATAHEADER-1]},
+		{"truncated rectangles", valid[:len(valid)-1]},
+		{"dwSize too small", modified(0, sizeofRGNDATAHEADER-1)},
+		{"dwSize beyond data", modified(0, uint32(len(valid))+1)},
+		{"huge dwSize", modified(0, 0xFFFFFFFF)},
+		{"iType", modified(4, 2)},
+		{"nCount beyond data", modified(8, 3)},
+		{"huge nCount", modified(8, 0xFFFFFFFF)},
+	}
+
+	for _, tt := range tests {
+		if _, err := UnmarshalRGNDATA(tt.data); err != ErrInvalidRegionData {
+			t.Errorf("%s: err = %v, want ErrInvalidRegionData", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+// ScrollBar commands
+const (
+	SB_LINEUP        = 0
+	SB_LINELEFT      = 0
+	SB_LINEDOWN      = 1
+	SB_LINERIGHT     = 1
+	SB_PAGEUP        = 2
+	SB_PAGELEFT      = 2
+	SB_PAGEDOWN      = 3
+	SB_PAGERIGHT     = 3
+	SB_THUMBPOSITION = 4
+	SB_THUMBTRACK    = 5
+	SB_TOP           = 6
+	SB_LEFT          = 6
+	SB_BOTTOM        = 7
+	SB_RIGHT         = 7
+	SB_ENDSCROLL     = 8
+)
+
+const (
+	WHEEL_DELTA      = 120
+	WHEEL_PAGESCROLL = 0xFFFFFFFF // SPI_GETWHEELSCROLLLINES
+)
+
+// ScrollAxis is the scroll state of one direction of a document shown in a
+// smaller viewport. Positions are offsets of the viewport into the
+// document. ScrollAxis does not call into Windows.
+type ScrollAxis struct {
+	document int32
+	viewport int32
+	line     int32
+	pos      int32
+	wheel    int64 // accumulated wheel distance in pixels * WHEEL_DELTA
+}
+
+// Document returns the size of the document.
+func (a *ScrollAxis) Document() int32 {
+	return a.document
+}
+
+// Viewport returns the size of the visible part of the document.
+func (a *ScrollAxis) Viewport() int32 {
+	return a.viewport
+}
+
+// Line returns the distance scrolled by SB_LINEUP and SB_LINEDOWN, which
+// is 1 unless set with SetLine.
+func (a *ScrollAxis) Line() int32 {
+	if a.line < 1 {
+		return 1
+	}
+
+	return a.line
+}
+
+// Pos returns the current position.
+func (a *ScrollAxis) Pos() int32 {
+	return a.pos
+}
+
+// Max returns the largest position, at which the end of the document is
+// visible. It is 0 if the document fits the viewport.
+func (a *ScrollAxis) Max() int32 {
+	if a.document <= a.viewport {
+		return 0
+	}
+
+	return a.document - a.viewport
+}
+
+// SetSize sets the document and viewport size and clamps the position.
+func (a *ScrollAxis) SetSize(document, viewport int32) {
+	if document < 0 {
+		document = 0
+	}
+	if viewport < 0 {
+		viewport = 0
+	}
+
+	a.document = document
+	a.viewport = viewport
+	a.SetPos(a.pos)
+}
+
+// SetLine sets the distance scrolled by SB_LINEUP and SB_LINEDOWN.
+func (a *ScrollAxis) SetLine(line int32) {
+	if line < 1 {
+		line = 1
+	}
+
+	a.line = line
+}
+
+// SetPos sets the position, clamped to between 0 and Max.
+func (a *ScrollAxis) SetPos(pos int32) {
+	if max := a.Max(); pos > max {
+		pos = max
+	}
+	if pos < 0 {
+		pos = 0
+	}
+
+	a.pos = pos
+}
+
+// Page returns the distance scrolled by SB_PAGEUP and SB_PAGEDOWN, which
+// is the viewport size, but at least a line.
+func (a *ScrollAxis) Page() int32 {
+	if line := a.Line(); a.viewport < line {
+		return line
+	}
+
+	return a.viewport
+}
+
+// Request returns the position a WM_HSCROLL or WM_VSCROLL request asks for.
+// trackPos is the 32-bit thumb position of SB_THUMBPOSITION and
+// SB_THUMBTRACK. ok is false for requests that do not scroll, such as
+// SB_ENDSCROLL.
+func (a *ScrollAxis) Request(request uint16, trackPos int32) (pos int32, ok bool) {
+	switch request {
+	case SB_LINEUP:
+		pos = a.pos - a.Line()
+
+	case SB_LINEDOWN:
+		pos = a.pos + a.Line()
+
+	case SB_PAGEUP:
+		pos = a.pos - a.Page()
+
+	case SB_PAGEDOWN:
+		pos = a.pos + a.Page()
+
+	case SB_THUMBPOSITION, SB_THUMBTRACK:
+		pos = trackPos
+
+	case SB_TOP:
+		pos = 0
+
+	case SB_BOTTOM:
+		pos = a.Max()
+
+	default:
+		return a.pos, false
+	}
+
+	return a.clamp(pos), true
+}
+
+// Wheel returns the position after rotating the wheel by delta, positive
+// towards the start of the document, with lines lines per WHEEL_DELTA, or a
+// page for WHEEL_PAGESCROLL. Deltas of high-resolution wheels are
+// accumulated, so that every fraction of WHEEL_DELTA scrolls by the
+// corresponding distance, and none of it is lost.
+func (a *ScrollAxis) Wheel(delta int32, lines uint32) int32 {
+	var step int64
+	if lines == WHEEL_PAGESCROLL {
+		step = int64(a.Page())
+	} else {
+		step = int64(lines) * int64(a.Line())
+	}
+
+	if step == 0 || delta == 0 {
+		return a.pos
+	}
+
+	// Reversing direction discards what is left of the previous one.
+	if (delta > 0) != (a.wheel > 0) {
+		a.wheel = 0
+	}
+
+	a.wheel += int64(delta) * step
+
+	dist := a.wheel / WHEEL_DELTA
+	a.wheel -= dist * WHEEL_DELTA
+
+	// Hitting either end discards the rest too.
+	switch pos := int64(a.pos) - dist; {
+	case pos < 0:
+		a.wheel = 0
+		return 0
+
+	case pos > int64(a.Max()):
+		a.wheel = 0
+		return a.Max()
+
+	default:
+		return int32(pos)
+	}
+}
+
+func (a *ScrollAxis) clamp(pos int32) int32 {
+	if max := a.Max(); pos > max {
+		return max
+	}
+	if pos < 0 {
+		return 0
+	}
+
+	return pos
+}
@@ -0,0 +1,174 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"testing"
+)
+
+func newTestScrollAxis(document, viewport, line, pos int32) *ScrollAxis {
+	a := new(ScrollAxis)
+	a.SetSize(document, viewport)
+	a.SetLine(line)
+	a.SetPos(pos)
+
+	return a
+}
+
+func TestScrollAxisRequest(t *testing.T) {
+	// The document is larger than the 16 bits of the thumb position in
+	// WM_VSCROLL, so SB_THUMBTRACK gets the 32-bit one of GetScrollInfo.
+	const max = 200000 - 100
+
+	tests := []struct {
+		request  uint16
+		trackPos int32
+		pos      int32
+		ok       bool
+	}{
+		{SB_LINEUP, 0, 490, true},
+		{SB_LINELEFT, 0, 490, true},
+		{SB_LINEDOWN, 0, 510, true},
+		{SB_LINERIGHT, 0, 510, true},
+		{SB_PAGEUP, 0, 400, true},
+		{SB_PAGELEFT, 0, 400, true},
+		{SB_PAGEDOWN, 0, 600, true},
+		{SB_PAGERIGHT, 0, 600, true},
+		{SB_THUMBPOSITION, 123, 123, true},
+		{SB_THUMBPOSITION, 70000, 70000, true},
+		{SB_THUMBTRACK, 70000, 70000, true},
+		{SB_THUMBTRACK, 0x7FFFFFFF, max, true},
+		{SB_THUMBTRACK, -1, 0, true},
+		{SB_TOP, 0, 0, true},
+		{SB_LEFT, 0, 0, true},
+		{SB_BOTTOM, 0, max, true},
+		{SB_RIGHT, 0, max, true},
+		{SB_ENDSCROLL, 0, 500, false},
+		{SB_ENDSCROLL + 1, 0, 500, false},
+	}
+
+	for _, tt := range tests {
+		a := newTestScrollAxis(200000, 100, 10, 500)
+
+		if pos, ok := a.Request(tt.request, tt.trackPos); pos != tt.pos || ok != tt.ok {
+			t.Errorf("Request(%d, %d) = %d, %v, want %d, %v", tt.request, tt.trackPos, pos, ok, tt.pos, tt.ok)
+		}
+		if a.Pos() != 500 {
+			t.Errorf("Request(%d, %d) changed the position to %d", tt.request, tt.trackPos, a.Pos())
+		}
+	}
+
+	// Requests are clamped at both ends.
+	a := newTestScrollAxis(1000, 100, 10, 5)
+	if pos, _ := a.Request(SB_PAGEUP, 0); pos != 0 {
+		t.Errorf("SB_PAGEUP near the start = %d, want 0", pos)
+	}
+	if pos, _ := a.Request(SB_LINEUP, 0); pos != 0 {
+		t.Errorf("SB_LINEUP near the start = %d, want 0", pos)
+	}
+
+	a.SetPos(a.Max() - 5)
+	if pos, _ := a.Request(SB_LINEDOWN, 0); pos != 900 {
+		t.Errorf("SB_LINEDOWN near the end = %d, want 900", pos)
+	}
+
+	// A document that fits the viewport does not scroll.
+	a = newTestScrollAxis(50, 100, 10, 0)
+	if pos, _ := a.Request(SB_BOTTOM, 0); pos != 0 {
+		t.Errorf("SB_BOTTOM of a small document = %d, want 0", pos)
+	}
+}
+
+func TestScrollAxisPage(t *testing.T) {
+	if p := newTestScrollAxis(1000, 100, 10, 0).Page(); p != 100 {
+		t.Errorf("Page = %d, want 100", p)
+	}
+
+	// The page is at least a line.
+	if p := newTestScrollAxis(1000, 4, 10, 0).Page(); p != 10 {
+		t.Errorf("Page of a small viewport = %d, want 10", p)
+	}
+}
+
+func TestScrollAxisWheel(t *testing.T) {
+	// 3 lines of 10 pixels per WHEEL_DELTA.
+	a := newTestScrollAxis(1000, 100, 10, 500)
+
+	wheel := func(delta int32, lines uint32) int32 {
+		a.SetPos(a.Wheel(delta, lines))
+		return a.Pos()
+	}
+
+	steps := []struct {
+		name  string
+		delta int32
+		lines uint32
+		pos   int32
+	}{
+		{"notch up", WHEEL_DELTA, 3, 470},
+		{"notch down", -WHEEL_DELTA, 3, 500},
+		{"two notches", -2 * WHEEL_DELTA, 3, 560},
+
+		// A third of WHEEL_DELTA scrolls a line.
+		{"third", 40, 3, 550},
+		{"third", 40, 3, 540},
+		{"third", 40, 3, 530},
+
+		// Fractions of a pixel accumulate.
+		{"1/120", 1, 3, 530},
+		{"2/120", 1, 3, 530},
+		{"3/120", 1, 3, 530},
+		{"4/120", 1, 3, 529},
+
+		// Reversing the direction discards the 90 accumulated, so that
+		// 30 + 90 make the next pixel.
+		{"3/120", 3, 3, 529},
+		{"reverse", -1, 3, 529},
+		{"reverse", -3, 3, 530},
+
+		{"page down", -WHEEL_DELTA, WHEEL_PAGESCROLL, 630},
+		{"half page up", WHEEL_DELTA / 2, WHEEL_PAGESCROLL, 580},
+
+		{"no lines", WHEEL_DELTA, 0, 580},
+		{"no delta", 0, 3, 580},
+
+		{"past the start", 100 * WHEEL_DELTA, 3, 0},
+		{"at the start", WHEEL_DELTA, 3, 0},
+		{"down from the start", -WHEEL_DELTA, 3, 30},
+		{"past the end", -100 * WHEEL_DELTA, 3, 900},
+		{"at the end", -WHEEL_DELTA, 3, 900},
+		{"up from the end", WHEEL_DELTA, 3, 870},
+	}
+
+	for i, s := range steps {
+		if pos := wheel(s.delta, s.lines); pos != s.pos {
+			t.Fatalf("step %d, %s: Wheel(%d, %d) = %d, want %d", i, s.name, s.delta, s.lines, pos, s.pos)
+		}
+	}
+}
+
+func TestScrollAxisWheelClampDiscards(t *testing.T) {
+	// Hitting an end discards the rest of the wheel distance, so that
+	// scrolling back starts afresh.
+	a := newTestScrollAxis(1000, 100, 10, 0)
+
+	a.SetPos(a.Wheel(5, 3)) // 150 of 120 for a pixel
+	if a.Pos() != 0 || a.wheel != 0 {
+		t.Errorf("at the start: pos = %d, wheel = %d, want 0, 0", a.Pos(), a.wheel)
+	}
+
+	a.SetPos(a.Max())
+	a.SetPos(a.Wheel(-5, 3))
+	if a.Pos() != a.Max() || a.wheel != 0 {
+		t.Errorf("at the end: pos = %d, wheel = %d, want %d, 0", a.Pos(), a.wheel, a.Max())
+	}
+
+	// Without hitting an end, the rest is kept.
+	a.SetPos(500)
+	a.SetPos(a.Wheel(5, 3))
+	if a.Pos() != 499 || a.wheel != 30 {
+		t.Errorf("in between: pos = %d, wheel = %d, want 499, 30", a.Pos(), a.wheel)
+	}
+}
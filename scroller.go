@@ -0,0 +1,273 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"unsafe"
+)
+
+// defaultScrollLine is the default line size of a Scroller in pixels.
+const defaultScrollLine = 16
+
+// Scroller scrolls a document, e.g. a custom drawn canvas, in the client
+// area of a window with its standard scroll bars. It handles WM_HSCROLL,
+// WM_VSCROLL, WM_MOUSEWHEEL and WM_MOUSEHWHEEL, keeps the viewport size in
+// sync with the client area, updates the scroll bars and moves the client
+// area contents with ScrollWindowEx, so only the uncovered parts need
+// painting. All methods must be called on the thread of the window.
+type Scroller struct {
+	// ScrollFlags are the SW_* flags passed to ScrollWindowEx,
+	// SW_INVALIDATE | SW_ERASE by default.
+	ScrollFlags uint32
+
+	// KeepScrollBars shows the scroll bars disabled, instead of hiding
+	// them, when the document fits the viewport.
+	KeepScrollBars bool
+
+	// OnScroll, if not nil, is called after the offset changed.
+	OnScroll func(offset POINT)
+
+	w    *Window
+	horz ScrollAxis
+	vert ScrollAxis
+}
+
+// NewScroller returns a Scroller for the client area of w with an empty
+// document.
+func NewScroller(w *Window) *Scroller {
+	s := &Scroller{
+		ScrollFlags: SW_INVALIDATE | SW_ERASE,
+		w:           w,
+	}
+
+	s.horz.SetLine(defaultScrollLine)
+	s.vert.SetLine(defaultScrollLine)
+
+	var rc RECT
+	if hwnd := w.HWND(); hwnd != 0 && GetClientRect(hwnd, &rc) {
+		s.horz.SetSize(0, rc.Right-rc.Left)
+		s.vert.SetSize(0, rc.Bottom-rc.Top)
+	}
+
+	w.Handle(WM_SIZE, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		m := CrackSizeMsg(wParam, lParam)
+		if m.Type == SIZE_MINIMIZED {
+			return 0, false
+		}
+
+		old := s.Offset()
+
+		s.horz.SetSize(s.horz.document, m.Width)
+		s.vert.SetSize(s.vert.document, m.Height)
+
+		s.updateScrollBars()
+		s.scrolled(old)
+
+		return 0, false
+	})
+
+	scroll := func(bar int32, a *ScrollAxis) MessageHandler {
+		return func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+			m := CrackScrollMsg(wParam, lParam)
+			if m.ScrollBar != 0 {
+				return 0, false
+			}
+
+			// The position in the message is limited to 16 bits.
+			trackPos := int32(m.Pos)
+			if m.Request == SB_THUMBTRACK || m.Request == SB_THUMBPOSITION {
+				si := SCROLLINFO{FMask: SIF_TRACKPOS}
+				si.CbSize = uint32(unsafe.Sizeof(si))
+				if GetScrollInfo(w.HWND(), bar, &si) {
+					trackPos = si.NTrackPos
+				}
+			}
+
+			if pos, ok := a.Request(m.Request, trackPos); ok {
+				s.setPos(a, pos)
+			}
+
+			return 0, true
+		}
+	}
+
+	w.Handle(WM_HSCROLL, scroll(SB_HORZ, &s.horz))
+	w.Handle(WM_VSCROLL, scroll(SB_VERT, &s.vert))
+
+	wheel := func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		m := CrackMouseWheelMsg(wParam, lParam)
+
+		// Ctrl+wheel usually zooms, leave it to other handlers.
+		if m.Keys&MK_CONTROL != 0 {
+			return 0, false
+		}
+
+		a, action, delta := &s.vert, uint32(SPI_GETWHEELSCROLLLINES), int32(m.Delta)
+		if msg == WM_MOUSEHWHEEL || m.Keys&MK_SHIFT != 0 {
+			a, action = &s.horz, SPI_GETWHEELSCROLLCHARS
+		}
+		if msg == WM_MOUSEHWHEEL {
+			// Tilting right scrolls towards the end.
+			delta = -delta
+		}
+
+		// Let the parent scroll if there is nothing to scroll here.
+		if a.Max() == 0 {
+			return 0, false
+		}
+
+		lines := uint32(3)
+		SystemParametersInfo(action, 0, unsafe.Pointer(&lines), 0)
+
+		s.setPos(a, a.Wheel(delta, lines))
+
+		return 0, true
+	}
+
+	w.Handle(WM_MOUSEWHEEL, wheel)
+	w.Handle(WM_MOUSEHWHEEL, wheel)
+
+	return s
+}
+
+// Offset returns the position of the viewport in the document, i.e. the
+// document coordinates of the top left corner of the client area.
+func (s *Scroller) Offset() POINT {
+	return POINT{s.horz.pos, s.vert.pos}
+}
+
+// DocumentSize returns the size of the document.
+func (s *Scroller) DocumentSize() SIZE {
+	return SIZE{s.horz.document, s.vert.document}
+}
+
+// ViewportSize returns the size of the visible part of the document, which
+// is the size of the client area.
+func (s *Scroller) ViewportSize() SIZE {
+	return SIZE{s.horz.viewport, s.vert.viewport}
+}
+
+// Horz returns the horizontal scroll state.
+func (s *Scroller) Horz() ScrollAxis {
+	return s.horz
+}
+
+// Vert returns the vertical scroll state.
+func (s *Scroller) Vert() ScrollAxis {
+	return s.vert
+}
+
+// SetDocumentSize sets the size of the document, scrolling back if the
+// offset is now past its end. A size of 0 disables scrolling in that
+// direction and hides its scroll bar, even with KeepScrollBars.
+func (s *Scroller) SetDocumentSize(size SIZE) {
+	old := s.Offset()
+
+	s.horz.SetSize(size.CX, s.horz.viewport)
+	s.vert.SetSize(size.CY, s.vert.viewport)
+
+	s.updateScrollBars()
+	s.scrolled(old)
+}
+
+// SetLineSize sets the distances scrolled per line by the scroll bar arrows
+// and the mouse wheel.
+func (s *Scroller) SetLineSize(size SIZE) {
+	s.horz.SetLine(size.CX)
+	s.vert.SetLine(size.CY)
+}
+
+// ScrollTo scrolls to offset, clamped to the document.
+func (s *Scroller) ScrollTo(offset POINT) {
+	old := s.Offset()
+
+	s.horz.SetPos(offset.X)
+	s.vert.SetPos(offset.Y)
+
+	s.updateScrollBars()
+	s.scrolled(old)
+}
+
+// ScrollBy scrolls by dx and dy, clamped to the document.
+func (s *Scroller) ScrollBy(dx, dy int32) {
+	s.ScrollTo(POINT{s.horz.pos + dx, s.vert.pos + dy})
+}
+
+// EnsureVisible scrolls as little as possible to make rc, in document
+// coordinates, visible. If rc is larger than the viewport, its top left
+// corner is made visible.
+func (s *Scroller) EnsureVisible(rc RECT) {
+	visible := func(a *ScrollAxis, lo, hi int32) int32 {
+		switch {
+		case hi-lo > a.viewport || lo < a.pos:
+			return lo
+
+		case hi > a.pos+a.viewport:
+			return hi - a.viewport
+		}
+
+		return a.pos
+	}
+
+	s.ScrollTo(POINT{visible(&s.horz, rc.Left, rc.Right), visible(&s.vert, rc.Top, rc.Bottom)})
+}
+
+func (s *Scroller) setPos(a *ScrollAxis, pos int32) {
+	old := s.Offset()
+
+	a.SetPos(pos)
+
+	s.updateScrollBars()
+	s.scrolled(old)
+}
+
+func (s *Scroller) updateScrollBars() {
+	hwnd := s.w.HWND()
+	if hwnd == 0 {
+		return
+	}
+
+	for _, bar := range []struct {
+		id int32
+		a  *ScrollAxis
+	}{{SB_HORZ, &s.horz}, {SB_VERT, &s.vert}} {
+		if bar.a.document == 0 {
+			ShowScrollBar(hwnd, bar.id, false)
+			continue
+		}
+
+		si := SCROLLINFO{
+			FMask: SIF_RANGE | SIF_PAGE | SIF_POS,
+			NMax:  bar.a.document - 1,
+			NPage: uint32(bar.a.viewport),
+			NPos:  bar.a.pos,
+		}
+		si.CbSize = uint32(unsafe.Sizeof(si))
+		if s.KeepScrollBars {
+			si.FMask |= SIF_DISABLENOSCROLL
+		}
+
+		SetScrollInfo(hwnd, bar.id, &si, true)
+	}
+}
+
+// scrolled moves the client area contents from offset old to the current
+// one.
+func (s *Scroller) scrolled(old POINT) {
+	offset := s.Offset()
+	if offset == old {
+		return
+	}
+
+	if hwnd := s.w.HWND(); hwnd != 0 {
+		ScrollWindowEx(hwnd, old.X-offset.X, old.Y-offset.Y, nil, nil, 0, nil, s.ScrollFlags)
+	}
+
+	if s.OnScroll != nil {
+		s.OnScroll(offset)
+	}
+}
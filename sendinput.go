@@ -0,0 +1,49 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// NewInputBuilder returns an empty InputBuilder for the current virtual
+// desktop.
+func NewInputBuilder() *InputBuilder {
+	x := GetSystemMetrics(SM_XVIRTUALSCREEN)
+	y := GetSystemMetrics(SM_YVIRTUALSCREEN)
+
+	return &InputBuilder{
+		Desktop: RECT{
+			Left:   x,
+			Top:    y,
+			Right:  x + GetSystemMetrics(SM_CXVIRTUALSCREEN),
+			Bottom: y + GetSystemMetrics(SM_CYVIRTUALSCREEN),
+		},
+	}
+}
+
+// Send injects the batch with one SendInput call and empties it. It returns
+// the number of records injected, which is less than Len if the input was
+// blocked, e.g. by UIPI.
+func (b *InputBuilder) Send() (int, error) {
+	if len(b.inputs) == 0 {
+		return 0, nil
+	}
+
+	buf := b.marshal()
+
+	n := SendInput(uint32(len(buf)), unsafe.Pointer(&buf[0]), int32(unsafe.Sizeof(buf[0])))
+
+	b.Reset()
+
+	if int(n) != len(buf) {
+		return int(n), errors.New("SendInput failed")
+	}
+
+	return int(n), nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+type MONITOR_DPI_TYPE int32
+
+// MONITOR_DPI_TYPE values
+const (
+	MDT_EFFECTIVE_DPI MONITOR_DPI_TYPE = 0
+	MDT_ANGULAR_DPI   MONITOR_DPI_TYPE = 1
+	MDT_RAW_DPI       MONITOR_DPI_TYPE = 2
+	MDT_DEFAULT       MONITOR_DPI_TYPE = MDT_EFFECTIVE_DPI
+)
+
+type PROCESS_DPI_AWARENESS int32
+
+// PROCESS_DPI_AWARENESS values
+const (
+	PROCESS_DPI_UNAWARE           PROCESS_DPI_AWARENESS = 0
+	PROCESS_SYSTEM_DPI_AWARE      PROCESS_DPI_AWARENESS = 1
+	PROCESS_PER_MONITOR_DPI_AWARE PROCESS_DPI_AWARENESS = 2
+)
+
+var (
+	// Library
+	libshcore *windows.LazyDLL
+
+	// Functions
+	getDpiForMonitor       *windows.LazyProc
+	setProcessDpiAwareness *windows.LazyProc
+)
+
+func init() {
+	// Library
+	libshcore = windows.NewLazySystemDLL("shcore.dll")
+
+	// Functions
+	getDpiForMonitor = libshcore.NewProc("GetDpiForMonitor")
+	setProcessDpiAwareness = libshcore.NewProc("SetProcessDpiAwareness")
+}
+
+func GetDpiForMonitor(hmonitor HMONITOR, dpiType MONITOR_DPI_TYPE, dpiX, dpiY *uint32) HRESULT {
+	if getDpiForMonitor.Find() != nil {
+		// Before Windows 8.1, all monitors use the system DPI.
+		hdc := GetDC(0)
+		defer ReleaseDC(0, hdc)
+
+		*dpiX = uint32(GetDeviceCaps(hdc, LOGPIXELSX))
+		*dpiY = uint32(GetDeviceCaps(hdc, LOGPIXELSY))
+
+		return S_OK
+	}
+
+	ret, _, _ := syscall.Syscall6(getDpiForMonitor.Addr(), 4,
+		uintptr(hmonitor),
+		uintptr(dpiType),
+		uintptr(unsafe.Pointer(dpiX)),
+		uintptr(unsafe.Pointer(dpiY)),
+		0,
+		0)
+
+	return HRESULT(ret)
+}
+
+func SetProcessDpiAwareness(value PROCESS_DPI_AWARENESS) HRESULT {
+	if setProcessDpiAwareness.Find() != nil {
+		return -((E_NOTIMPL ^ 0xFFFFFFFF) + 1)
+	}
+
+	ret, _, _ := syscall.Syscall(setProcessDpiAwareness.Addr(), 1,
+		uintptr(value),
+		0,
+		0)
+
+	return HRESULT(ret)
+}
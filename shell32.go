@@ -13,7 +13,6 @@ import (
 )
 
 type CSIDL uint32
-type HDROP HANDLE
 
 const (
 	CSIDL_DESKTOP                 = 0x00
@@ -287,6 +286,13 @@ type NOTIFYICONDATA struct {
 	HBalloonIcon     HICON
 }
 
+type DROPFILES struct {
+	PFiles uint32
+	Pt     POINT
+	FNC    BOOL
+	FWide  BOOL
+}
+
 type SHFILEINFO struct {
 	HIcon         HICON
 	IIcon         int32
@@ -322,8 +328,10 @@ var (
 	dragAcceptFiles        *windows.LazyProc
 	dragFinish             *windows.LazyProc
 	dragQueryFile          *windows.LazyProc
+	dragQueryPoint         *windows.LazyProc
 	extractIcon            *windows.LazyProc
 	shBrowseForFolder      *windows.LazyProc
+	shCreateStdEnumFmtEtc  *windows.LazyProc
 	shDefExtractIcon       *windows.LazyProc
 	shGetFileInfo          *windows.LazyProc
 	shGetPathFromIDList    *windows.LazyProc
@@ -342,8 +350,10 @@ func init() {
 	dragAcceptFiles = libshell32.NewProc("DragAcceptFiles")
 	dragFinish = libshell32.NewProc("DragFinish")
 	dragQueryFile = libshell32.NewProc("DragQueryFileW")
+	dragQueryPoint = libshell32.NewProc("DragQueryPoint")
 	extractIcon = libshell32.NewProc("ExtractIconW")
 	shBrowseForFolder = libshell32.NewProc("SHBrowseForFolderW")
+	shCreateStdEnumFmtEtc = libshell32.NewProc("SHCreateStdEnumFmtEtc")
 	shDefExtractIcon = libshell32.NewProc("SHDefExtractIconW")
 	shGetFileInfo = libshell32.NewProc("SHGetFileInfoW")
 	shGetPathFromIDList = libshell32.NewProc("SHGetPathFromIDListW")
@@ -376,12 +386,21 @@ func DragQueryFile(hDrop HDROP, iFile uint, lpszFile *uint16, cch uint) uint {
 }
 
 func DragFinish(hDrop HDROP) {
-	syscall.Syscall(dragAcceptFiles.Addr(), 1,
+	syscall.Syscall(dragFinish.Addr(), 1,
 		uintptr(hDrop),
 		0,
 		0)
 }
 
+func DragQueryPoint(hDrop HDROP, ppt *POINT) bool {
+	ret, _, _ := syscall.Syscall(dragQueryPoint.Addr(), 2,
+		uintptr(hDrop),
+		uintptr(unsafe.Pointer(ppt)),
+		0)
+
+	return ret != 0
+}
+
 func ExtractIcon(hInst HINSTANCE, exeFileName *uint16, iconIndex int32) HICON {
 	ret, _, _ := syscall.Syscall(extractIcon.Addr(), 3,
 		uintptr(hInst),
@@ -400,6 +419,15 @@ func SHBrowseForFolder(lpbi *BROWSEINFO) uintptr {
 	return ret
 }
 
+func SHCreateStdEnumFmtEtc(cfmt uint32, afmt *FORMATETC, ppenumFormatEtc **IEnumFORMATETC) HRESULT {
+	ret, _, _ := syscall.Syscall(shCreateStdEnumFmtEtc.Addr(), 3,
+		uintptr(cfmt),
+		uintptr(unsafe.Pointer(afmt)),
+		uintptr(unsafe.Pointer(ppenumFormatEtc)))
+
+	return HRESULT(ret)
+}
+
 func SHDefExtractIcon(pszIconFile *uint16, iIndex int32, uFlags uint32, phiconLarge, phiconSmall *HICON, nIconSize uint32) HRESULT {
 	ret, _, _ := syscall.Syscall6(shDefExtractIcon.Addr(), 6,
 		uintptr(unsafe.Pointer(pszIconFile)),
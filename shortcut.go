@@ -0,0 +1,340 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ACCEL.FVirt flags
+const (
+	FVIRTKEY  = 0x01
+	FNOINVERT = 0x02
+	FSHIFT    = 0x04
+	FCONTROL  = 0x08
+	FALT      = 0x10
+)
+
+// RegisterHotKey modifiers
+const (
+	MOD_ALT      = 0x0001
+	MOD_CONTROL  = 0x0002
+	MOD_SHIFT    = 0x0004
+	MOD_WIN      = 0x0008
+	MOD_NOREPEAT = 0x4000
+)
+
+type ACCEL struct {
+	FVirt byte
+	Key   uint16
+	Cmd   uint16
+}
+
+// KeyModifiers is a set of modifier keys of a Shortcut.
+type KeyModifiers uint8
+
+const (
+	ModCtrl KeyModifiers = 1 << iota
+	ModShift
+	ModAlt
+	ModWin
+)
+
+// Shortcut is a key combination such as Ctrl+Shift+F5.
+type Shortcut struct {
+	Modifiers KeyModifiers
+	Key       uint16 // virtual key code
+}
+
+// shortcutKeyNames lists the key names accepted by ParseShortcut. Letters,
+// digits and F1 to F24 are handled separately. The first name listed for a
+// key is its display name. Punctuation is named as on a US keyboard.
+var shortcutKeyNames = []struct {
+	name string
+	vk   uint16
+}{
+	{"Backspace", VK_BACK},
+	{"Back", VK_BACK},
+	{"Tab", VK_TAB},
+	{"Enter", VK_RETURN},
+	{"Return", VK_RETURN},
+	{"Pause", VK_PAUSE},
+	{"Break", VK_PAUSE},
+	{"CapsLock", VK_CAPITAL},
+	{"Esc", VK_ESCAPE},
+	{"Escape", VK_ESCAPE},
+	{"Space", VK_SPACE},
+	{"PgUp", VK_PRIOR},
+	{"PageUp", VK_PRIOR},
+	{"PgDn", VK_NEXT},
+	{"PageDown", VK_NEXT},
+	{"End", VK_END},
+	{"Home", VK_HOME},
+	{"Left", VK_LEFT},
+	{"Up", VK_UP},
+	{"Right", VK_RIGHT},
+	{"Down", VK_DOWN},
+	{"PrintScreen", VK_SNAPSHOT},
+	{"PrtSc", VK_SNAPSHOT},
+	{"Ins", VK_INSERT},
+	{"Insert", VK_INSERT},
+	{"Del", VK_DELETE},
+	{"Delete", VK_DELETE},
+	{"Apps", VK_APPS},
+	{"Menu", VK_APPS},
+	{"Num 0", VK_NUMPAD0},
+	{"Num 1", VK_NUMPAD1},
+	{"Num 2", VK_NUMPAD2},
+	{"Num 3", VK_NUMPAD3},
+	{"Num 4", VK_NUMPAD4},
+	{"Num 5", VK_NUMPAD5},
+	{"Num 6", VK_NUMPAD6},
+	{"Num 7", VK_NUMPAD7},
+	{"Num 8", VK_NUMPAD8},
+	{"Num 9", VK_NUMPAD9},
+	{"Num *", VK_MULTIPLY},
+	{"Num +", VK_ADD},
+	{"Num -", VK_SUBTRACT},
+	{"Num .", VK_DECIMAL},
+	{"Num Del", VK_DECIMAL},
+	{"Num /", VK_DIVIDE},
+	{"NumLock", VK_NUMLOCK},
+	{"ScrollLock", VK_SCROLL},
+	{";", VK_OEM_1},
+	{"+", VK_OEM_PLUS},
+	{"=", VK_OEM_PLUS},
+	{",", VK_OEM_COMMA},
+	{"-", VK_OEM_MINUS},
+	{".", VK_OEM_PERIOD},
+	{"/", VK_OEM_2},
+	{"`", VK_OEM_3},
+	{"[", VK_OEM_4},
+	{"\\", VK_OEM_5},
+	{"]", VK_OEM_6},
+	{"'", VK_OEM_7},
+}
+
+// normalizeKeyName makes key names comparable: case and spaces do not
+// matter, and "Numpad" is the same as "Num".
+func normalizeKeyName(name string) string {
+	name = strings.ToLower(strings.Replace(name, " ", "", -1))
+
+	if strings.HasPrefix(name, "numpad") {
+		name = "num" + name[len("numpad"):]
+	}
+
+	return name
+}
+
+var shortcutKeysByName map[string]uint16
+
+func init() {
+	shortcutKeysByName = make(map[string]uint16, len(shortcutKeyNames))
+
+	for _, k := range shortcutKeyNames {
+		shortcutKeysByName[normalizeKeyName(k.name)] = k.vk
+	}
+}
+
+// ParseShortcut parses a key combination such as "Ctrl+O", "Ctrl+Shift+F5",
+// "Ctrl++" or "Alt+Num+". Names are case-insensitive. The modifiers are
+// Ctrl (or Control), Shift, Alt and Win. Keys without a name can be given
+// as a hex virtual key code, e.g. "0xE2".
+func ParseShortcut(s string) (Shortcut, error) {
+	var sc Shortcut
+
+	// The key itself may be or end with a plus sign, so it extends from
+	// the last separator that is not the final character.
+	body, suffix := strings.TrimSpace(s), ""
+	if strings.HasSuffix(body, "+") {
+		body, suffix = body[:len(body)-1], "+"
+	}
+
+	key := body + suffix
+	if i := strings.LastIndex(body, "+"); i >= 0 {
+		key = body[i+1:] + suffix
+
+		for _, mod := range strings.Split(body[:i], "+") {
+			switch strings.ToLower(strings.TrimSpace(mod)) {
+			case "ctrl", "control":
+				sc.Modifiers |= ModCtrl
+
+			case "shift":
+				sc.Modifiers |= ModShift
+
+			case "alt":
+				sc.Modifiers |= ModAlt
+
+			case "win":
+				sc.Modifiers |= ModWin
+
+			default:
+				return Shortcut{}, fmt.Errorf("invalid shortcut %q: unknown modifier %q", s, mod)
+			}
+		}
+	}
+
+	vk, ok := parseKeyName(key)
+	if !ok {
+		return Shortcut{}, fmt.Errorf("invalid shortcut %q: unknown key %q", s, strings.TrimSpace(key))
+	}
+	sc.Key = vk
+
+	return sc, nil
+}
+
+func parseKeyName(name string) (uint16, bool) {
+	name = normalizeKeyName(name)
+
+	if len(name) == 1 {
+		if c := name[0]; c >= 'a' && c <= 'z' {
+			return uint16(c - 'a' + 'A'), true
+		} else if c >= '0' && c <= '9' {
+			return uint16(c), true
+		}
+	}
+
+	if len(name) >= 2 && name[0] == 'f' && name[1] != '0' {
+		if n, err := strconv.Atoi(name[1:]); err == nil && n >= 1 && n <= 24 {
+			return uint16(VK_F1 + n - 1), true
+		}
+	}
+
+	if strings.HasPrefix(name, "0x") {
+		if n, err := strconv.ParseUint(name[2:], 16, 8); err == nil && n != 0 {
+			return uint16(n), true
+		}
+	}
+
+	vk, ok := shortcutKeysByName[name]
+
+	return vk, ok
+}
+
+// keyName returns the display name of the virtual key vk.
+func keyName(vk uint16) string {
+	switch {
+	case vk >= 'A' && vk <= 'Z', vk >= '0' && vk <= '9':
+		return string(rune(vk))
+
+	case vk >= VK_F1 && vk <= VK_F24:
+		return "F" + strconv.Itoa(int(vk-VK_F1+1))
+	}
+
+	for _, k := range shortcutKeyNames {
+		if k.vk == vk {
+			return k.name
+		}
+	}
+
+	return fmt.Sprintf("0x%02X", vk)
+}
+
+// String formats s for display, e.g. "Ctrl+Shift+F5". ParseShortcut
+// accepts the result.
+func (s Shortcut) String() string {
+	var b strings.Builder
+
+	for _, mod := range []struct {
+		m    KeyModifiers
+		name string
+	}{
+		{ModWin, "Win+"},
+		{ModCtrl, "Ctrl+"},
+		{ModAlt, "Alt+"},
+		{ModShift, "Shift+"},
+	} {
+		if s.Modifiers&mod.m != 0 {
+			b.WriteString(mod.name)
+		}
+	}
+
+	b.WriteString(keyName(s.Key))
+
+	return b.String()
+}
+
+// Accel returns the accelerator table entry that sends the command id cmd
+// for s. Accelerators cannot use the Win key.
+func (s Shortcut) Accel(cmd uint16) (ACCEL, error) {
+	if s.Modifiers&ModWin != 0 {
+		return ACCEL{}, errors.New("accelerators do not support the Win modifier")
+	}
+
+	virt := byte(FVIRTKEY)
+	if s.Modifiers&ModCtrl != 0 {
+		virt |= FCONTROL
+	}
+	if s.Modifiers&ModShift != 0 {
+		virt |= FSHIFT
+	}
+	if s.Modifiers&ModAlt != 0 {
+		virt |= FALT
+	}
+
+	return ACCEL{FVirt: virt, Key: s.Key, Cmd: cmd}, nil
+}
+
+// ShortcutFromAccel returns the shortcut of the accelerator table entry a,
+// which must use FVIRTKEY.
+func ShortcutFromAccel(a ACCEL) Shortcut {
+	s := Shortcut{Key: a.Key}
+
+	if a.FVirt&FCONTROL != 0 {
+		s.Modifiers |= ModCtrl
+	}
+	if a.FVirt&FSHIFT != 0 {
+		s.Modifiers |= ModShift
+	}
+	if a.FVirt&FALT != 0 {
+		s.Modifiers |= ModAlt
+	}
+
+	return s
+}
+
+// HotKeyModifiers returns the MOD_* flags of s for RegisterHotKey.
+func (s Shortcut) HotKeyModifiers() uint32 {
+	var mods uint32
+
+	if s.Modifiers&ModCtrl != 0 {
+		mods |= MOD_CONTROL
+	}
+	if s.Modifiers&ModShift != 0 {
+		mods |= MOD_SHIFT
+	}
+	if s.Modifiers&ModAlt != 0 {
+		mods |= MOD_ALT
+	}
+	if s.Modifiers&ModWin != 0 {
+		mods |= MOD_WIN
+	}
+
+	return mods
+}
+
+// ShortcutFromHotKey returns the shortcut of a WM_HOTKEY message or of
+// RegisterHotKey arguments.
+func ShortcutFromHotKey(mods uint32, vk uint16) Shortcut {
+	s := Shortcut{Key: vk}
+
+	if mods&MOD_CONTROL != 0 {
+		s.Modifiers |= ModCtrl
+	}
+	if mods&MOD_SHIFT != 0 {
+		s.Modifiers |= ModShift
+	}
+	if mods&MOD_ALT != 0 {
+		s.Modifiers |= ModAlt
+	}
+	if mods&MOD_WIN != 0 {
+		s.Modifiers |= ModWin
+	}
+
+	return s
+}
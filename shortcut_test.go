@@ -0,0 +1,142 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+import (
+	"testing"
+)
+
+func TestParseShortcut(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Shortcut
+	}{
+		{"Ctrl+O", Shortcut{ModCtrl, 'O'}},
+		{"ctrl+o", Shortcut{ModCtrl, 'O'}},
+		{"Ctrl+Shift+F5", Shortcut{ModCtrl | ModShift, VK_F5}},
+		{"Control + Alt + Win + F24", Shortcut{ModCtrl | ModAlt | ModWin, VK_F24}},
+		{"Ctrl++", Shortcut{ModCtrl, VK_OEM_PLUS}},
+		{"+", Shortcut{0, VK_OEM_PLUS}},
+		{"Ctrl+-", Shortcut{ModCtrl, VK_OEM_MINUS}},
+		{"Alt+Num+", Shortcut{ModAlt, VK_ADD}},
+		{"Num +", Shortcut{0, VK_ADD}},
+		{"Numpad 5", Shortcut{0, VK_NUMPAD5}},
+		{"Shift+Num Del", Shortcut{ModShift, VK_DECIMAL}},
+		{"0xE2", Shortcut{0, 0xE2}},
+		{"Win+0xe2", Shortcut{ModWin, 0xE2}},
+		{"Alt+7", Shortcut{ModAlt, '7'}},
+		{" Esc ", Shortcut{0, VK_ESCAPE}},
+		{"PgDn", Shortcut{0, VK_NEXT}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseShortcut(tt.s)
+		if err != nil {
+			t.Errorf("ParseShortcut(%q): %v", tt.s, err)
+		} else if got != tt.want {
+			t.Errorf("ParseShortcut(%q) = %+v, want %+v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParseShortcutInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"Ctrl+",
+		"Hyper+A",
+		"Ctrl+Meta+A",
+		"Ctrl++A",
+		"Ctrl+Foo",
+		"F0",
+		"F25",
+		"F05",
+		"0x",
+		"0x0",
+		"0x100",
+		"AB",
+	} {
+		if sc, err := ParseShortcut(s); err == nil {
+			t.Errorf("ParseShortcut(%q) = %+v, want an error", s, sc)
+		}
+	}
+}
+
+func TestShortcutString(t *testing.T) {
+	tests := []struct {
+		sc   Shortcut
+		want string
+	}{
+		{Shortcut{ModCtrl | ModShift, VK_F5}, "Ctrl+Shift+F5"},
+		{Shortcut{ModShift | ModAlt | ModCtrl | ModWin, 'A'}, "Win+Ctrl+Alt+Shift+A"},
+		{Shortcut{ModCtrl, VK_OEM_PLUS}, "Ctrl++"},
+		{Shortcut{ModAlt, VK_ADD}, "Alt+Num +"},
+		{Shortcut{0, VK_DELETE}, "Del"},
+		{Shortcut{0, 0xE2}, "0xE2"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.sc.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.sc, got, tt.want)
+		}
+	}
+}
+
+func TestShortcutStringRoundTrip(t *testing.T) {
+	for mods := KeyModifiers(0); mods <= ModCtrl|ModShift|ModAlt|ModWin; mods++ {
+		for vk := 1; vk <= 0xFF; vk++ {
+			sc := Shortcut{mods, uint16(vk)}
+
+			got, err := ParseShortcut(sc.String())
+			if err != nil {
+				t.Fatalf("ParseShortcut(%q): %v", sc.String(), err)
+			}
+			if got != sc {
+				t.Fatalf("ParseShortcut(%q) = %+v, want %+v", sc.String(), got, sc)
+			}
+		}
+	}
+}
+
+func TestShortcutAccel(t *testing.T) {
+	sc := Shortcut{ModCtrl | ModShift | ModAlt, VK_F5}
+
+	a, err := sc.Accel(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (ACCEL{FVIRTKEY | FCONTROL | FSHIFT | FALT, VK_F5, 42}); a != want {
+		t.Errorf("Accel = %+v, want %+v", a, want)
+	}
+	if got := ShortcutFromAccel(a); got != sc {
+		t.Errorf("ShortcutFromAccel = %+v, want %+v", got, sc)
+	}
+
+	if _, err := (Shortcut{ModWin, 'A'}).Accel(1); err == nil {
+		t.Error("Accel with the Win modifier succeeded")
+	}
+}
+
+func TestShortcutHotKeyModifiers(t *testing.T) {
+	tests := []struct {
+		mods KeyModifiers
+		want uint32
+	}{
+		{0, 0},
+		{ModCtrl, MOD_CONTROL},
+		{ModShift | ModWin, MOD_SHIFT | MOD_WIN},
+		{ModCtrl | ModShift | ModAlt | ModWin, MOD_CONTROL | MOD_SHIFT | MOD_ALT | MOD_WIN},
+	}
+
+	for _, tt := range tests {
+		sc := Shortcut{tt.mods, 'K'}
+
+		if got := sc.HotKeyModifiers(); got != tt.want {
+			t.Errorf("%v: HotKeyModifiers = %#x, want %#x", sc, got, tt.want)
+		}
+		if got := ShortcutFromHotKey(tt.want|MOD_NOREPEAT, 'K'); got != sc {
+			t.Errorf("ShortcutFromHotKey(%#x) = %+v, want %+v", tt.want, got, sc)
+		}
+	}
+}
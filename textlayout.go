@@ -0,0 +1,324 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"unicode/utf16"
+	"unsafe"
+)
+
+// Ellipsis selects how TextLayout shortens text that does not fit.
+type Ellipsis uint32
+
+const (
+	EllipsisNone Ellipsis = 0
+	EllipsisEnd  Ellipsis = DT_END_ELLIPSIS
+	EllipsisPath Ellipsis = DT_PATH_ELLIPSIS
+	EllipsisWord Ellipsis = DT_WORD_ELLIPSIS
+)
+
+// Prefix selects how TextLayout treats '&' mnemonic prefixes.
+type Prefix uint32
+
+const (
+	// PrefixNone draws '&' literally.
+	PrefixNone Prefix = DT_NOPREFIX
+	// PrefixShow underlines the character following '&'.
+	PrefixShow Prefix = 0
+	// PrefixHide removes '&' without underlining the following character.
+	PrefixHide Prefix = DT_HIDEPREFIX
+)
+
+// TextLayout measures and draws Go strings with DrawTextEx and friends.
+//
+// A TextLayout keeps its UTF-16 conversion buffers between calls, so drawing
+// many strings with the same TextLayout does not allocate. It is not safe for
+// concurrent use.
+type TextLayout struct {
+	// Font is selected into the DC for the duration of each call. If zero,
+	// the font currently selected into the DC is used.
+	Font HFONT
+
+	// Format holds additional DT_* flags such as DT_CENTER or DT_VCENTER.
+	Format uint32
+
+	Ellipsis Ellipsis
+	Prefix   Prefix
+
+	// TabLength is the tab stop distance in average character widths.
+	// Zero disables tab expansion.
+	TabLength int32
+
+	buf    []uint16
+	offs   []int
+	params DRAWTEXTPARAMS
+}
+
+// NewTextLayout returns a TextLayout drawing with font and no prefix
+// processing.
+func NewTextLayout(font HFONT) *TextLayout {
+	return &TextLayout{
+		Font:   font,
+		Prefix: PrefixNone,
+	}
+}
+
+// encode converts s into the reusable UTF-16 buffer and records the byte
+// offset in s of every UTF-16 unit, plus len(s) as a final sentinel.
+func (l *TextLayout) encode(s string) []uint16 {
+	l.buf = l.buf[:0]
+	l.offs = l.offs[:0]
+
+	for i, r := range s {
+		if r >= 0x10000 {
+			r1, r2 := utf16.EncodeRune(r)
+			l.buf = append(l.buf, uint16(r1), uint16(r2))
+			l.offs = append(l.offs, i, i)
+		} else {
+			l.buf = append(l.buf, uint16(r))
+			l.offs = append(l.offs, i)
+		}
+	}
+
+	l.offs = append(l.offs, len(s))
+
+	return l.buf
+}
+
+func (l *TextLayout) selectFont(hdc HDC) HGDIOBJ {
+	if l.Font == 0 {
+		return 0
+	}
+
+	return SelectObject(hdc, HGDIOBJ(l.Font))
+}
+
+func (l *TextLayout) restoreFont(hdc HDC, old HGDIOBJ) {
+	if old != 0 {
+		SelectObject(hdc, old)
+	}
+}
+
+func (l *TextLayout) format(extra uint32) uint32 {
+	format := l.Format | uint32(l.Ellipsis) | uint32(l.Prefix) | extra
+
+	if l.TabLength > 0 {
+		format |= DT_EXPANDTABS | DT_TABSTOP
+	}
+
+	return format
+}
+
+func (l *TextLayout) drawText(hdc HDC, text string, rc *RECT, format uint32) int32 {
+	buf := l.encode(text)
+	if len(buf) == 0 {
+		if format&DT_CALCRECT != 0 {
+			rc.Right = rc.Left
+			rc.Bottom = rc.Top
+		}
+		return 0
+	}
+
+	l.params = DRAWTEXTPARAMS{
+		CbSize:     uint32(unsafe.Sizeof(l.params)),
+		ITabLength: l.TabLength,
+	}
+
+	old := l.selectFont(hdc)
+	defer l.restoreFont(hdc, old)
+
+	return DrawTextEx(hdc, &buf[0], int32(len(buf)), rc, format, &l.params)
+}
+
+// Draw draws text into rc and returns the height of the drawn text.
+// Multi-line text is wrapped at word boundaries unless wrap is false.
+func (l *TextLayout) Draw(hdc HDC, text string, rc *RECT, wrap bool) (int32, error) {
+	var extra uint32
+	if wrap {
+		extra = DT_WORDBREAK
+	} else {
+		extra = DT_SINGLELINE
+	}
+
+	height := l.drawText(hdc, text, rc, l.format(extra))
+	if height == 0 && text != "" {
+		return 0, errors.New("DrawTextEx failed")
+	}
+
+	return height, nil
+}
+
+// Measure returns the rectangle text occupies when wrapped to width, or on
+// a single line if width is not positive. The result has its origin at 0, 0.
+func (l *TextLayout) Measure(hdc HDC, text string, width int32) (RECT, error) {
+	rc := RECT{Right: width}
+
+	var extra uint32 = DT_CALCRECT
+	if width > 0 {
+		extra |= DT_WORDBREAK
+	} else {
+		extra |= DT_SINGLELINE
+	}
+
+	if l.drawText(hdc, text, &rc, l.format(extra)) == 0 && text != "" {
+		return RECT{}, errors.New("DrawTextEx failed")
+	}
+
+	return rc, nil
+}
+
+// Extent returns the size of text drawn on a single line without any prefix
+// or tab processing.
+func (l *TextLayout) Extent(hdc HDC, text string) (SIZE, error) {
+	var size SIZE
+
+	buf := l.encode(text)
+	if len(buf) == 0 {
+		return size, nil
+	}
+
+	old := l.selectFont(hdc)
+	defer l.restoreFont(hdc, old)
+
+	if !GetTextExtentPoint32(hdc, &buf[0], int32(len(buf)), &size) {
+		return size, errors.New("GetTextExtentPoint32 failed")
+	}
+
+	return size, nil
+}
+
+// Fit returns the length in bytes of the longest prefix of text that fits
+// into maxWidth on a single line, along with the full text extent.
+func (l *TextLayout) Fit(hdc HDC, text string, maxWidth int32) (int, SIZE, error) {
+	var size SIZE
+
+	buf := l.encode(text)
+	if len(buf) == 0 {
+		return 0, size, nil
+	}
+
+	old := l.selectFont(hdc)
+	defer l.restoreFont(hdc, old)
+
+	var fit int32
+	if !GetTextExtentExPoint(hdc, &buf[0], int32(len(buf)), maxWidth, &fit, nil, &size) {
+		return 0, size, errors.New("GetTextExtentExPoint failed")
+	}
+
+	return l.offs[l.unitBoundary(int(fit))], size, nil
+}
+
+// unitBoundary moves i back so that it does not split a surrogate pair.
+func (l *TextLayout) unitBoundary(i int) int {
+	if i > 0 && i < len(l.buf) && l.buf[i] >= 0xDC00 && l.buf[i] <= 0xDFFF {
+		i--
+	}
+
+	return i
+}
+
+// AppendLineBreaks wraps text to width and appends the byte offset at which
+// each line after the first starts to dst. Lines break after spaces where
+// possible, otherwise between characters; "\n" and "\r\n" always break.
+//
+// Text is measured literally, so it should not contain '&' prefixes or tabs
+// if the result is to match what Draw produces.
+func (l *TextLayout) AppendLineBreaks(dst []int, hdc HDC, text string, width int32) ([]int, error) {
+	buf := l.encode(text)
+	if len(buf) == 0 {
+		return dst, nil
+	}
+
+	old := l.selectFont(hdc)
+	defer l.restoreFont(hdc, old)
+
+	start := 0
+	for start < len(buf) {
+		end := start
+		for end < len(buf) && buf[end] != '\n' {
+			end++
+		}
+
+		lineEnd := end
+		if lineEnd > start && buf[lineEnd-1] == '\r' {
+			lineEnd--
+		}
+
+		for start < lineEnd {
+			var fit int32
+			var size SIZE
+			if !GetTextExtentExPoint(hdc, &buf[start], int32(lineEnd-start), width, &fit, nil, &size) {
+				return dst, errors.New("GetTextExtentExPoint failed")
+			}
+
+			if start+int(fit) >= lineEnd {
+				break
+			}
+
+			brk := -1
+			for i := start + int(fit); i > start; i-- {
+				if buf[i] == ' ' || buf[i-1] == ' ' {
+					brk = i
+					break
+				}
+			}
+			if brk < 0 {
+				brk = l.unitBoundary(start + int(fit))
+			}
+			if brk <= start {
+				// Always make progress, even if not a single character fits.
+				brk = start + 1
+				if brk < lineEnd && buf[brk] >= 0xDC00 && buf[brk] <= 0xDFFF {
+					brk++
+				}
+			}
+
+			for brk < lineEnd && buf[brk] == ' ' {
+				brk++
+			}
+			if brk >= lineEnd {
+				break
+			}
+
+			dst = append(dst, l.offs[brk])
+			start = brk
+		}
+
+		if end >= len(buf) {
+			break
+		}
+
+		start = end + 1
+		dst = append(dst, l.offs[start])
+	}
+
+	return dst, nil
+}
+
+// LineBreaks is like AppendLineBreaks but returns a new slice.
+func (l *TextLayout) LineBreaks(hdc HDC, text string, width int32) ([]int, error) {
+	return l.AppendLineBreaks(nil, hdc, text, width)
+}
+
+// TextOut draws text at x, y with TextOut, bypassing all DrawTextEx layout.
+// It is the fastest way to draw a run of glyphs that is known to fit.
+func (l *TextLayout) TextOut(hdc HDC, x, y int32, text string) error {
+	buf := l.encode(text)
+	if len(buf) == 0 {
+		return nil
+	}
+
+	old := l.selectFont(hdc)
+	defer l.restoreFont(hdc, old)
+
+	if !TextOut(hdc, x, y, &buf[0], int32(len(buf))) {
+		return errors.New("TextOut failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// lastTimerID is the last timer id allocated by a TimerManager. Ids are
+// unique in the process, so managers and components sharing a window do
+// not collide. They start above the small ids typically chosen by hand.
+var lastTimerID uint32 = 0xFFFF
+
+func nextTimerID() uintptr {
+	return uintptr(atomic.AddUint32(&lastTimerID, 1))
+}
+
+// TimerManager runs Go functions on the WM_TIMER messages of a window. All
+// methods, and those of its timers, must be called on the thread of the
+// window.
+type TimerManager struct {
+	w      *Window
+	timers map[uintptr]*Timer
+}
+
+// Timer is a one-shot or repeating timer of a TimerManager.
+type Timer struct {
+	m         *TimerManager
+	id        uintptr
+	f         func()
+	interval  time.Duration
+	tolerance uint32
+	repeat    bool
+}
+
+// NewTimerManager returns a TimerManager for w. Its timers are stopped when
+// w is destroyed.
+func NewTimerManager(w *Window) *TimerManager {
+	m := &TimerManager{
+		w:      w,
+		timers: make(map[uintptr]*Timer),
+	}
+
+	w.Handle(WM_TIMER, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		t := m.timers[CrackTimerMsg(wParam, lParam).ID]
+		if t == nil {
+			return 0, false
+		}
+
+		if !t.repeat {
+			t.Stop()
+		}
+
+		t.f()
+
+		return 0, true
+	})
+
+	w.Handle(WM_DESTROY, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		m.StopAll()
+		return 0, false
+	})
+
+	return m
+}
+
+// After calls f once after d.
+func (m *TimerManager) After(d time.Duration, f func()) (*Timer, error) {
+	return m.start(d, TIMERV_DEFAULT_COALESCING, false, f)
+}
+
+// Every calls f every d until the timer is stopped.
+func (m *TimerManager) Every(d time.Duration, f func()) (*Timer, error) {
+	return m.start(d, TIMERV_DEFAULT_COALESCING, true, f)
+}
+
+// EveryCoalesced is like Every, but allows the system to delay f by up to
+// tolerance, so that it can coalesce timers and save power. On systems
+// without SetCoalescableTimer it is the same as Every.
+func (m *TimerManager) EveryCoalesced(d, tolerance time.Duration, f func()) (*Timer, error) {
+	return m.start(d, timerMilliseconds(tolerance), true, f)
+}
+
+func (m *TimerManager) start(d time.Duration, tolerance uint32, repeat bool, f func()) (*Timer, error) {
+	t := &Timer{
+		m:         m,
+		id:        nextTimerID(),
+		f:         f,
+		interval:  d,
+		tolerance: tolerance,
+		repeat:    repeat,
+	}
+
+	if err := t.set(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// timerMilliseconds converts d to the range SetTimer accepts.
+func timerMilliseconds(d time.Duration) uint32 {
+	ms := d / time.Millisecond
+	if ms < USER_TIMER_MINIMUM {
+		return USER_TIMER_MINIMUM
+	}
+	if ms > USER_TIMER_MAXIMUM {
+		return USER_TIMER_MAXIMUM
+	}
+
+	return uint32(ms)
+}
+
+func (t *Timer) set() error {
+	hwnd := t.m.w.HWND()
+	if hwnd == 0 {
+		return errors.New("window has not been created")
+	}
+
+	if SetCoalescableTimer(hwnd, t.id, timerMilliseconds(t.interval), 0, t.tolerance) == 0 {
+		return errors.New("SetCoalescableTimer failed")
+	}
+
+	t.m.timers[t.id] = t
+
+	return nil
+}
+
+// ID returns the WM_TIMER id of t.
+func (t *Timer) ID() uintptr {
+	return t.id
+}
+
+// Active reports whether t is running.
+func (t *Timer) Active() bool {
+	return t.m.timers[t.id] == t
+}
+
+// Reset restarts t with the interval d, also if it has fired or was
+// stopped.
+func (t *Timer) Reset(d time.Duration) error {
+	t.interval = d
+
+	return t.set()
+}
+
+// Stop stops t and reports whether it was running.
+func (t *Timer) Stop() bool {
+	if !t.Active() {
+		return false
+	}
+
+	delete(t.m.timers, t.id)
+
+	if hwnd := t.m.w.HWND(); hwnd != 0 {
+		KillTimer(hwnd, t.id)
+	}
+
+	return true
+}
+
+// StopAll stops all timers of m.
+func (m *TimerManager) StopAll() {
+	for _, t := range m.timers {
+		t.Stop()
+	}
+}
+
+// Debounce returns a function that calls f once it has not been called for
+// d, e.g. to relayout after a window has stopped being resized.
+func (m *TimerManager) Debounce(d time.Duration, f func()) func() {
+	var t *Timer
+
+	return func() {
+		if t != nil {
+			t.Reset(d)
+			return
+		}
+
+		t, _ = m.After(d, f)
+	}
+}
+
+// Throttle returns a function that calls f at most once every d. The first
+// call runs f immediately; further calls within d are collapsed into one
+// call at the end of the interval.
+func (m *TimerManager) Throttle(d time.Duration, f func()) func() {
+	var t *Timer
+	var pending bool
+
+	tick := func() {
+		if !pending {
+			return
+		}
+
+		pending = false
+		f()
+
+		t.Reset(d)
+	}
+
+	return func() {
+		if t != nil && t.Active() {
+			pending = true
+			return
+		}
+
+		f()
+
+		if t == nil {
+			t, _ = m.After(d, tick)
+		} else {
+			t.Reset(d)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package win
+
+// The declarations in this file do not depend on Windows, so that the
+// encoders and decoders built on them compile and can be tested on any
+// platform.
+
+type (
+	ATOM          uint16
+	HANDLE        uintptr
+	HGLOBAL       HANDLE
+	HINSTANCE     HANDLE
+	LCID          uint32
+	LCTYPE        uint32
+	LANGID        uint16
+	HMODULE       uintptr
+	HWINEVENTHOOK HANDLE
+	HRSRC         uintptr
+)
+
+type (
+	COLORREF     uint32
+	HBITMAP      uintptr
+	HBRUSH       uintptr
+	HDC          uintptr
+	HFONT        uintptr
+	HGDIOBJ      uintptr
+	HENHMETAFILE uintptr
+	HPALETTE     uintptr
+	HPEN         uintptr
+	HRGN         uintptr
+	CLIPFORMAT   uint16
+)
+
+type (
+	HACCEL    HANDLE
+	HCURSOR   HANDLE
+	HDWP      HANDLE
+	HHOOK     HANDLE
+	HICON     HANDLE
+	HKL       HANDLE
+	HMENU     HANDLE
+	HMONITOR  HANDLE
+	HRAWINPUT HANDLE
+	HWND      HANDLE
+)
+
+type HDROP HANDLE
+
+func MAKEWORD(lo, hi byte) uint16 {
+	return uint16(uint16(lo) | ((uint16(hi)) << 8))
+}
+
+func LOBYTE(w uint16) byte {
+	return byte(w)
+}
+
+func HIBYTE(w uint16) byte {
+	return byte(w >> 8 & 0xff)
+}
+
+func MAKELONG(lo, hi uint16) uint32 {
+	return uint32(uint32(lo) | ((uint32(hi)) << 16))
+}
+
+func LOWORD(dw uint32) uint16 {
+	return uint16(dw)
+}
+
+func HIWORD(dw uint32) uint16 {
+	return uint16(dw >> 16 & 0xffff)
+}
+
+type POINT struct {
+	X, Y int32
+}
+
+type RECT struct {
+	Left, Top, Right, Bottom int32
+}
+
+type SIZE struct {
+	CX, CY int32
+}
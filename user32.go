@@ -214,6 +214,14 @@ const (
 	PM_NOYIELD  = 0x0002
 )
 
+// SetTimer and SetCoalescableTimer values
+const (
+	USER_TIMER_MINIMUM        = 0x0000000A
+	USER_TIMER_MAXIMUM        = 0x7FFFFFFF
+	TIMERV_DEFAULT_COALESCING = 0
+	TIMERV_NO_COALESCING      = 0xFFFFFFFF
+)
+
 // Button state constants
 const (
 	BST_CHECKED       = 1
@@ -284,6 +292,21 @@ const (
 	GWLP_USERDATA   = -21
 )
 
+// GetClassLong and GetClassLongPtr constants
+const (
+	GCLP_MENUNAME      = -8
+	GCLP_HBRBACKGROUND = -10
+	GCLP_HCURSOR       = -12
+	GCLP_HICON         = -14
+	GCLP_HMODULE       = -16
+	GCL_CBWNDEXTRA     = -18
+	GCL_CBCLSEXTRA     = -20
+	GCLP_WNDPROC       = -24
+	GCL_STYLE          = -26
+	GCW_ATOM           = -32
+	GCLP_HICONSM       = -34
+)
+
 // Predefined window handles
 const (
 	HWND_BROADCAST = HWND(0xFFFF)
@@ -479,147 +502,6 @@ const (
 	UISF_ACTIVE    = 0x4
 )
 
-// Virtual key codes
-const (
-	VK_LBUTTON             = 1
-	VK_RBUTTON             = 2
-	VK_CANCEL              = 3
-	VK_MBUTTON             = 4
-	VK_XBUTTON1            = 5
-	VK_XBUTTON2            = 6
-	VK_BACK                = 8
-	VK_TAB                 = 9
-	VK_CLEAR               = 12
-	VK_RETURN              = 13
-	VK_SHIFT               = 16
-	VK_CONTROL             = 17
-	VK_MENU                = 18
-	VK_PAUSE               = 19
-	VK_CAPITAL             = 20
-	VK_KANA                = 0x15
-	VK_HANGEUL             = 0x15
-	VK_HANGUL              = 0x15
-	VK_JUNJA               = 0x17
-	VK_FINAL               = 0x18
-	VK_HANJA               = 0x19
-	VK_KANJI               = 0x19
-	VK_ESCAPE              = 0x1B
-	VK_CONVERT             = 0x1C
-	VK_NONCONVERT          = 0x1D
-	VK_ACCEPT              = 0x1E
-	VK_MODECHANGE          = 0x1F
-	VK_SPACE               = 32
-	VK_PRIOR               = 33
-	VK_NEXT                = 34
-	VK_END                 = 35
-	VK_HOME                = 36
-	VK_LEFT                = 37
-	VK_UP                  = 38
-	VK_RIGHT               = 39
-	VK_DOWN                = 40
-	VK_SELECT              = 41
-	VK_PRINT               = 42
-	VK_EXECUTE             = 43
-	VK_SNAPSHOT            = 44
-	VK_INSERT              = 45
-	VK_DELETE              = 46
-	VK_HELP                = 47
-	VK_LWIN                = 0x5B
-	VK_RWIN                = 0x5C
-	VK_APPS                = 0x5D
-	VK_SLEEP               = 0x5F
-	VK_NUMPAD0             = 0x60
-	VK_NUMPAD1             = 0x61
-	VK_NUMPAD2             = 0x62
-	VK_NUMPAD3             = 0x63
-	VK_NUMPAD4             = 0x64
-	VK_NUMPAD5             = 0x65
-	VK_NUMPAD6             = 0x66
-	VK_NUMPAD7             = 0x67
-	VK_NUMPAD8             = 0x68
-	VK_NUMPAD9             = 0x69
-	VK_MULTIPLY            = 0x6A
-	VK_ADD                 = 0x6B
-	VK_SEPARATOR           = 0x6C
-	VK_SUBTRACT            = 0x6D
-	VK_DECIMAL             = 0x6E
-	VK_DIVIDE              = 0x6F
-	VK_F1                  = 0x70
-	VK_F2                  = 0x71
-	VK_F3                  = 0x72
-	VK_F4                  = 0x73
-	VK_F5                  = 0x74
-	VK_F6                  = 0x75
-	VK_F7                  = 0x76
-	VK_F8                  = 0x77
-	VK_F9                  = 0x78
-	VK_F10                 = 0x79
-	VK_F11                 = 0x7A
-	VK_F12                 = 0x7B
-	VK_F13                 = 0x7C
-	VK_F14                 = 0x7D
-	VK_F15                 = 0x7E
-	VK_F16                 = 0x7F
-	VK_F17                 = 0x80
-	VK_F18                 = 0x81
-	VK_F19                 = 0x82
-	VK_F20                 = 0x83
-	VK_F21                 = 0x84
-	VK_F22                 = 0x85
-	VK_F23                 = 0x86
-	VK_F24                 = 0x87
-	VK_NUMLOCK             = 0x90
-	VK_SCROLL              = 0x91
-	VK_LSHIFT              = 0xA0
-	VK_RSHIFT              = 0xA1
-	VK_LCONTROL            = 0xA2
-	VK_RCONTROL            = 0xA3
-	VK_LMENU               = 0xA4
-	VK_RMENU               = 0xA5
-	VK_BROWSER_BACK        = 0xA6
-	VK_BROWSER_FORWARD     = 0xA7
-	VK_BROWSER_REFRESH     = 0xA8
-	VK_BROWSER_STOP        = 0xA9
-	VK_BROWSER_SEARCH      = 0xAA
-	VK_BROWSER_FAVORITES   = 0xAB
-	VK_BROWSER_HOME        = 0xAC
-	VK_VOLUME_MUTE         = 0xAD
-	VK_VOLUME_DOWN         = 0xAE
-	VK_VOLUME_UP           = 0xAF
-	VK_MEDIA_NEXT_TRACK    = 0xB0
-	VK_MEDIA_PREV_TRACK    = 0xB1
-	VK_MEDIA_STOP          = 0xB2
-	VK_MEDIA_PLAY_PAUSE    = 0xB3
-	VK_LAUNCH_MAIL         = 0xB4
-	VK_LAUNCH_MEDIA_SELECT = 0xB5
-	VK_LAUNCH_APP1         = 0xB6
-	VK_LAUNCH_APP2         = 0xB7
-	VK_OEM_1               = 0xBA
-	VK_OEM_PLUS            = 0xBB
-	VK_OEM_COMMA           = 0xBC
-	VK_OEM_MINUS           = 0xBD
-	VK_OEM_PERIOD          = 0xBE
-	VK_OEM_2               = 0xBF
-	VK_OEM_3               = 0xC0
-	VK_OEM_4               = 0xDB
-	VK_OEM_5               = 0xDC
-	VK_OEM_6               = 0xDD
-	VK_OEM_7               = 0xDE
-	VK_OEM_8               = 0xDF
-	VK_OEM_102             = 0xE2
-	VK_PROCESSKEY          = 0xE5
-	VK_PACKET              = 0xE7
-	VK_ATTN                = 0xF6
-	VK_CRSEL               = 0xF7
-	VK_EXSEL               = 0xF8
-	VK_EREOF               = 0xF9
-	VK_PLAY                = 0xFA
-	VK_ZOOM                = 0xFB
-	VK_NONAME              = 0xFC
-	VK_PA1                 = 0xFD
-	VK_OEM_CLEAR           = 0xFE
-)
-
 // Window style constants
 const (
 	WS_OVERLAPPED       = 0x00000000
@@ -751,6 +633,7 @@ const (
 	WM_INITMENU               = 278
 	WM_INITMENUPOPUP          = 279
 	WM_INPUT                  = 0x00FF
+	WM_INPUT_DEVICE_CHANGE    = 0x00FE
 	WM_INPUTLANGCHANGE        = 81
 	WM_INPUTLANGCHANGEREQUEST = 80
 	WM_KEYDOWN                = 256
@@ -879,6 +762,7 @@ const (
 	WM_MBUTTONUP              = 520
 	WM_MBUTTONDBLCLK          = 521
 	WM_MOUSEWHEEL             = 522
+	WM_MOUSEHWHEEL            = 526
 	WM_MOUSEFIRST             = 512
 	WM_XBUTTONDOWN            = 523
 	WM_XBUTTONUP              = 524
@@ -890,6 +774,27 @@ const (
 	WM_UNICHAR                = 0x0109
 )
 
+// WM_ENTERIDLE wParam values
+const (
+	MSGF_DIALOGBOX  = 0
+	MSGF_MESSAGEBOX = 1
+	MSGF_MENU       = 2
+	MSGF_SCROLLBAR  = 5
+	MSGF_NEXTWINDOW = 6
+)
+
+// WM_SIZE wParam values
+const (
+	SIZE_RESTORED  = 0
+	SIZE_MINIMIZED = 1
+	SIZE_MAXIMIZED = 2
+	SIZE_MAXSHOW   = 3
+	SIZE_MAXHIDE   = 4
+)
+
+// WM_UNICHAR wParam value
+const UNICODE_NOCHAR = 0xFFFF
+
 const (
 	CHILDID_SELF      = 0
 	INDEXID_OBJECT    = 0
@@ -1024,6 +929,65 @@ const (
 	MK_XBUTTON2 = 0x0040
 )
 
+// WM_HOTKEY ids reserved by the system
+const (
+	IDHOT_SNAPWINDOW  = -1
+	IDHOT_SNAPDESKTOP = -2
+)
+
+// SetWindowsHookEx hook types
+const (
+	WH_MSGFILTER       = -1
+	WH_JOURNALRECORD   = 0
+	WH_JOURNALPLAYBACK = 1
+	WH_KEYBOARD        = 2
+	WH_GETMESSAGE      = 3
+	WH_CALLWNDPROC     = 4
+	WH_CBT             = 5
+	WH_SYSMSGFILTER    = 6
+	WH_MOUSE           = 7
+	WH_DEBUG           = 9
+	WH_SHELL           = 10
+	WH_FOREGROUNDIDLE  = 11
+	WH_CALLWNDPROCRET  = 12
+	WH_KEYBOARD_LL     = 13
+	WH_MOUSE_LL        = 14
+)
+
+// Hook codes
+const (
+	HC_ACTION      = 0
+	HC_GETNEXT     = 1
+	HC_SKIP        = 2
+	HC_NOREMOVE    = 3
+	HC_SYSMODALON  = 4
+	HC_SYSMODALOFF = 5
+)
+
+// KBDLLHOOKSTRUCT flags
+const (
+	LLKHF_EXTENDED          = 0x01
+	LLKHF_LOWER_IL_INJECTED = 0x02
+	LLKHF_INJECTED          = 0x10
+	LLKHF_ALTDOWN           = 0x20
+	LLKHF_UP                = 0x80
+)
+
+// MSLLHOOKSTRUCT flags
+const (
+	LLMHF_INJECTED          = 0x01
+	LLMHF_LOWER_IL_INJECTED = 0x02
+)
+
+// DPI_AWARENESS_CONTEXT values
+const (
+	DPI_AWARENESS_CONTEXT_UNAWARE              = ^DPI_AWARENESS_CONTEXT(0) // -1
+	DPI_AWARENESS_CONTEXT_SYSTEM_AWARE         = ^DPI_AWARENESS_CONTEXT(1) // -2
+	DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE    = ^DPI_AWARENESS_CONTEXT(2) // -3
+	DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2 = ^DPI_AWARENESS_CONTEXT(3) // -4
+	DPI_AWARENESS_CONTEXT_UNAWARE_GDISCALED    = ^DPI_AWARENESS_CONTEXT(4) // -5
+)
+
 // TrackPopupMenu[Ex] flags
 const (
 	TPM_CENTERALIGN     = 0x0004
@@ -1101,28 +1065,11 @@ const (
 
 // SystemParametersInfo actions
 const (
+	SPI_GETICONTITLELOGFONT = 0x001F
 	SPI_GETNONCLIENTMETRICS = 0x0029
 	SPI_GETHIGHCONTRAST     = 0x0042
-)
-
-// Dialog styles
-const (
-	DS_ABSALIGN      = 0x0001
-	DS_SYSMODAL      = 0x0002
-	DS_3DLOOK        = 0x0004
-	DS_FIXEDSYS      = 0x0008
-	DS_NOFAILCREATE  = 0x0010
-	DS_LOCALEDIT     = 0x0020
-	DS_SETFONT       = 0x0040
-	DS_MODALFRAME    = 0x0080
-	DS_NOIDLEMSG     = 0x0100
-	DS_SETFOREGROUND = 0x0200
-	DS_CONTROL       = 0x0400
-	DS_CENTER        = 0x0800
-	DS_CENTERMOUSE   = 0x1000
-	DS_CONTEXTHELP   = 0x2000
-	DS_USEPIXELS     = 0x8000
-	DS_SHELLFONT     = (DS_SETFONT | DS_FIXEDSYS)
+	SPI_GETWHEELSCROLLLINES = 0x0068
+	SPI_GETWHEELSCROLLCHARS = 0x006C
 )
 
 // WM_GETDLGCODE return values
@@ -1147,22 +1094,38 @@ const (
 	WA_INACTIVE    = 0
 )
 
+// Owner draw control types
+const (
+	ODT_MENU     = 1
+	ODT_LISTBOX  = 2
+	ODT_COMBOBOX = 3
+	ODT_BUTTON   = 4
+	ODT_STATIC   = 5
+	ODT_HEADER   = 100
+	ODT_TAB      = 101
+	ODT_LISTVIEW = 102
+)
+
 // Owner drawing actions
 const (
 	ODA_DRAWENTIRE = 0x0001
-	ODA_FOCUS      = 0x0002
-	ODA_SELECT     = 0x0004
+	ODA_SELECT     = 0x0002
+	ODA_FOCUS      = 0x0004
 )
 
 // Owner drawing states
 const (
-	ODS_CHECKED      = 0x0001
-	ODS_COMBOBOXEDIT = 0x0002
-	ODS_DEFAULT      = 0x0004
-	ODS_DISABLED     = 0x0008
+	ODS_SELECTED     = 0x0001
+	ODS_GRAYED       = 0x0002
+	ODS_DISABLED     = 0x0004
+	ODS_CHECKED      = 0x0008
 	ODS_FOCUS        = 0x0010
-	ODS_GRAYED       = 0x0020
-	ODS_SELECTED     = 0x0040
+	ODS_DEFAULT      = 0x0020
+	ODS_HOTLIGHT     = 0x0040
+	ODS_INACTIVE     = 0x0080
+	ODS_NOACCEL      = 0x0100
+	ODS_NOFOCUSRECT  = 0x0200
+	ODS_COMBOBOXEDIT = 0x1000
 )
 
 // Raw input device flags
@@ -1185,48 +1148,33 @@ const (
 	RID_INPUT  = 0x10000003
 )
 
-// Raw input type
-const (
-	RIM_TYPEHID      = 2
-	RIM_TYPEKEYBOARD = 1
-	RIM_TYPEMOUSE    = 0
-)
-
-// Raw input scan code information
+// GetRawInputDeviceInfo commands
 const (
-	RI_KEY_MAKE  = 0
-	RI_KEY_BREAK = 1
-	RI_KEY_E0    = 2
-	RI_KEY_E1    = 4
+	RIDI_PREPARSEDDATA = 0x20000005
+	RIDI_DEVICENAME    = 0x20000007
+	RIDI_DEVICEINFO    = 0x2000000b
 )
 
-// Raw input mouse state
+// WM_INPUT_DEVICE_CHANGE wParam values
 const (
-	MOUSE_MOVE_RELATIVE      = 0x00
-	MOUSE_MOVE_ABSOLUTE      = 0x01
-	MOUSE_VIRTUAL_DESKTOP    = 0x02
-	MOUSE_ATTRIBUTES_CHANGED = 0x04
+	GIDC_ARRIVAL = 1
+	GIDC_REMOVAL = 2
 )
 
-// Raw input transistion state of mouse buttons
+// HID usage pages and usages for RAWINPUTDEVICE
 const (
-	RI_MOUSE_LEFT_BUTTON_DOWN   = 0x0001
-	RI_MOUSE_LEFT_BUTTON_UP     = 0x0002
-	RI_MOUSE_MIDDLE_BUTTON_DOWN = 0x0010
-	RI_MOUSE_MIDDLE_BUTTON_UP   = 0x0020
-	RI_MOUSE_RIGHT_BUTTON_DOWN  = 0x0004
-	RI_MOUSE_RIGHT_BUTTON_UP    = 0x0008
-	RI_MOUSE_BUTTON_1_DOWN      = 0x0001
-	RI_MOUSE_BUTTON_1_UP        = 0x0002
-	RI_MOUSE_BUTTON_2_DOWN      = 0x0004
-	RI_MOUSE_BUTTON_2_UP        = 0x0008
-	RI_MOUSE_BUTTON_3_DOWN      = 0x0010
-	RI_MOUSE_BUTTON_3_UP        = 0x0020
-	RI_MOUSE_BUTTON_4_DOWN      = 0x0040
-	RI_MOUSE_BUTTON_4_UP        = 0x0080
-	RI_MOUSE_BUTTON_5_DOWN      = 0x0100
-	RI_MOUSE_BUTTON_5_UP        = 0x0200
-	RI_MOUSE_WHEEL              = 0x0400
+	HID_USAGE_PAGE_GENERIC         = 0x01
+	HID_USAGE_PAGE_GAME            = 0x05
+	HID_USAGE_PAGE_LED             = 0x08
+	HID_USAGE_PAGE_BUTTON          = 0x09
+	HID_USAGE_PAGE_DIGITIZER       = 0x0D
+	HID_USAGE_PAGE_BARCODE_SCANNER = 0x8C
+	HID_USAGE_GENERIC_POINTER      = 0x01
+	HID_USAGE_GENERIC_MOUSE        = 0x02
+	HID_USAGE_GENERIC_JOYSTICK     = 0x04
+	HID_USAGE_GENERIC_GAMEPAD      = 0x05
+	HID_USAGE_GENERIC_KEYBOARD     = 0x06
+	HID_USAGE_GENERIC_KEYPAD       = 0x07
 )
 
 // Multi monitor constants
@@ -1241,45 +1189,6 @@ const (
 	MONITORINFOF_PRIMARY = 0x1
 )
 
-// INPUT Type
-const (
-	INPUT_MOUSE    = 0
-	INPUT_KEYBOARD = 1
-	INPUT_HARDWARE = 2
-)
-
-// MOUSEINPUT MouseData
-const (
-	XBUTTON1 = 0x0001
-	XBUTTON2 = 0x0002
-)
-
-// MOUSEINPUT DwFlags
-const (
-	MOUSEEVENTF_ABSOLUTE        = 0x8000
-	MOUSEEVENTF_HWHEEL          = 0x1000
-	MOUSEEVENTF_MOVE            = 0x0001
-	MOUSEEVENTF_MOVE_NOCOALESCE = 0x2000
-	MOUSEEVENTF_LEFTDOWN        = 0x0002
-	MOUSEEVENTF_LEFTUP          = 0x0004
-	MOUSEEVENTF_RIGHTDOWN       = 0x0008
-	MOUSEEVENTF_RIGHTUP         = 0x0010
-	MOUSEEVENTF_MIDDLEDOWN      = 0x0020
-	MOUSEEVENTF_MIDDLEUP        = 0x0040
-	MOUSEEVENTF_VIRTUALDESK     = 0x4000
-	MOUSEEVENTF_WHEEL           = 0x0800
-	MOUSEEVENTF_XDOWN           = 0x0080
-	MOUSEEVENTF_XUP             = 0x0100
-)
-
-// KEYBDINPUT DwFlags
-const (
-	KEYEVENTF_EXTENDEDKEY = 0x0001
-	KEYEVENTF_KEYUP       = 0x0002
-	KEYEVENTF_SCANCODE    = 0x0008
-	KEYEVENTF_UNICODE     = 0x0004
-)
-
 // GetWindow uCmd constants
 const (
 	GW_CHILD        = 5
@@ -1329,25 +1238,6 @@ const (
 	SB_BOTH = 3
 )
 
-// ScrollBar commands
-const (
-	SB_LINEUP        = 0
-	SB_LINELEFT      = 0
-	SB_LINEDOWN      = 1
-	SB_LINERIGHT     = 1
-	SB_PAGEUP        = 2
-	SB_PAGELEFT      = 2
-	SB_PAGEDOWN      = 3
-	SB_PAGERIGHT     = 3
-	SB_THUMBPOSITION = 4
-	SB_THUMBTRACK    = 5
-	SB_TOP           = 6
-	SB_LEFT          = 6
-	SB_BOTTOM        = 7
-	SB_RIGHT         = 7
-	SB_ENDSCROLL     = 8
-)
-
 // [Get|Set]ScrollInfo mask constants
 const (
 	SIF_RANGE           = 1
@@ -1358,6 +1248,14 @@ const (
 	SIF_ALL             = SIF_RANGE + SIF_PAGE + SIF_POS + SIF_TRACKPOS
 )
 
+// ScrollWindowEx flags
+const (
+	SW_SCROLLCHILDREN = 0x0001
+	SW_INVALIDATE     = 0x0002
+	SW_ERASE          = 0x0004
+	SW_SMOOTHSCROLL   = 0x0010
+)
+
 // DrawIconEx flags
 const (
 	DI_COMPAT      = 0x0004
@@ -1469,16 +1367,12 @@ type MONITORINFO struct {
 	DwFlags   uint32
 }
 
-type (
-	HACCEL    HANDLE
-	HCURSOR   HANDLE
-	HDWP      HANDLE
-	HICON     HANDLE
-	HMENU     HANDLE
-	HMONITOR  HANDLE
-	HRAWINPUT HANDLE
-	HWND      HANDLE
-)
+type MONITORINFOEX struct {
+	MONITORINFO
+	SzDevice [CCHDEVICENAME]uint16
+}
+
+type DPI_AWARENESS_CONTEXT HANDLE
 
 type MSG struct {
 	HWnd    HWND
@@ -1489,6 +1383,27 @@ type MSG struct {
 	Pt      POINT
 }
 
+type KBDLLHOOKSTRUCT struct {
+	VkCode      uint32
+	ScanCode    uint32
+	Flags       uint32
+	Time        uint32
+	DwExtraInfo uintptr
+}
+
+type MSLLHOOKSTRUCT struct {
+	Pt          POINT
+	MouseData   uint32
+	Flags       uint32
+	Time        uint32
+	DwExtraInfo uintptr
+}
+
+type LASTINPUTINFO struct {
+	CbSize uint32
+	DwTime uint32
+}
+
 type RAWINPUTDEVICE struct {
 	UsUsagePage uint16
 	UsUsage     uint16
@@ -1520,9 +1435,9 @@ type RAWINPUTHID struct {
 
 type RAWMOUSE struct {
 	UsFlags            uint16
+	Pad_cgo_0          [2]byte
 	UsButtonFlags      uint16
 	UsButtonData       uint16
-	Pad_cgo_0          [2]byte
 	UlRawButtons       uint32
 	LLastX             int32
 	LLastY             int32
@@ -1544,6 +1459,53 @@ type RAWHID struct {
 	BRawData  [1]byte
 }
 
+type RAWINPUTDEVICELIST struct {
+	HDevice HANDLE
+	DwType  uint32
+}
+
+type RID_DEVICE_INFO_MOUSE struct {
+	DwId                uint32
+	DwNumberOfButtons   uint32
+	DwSampleRate        uint32
+	FHasHorizontalWheel BOOL
+}
+
+type RID_DEVICE_INFO_KEYBOARD struct {
+	DwType                 uint32
+	DwSubType              uint32
+	DwKeyboardMode         uint32
+	DwNumberOfFunctionKeys uint32
+	DwNumberOfIndicators   uint32
+	DwNumberOfKeysTotal    uint32
+}
+
+type RID_DEVICE_INFO_HID struct {
+	DwVendorId      uint32
+	DwProductId     uint32
+	DwVersionNumber uint32
+	UsUsagePage     uint16
+	UsUsage         uint16
+}
+
+type RID_DEVICE_INFO struct {
+	CbSize uint32
+	DwType uint32
+	union  [24]byte
+}
+
+func (di *RID_DEVICE_INFO) Mouse() *RID_DEVICE_INFO_MOUSE {
+	return (*RID_DEVICE_INFO_MOUSE)(unsafe.Pointer(&di.union[0]))
+}
+
+func (di *RID_DEVICE_INFO) Keyboard() *RID_DEVICE_INFO_KEYBOARD {
+	return (*RID_DEVICE_INFO_KEYBOARD)(unsafe.Pointer(&di.union[0]))
+}
+
+func (di *RID_DEVICE_INFO) Hid() *RID_DEVICE_INFO_HID {
+	return (*RID_DEVICE_INFO_HID)(unsafe.Pointer(&di.union[0]))
+}
+
 type NMHDR struct {
 	HwndFrom HWND
 	IdFrom   uintptr
@@ -1670,46 +1632,6 @@ type ICONINFO struct {
 	HbmColor HBITMAP
 }
 
-type MOUSE_INPUT struct {
-	Type uint32
-	Mi   MOUSEINPUT
-}
-
-type MOUSEINPUT struct {
-	Dx          int32
-	Dy          int32
-	MouseData   uint32
-	DwFlags     uint32
-	Time        uint32
-	DwExtraInfo uintptr
-}
-
-type KEYBD_INPUT struct {
-	Type uint32
-	Ki   KEYBDINPUT
-}
-
-type KEYBDINPUT struct {
-	WVk         uint16
-	WScan       uint16
-	DwFlags     uint32
-	Time        uint32
-	DwExtraInfo uintptr
-	Unused      [8]byte
-}
-
-type HARDWARE_INPUT struct {
-	Type uint32
-	Hi   HARDWAREINPUT
-}
-
-type HARDWAREINPUT struct {
-	UMsg    uint32
-	WParamL uint16
-	WParamH uint16
-	Unused  [16]byte
-}
-
 type SCROLLINFO struct {
 	CbSize    uint32
 	FMask     uint32
@@ -1743,12 +1665,74 @@ type HIGHCONTRAST struct {
 	LpszDefaultScheme *uint16
 }
 
-func GET_X_LPARAM(lp uintptr) int32 {
-	return int32(int16(LOWORD(uint32(lp))))
+type COPYDATASTRUCT struct {
+	DwData uintptr
+	CbData uint32
+	LpData unsafe.Pointer
+}
+
+type HELPINFO struct {
+	CbSize       uint32
+	IContextType int32
+	ICtrlId      int32
+	HItemHandle  HANDLE
+	DwContextId  uintptr
+	MousePos     POINT
+}
+
+type STYLESTRUCT struct {
+	StyleOld uint32
+	StyleNew uint32
+}
+
+type NCCALCSIZE_PARAMS struct {
+	Rgrc  [3]RECT
+	Lppos *WINDOWPOS
 }
 
-func GET_Y_LPARAM(lp uintptr) int32 {
-	return int32(int16(HIWORD(uint32(lp))))
+type COMPAREITEMSTRUCT struct {
+	CtlType    uint32
+	CtlID      uint32
+	HwndItem   HWND
+	ItemID1    uint32
+	ItemData1  uintptr
+	ItemID2    uint32
+	ItemData2  uintptr
+	DwLocaleId uint32
+}
+
+type DELETEITEMSTRUCT struct {
+	CtlType  uint32
+	CtlID    uint32
+	ItemID   uint32
+	HwndItem HWND
+	ItemData uintptr
+}
+
+type MDINEXTMENU struct {
+	HmenuIn   HMENU
+	HmenuNext HMENU
+	HwndNext  HWND
+}
+
+type MDICREATESTRUCT struct {
+	SzClass *uint16
+	SzTitle *uint16
+	HOwner  HANDLE
+	X       int32
+	Y       int32
+	Cx      int32
+	Cy      int32
+	Style   uint32
+	LParam  uintptr
+}
+
+type MENUGETOBJECTINFO struct {
+	DwFlags uint32
+	UPos    uint32
+	Hmenu   HMENU
+	Riid    unsafe.Pointer
+	PvObj   unsafe.Pointer
 }
 
 var (
@@ -1756,149 +1740,192 @@ var (
 	libuser32 *windows.LazyDLL
 
 	// Functions
-	addClipboardFormatListener  *windows.LazyProc
-	adjustWindowRect            *windows.LazyProc
-	attachThreadInput           *windows.LazyProc
-	animateWindow               *windows.LazyProc
-	beginDeferWindowPos         *windows.LazyProc
-	beginPaint                  *windows.LazyProc
-	bringWindowToTop            *windows.LazyProc
-	callWindowProc              *windows.LazyProc
-	changeWindowMessageFilterEx *windows.LazyProc
-	checkMenuRadioItem          *windows.LazyProc
-	clientToScreen              *windows.LazyProc
-	closeClipboard              *windows.LazyProc
-	createDialogParam           *windows.LazyProc
-	createIconIndirect          *windows.LazyProc
-	createMenu                  *windows.LazyProc
-	createPopupMenu             *windows.LazyProc
-	createWindowEx              *windows.LazyProc
-	deferWindowPos              *windows.LazyProc
-	defWindowProc               *windows.LazyProc
-	deleteMenu                  *windows.LazyProc
-	destroyIcon                 *windows.LazyProc
-	destroyMenu                 *windows.LazyProc
-	destroyWindow               *windows.LazyProc
-	dialogBoxParam              *windows.LazyProc
-	dispatchMessage             *windows.LazyProc
-	drawIconEx                  *windows.LazyProc
-	drawMenuBar                 *windows.LazyProc
-	drawFocusRect               *windows.LazyProc
-	drawTextEx                  *windows.LazyProc
-	emptyClipboard              *windows.LazyProc
-	enableMenuItem              *windows.LazyProc
-	enableWindow                *windows.LazyProc
-	endDeferWindowPos           *windows.LazyProc
-	endDialog                   *windows.LazyProc
-	endPaint                    *windows.LazyProc
-	enumChildWindows            *windows.LazyProc
-	findWindow                  *windows.LazyProc
-	getActiveWindow             *windows.LazyProc
-	getAncestor                 *windows.LazyProc
-	getCaretPos                 *windows.LazyProc
-	getClassName                *windows.LazyProc
-	getClientRect               *windows.LazyProc
-	getClipboardData            *windows.LazyProc
-	getCursorPos                *windows.LazyProc
-	getDC                       *windows.LazyProc
-	getDesktopWindow            *windows.LazyProc
-	getDlgItem                  *windows.LazyProc
-	getDpiForWindow             *windows.LazyProc
-	getFocus                    *windows.LazyProc
-	getForegroundWindow         *windows.LazyProc
-	getIconInfo                 *windows.LazyProc
-	getKeyState                 *windows.LazyProc
-	getMenuCheckMarkDimensions  *windows.LazyProc
-	getMenuInfo                 *windows.LazyProc
-	getMenuItemCount            *windows.LazyProc
-	getMenuItemID               *windows.LazyProc
-	getMenuItemInfo             *windows.LazyProc
-	getMessage                  *windows.LazyProc
-	getMonitorInfo              *windows.LazyProc
-	getParent                   *windows.LazyProc
-	getRawInputData             *windows.LazyProc
-	getScrollInfo               *windows.LazyProc
-	getSubMenu                  *windows.LazyProc
-	getSysColor                 *windows.LazyProc
-	getSysColorBrush            *windows.LazyProc
-	getSystemMenu               *windows.LazyProc
-	getSystemMetrics            *windows.LazyProc
-	getSystemMetricsForDpi      *windows.LazyProc
-	getWindow                   *windows.LazyProc
-	getWindowLong               *windows.LazyProc
-	getWindowLongPtr            *windows.LazyProc
-	getWindowPlacement          *windows.LazyProc
-	getWindowRect               *windows.LazyProc
-	getWindowThreadProcessId    *windows.LazyProc
-	insertMenuItem              *windows.LazyProc
-	invalidateRect              *windows.LazyProc
-	isChild                     *windows.LazyProc
-	isClipboardFormatAvailable  *windows.LazyProc
-	isDialogMessage             *windows.LazyProc
-	isIconic                    *windows.LazyProc
-	isWindowEnabled             *windows.LazyProc
-	isWindowVisible             *windows.LazyProc
-	isZoomed                    *windows.LazyProc
-	killTimer                   *windows.LazyProc
-	loadCursor                  *windows.LazyProc
-	loadIcon                    *windows.LazyProc
-	loadImage                   *windows.LazyProc
-	loadMenu                    *windows.LazyProc
-	loadString                  *windows.LazyProc
-	messageBeep                 *windows.LazyProc
-	messageBox                  *windows.LazyProc
-	monitorFromWindow           *windows.LazyProc
-	moveWindow                  *windows.LazyProc
-	notifyWinEvent              *windows.LazyProc
-	unregisterClass             *windows.LazyProc
-	openClipboard               *windows.LazyProc
-	peekMessage                 *windows.LazyProc
-	postMessage                 *windows.LazyProc
-	postQuitMessage             *windows.LazyProc
-	redrawWindow                *windows.LazyProc
-	registerClassEx             *windows.LazyProc
-	registerRawInputDevices     *windows.LazyProc
-	registerWindowMessage       *windows.LazyProc
-	releaseCapture              *windows.LazyProc
-	releaseDC                   *windows.LazyProc
-	removeMenu                  *windows.LazyProc
-	screenToClient              *windows.LazyProc
-	sendDlgItemMessage          *windows.LazyProc
-	sendInput                   *windows.LazyProc
-	sendMessage                 *windows.LazyProc
-	setActiveWindow             *windows.LazyProc
-	setCapture                  *windows.LazyProc
-	setClipboardData            *windows.LazyProc
-	setCursor                   *windows.LazyProc
-	setCursorPos                *windows.LazyProc
-	setFocus                    *windows.LazyProc
-	setForegroundWindow         *windows.LazyProc
-	setMenu                     *windows.LazyProc
-	setMenuDefaultItem          *windows.LazyProc
-	setMenuInfo                 *windows.LazyProc
-	setMenuItemBitmaps          *windows.LazyProc
-	setMenuItemInfo             *windows.LazyProc
-	setParent                   *windows.LazyProc
-	setRect                     *windows.LazyProc
-	setScrollInfo               *windows.LazyProc
-	setTimer                    *windows.LazyProc
-	setWinEventHook             *windows.LazyProc
-	setWindowLong               *windows.LazyProc
-	setWindowLongPtr            *windows.LazyProc
-	setWindowPlacement          *windows.LazyProc
-	setWindowPos                *windows.LazyProc
-	showWindow                  *windows.LazyProc
-	systemParametersInfo        *windows.LazyProc
-	trackMouseEvent             *windows.LazyProc
-	trackPopupMenu              *windows.LazyProc
-	trackPopupMenuEx            *windows.LazyProc
-	translateMessage            *windows.LazyProc
-	unhookWinEvent              *windows.LazyProc
-	updateWindow                *windows.LazyProc
-	windowFromDC                *windows.LazyProc
-	windowFromPoint             *windows.LazyProc
-	getWindowStyle              *windows.LazyProc
-	setWindowRgn                *windows.LazyProc
+	addClipboardFormatListener    *windows.LazyProc
+	adjustWindowRect              *windows.LazyProc
+	adjustWindowRectEx            *windows.LazyProc
+	adjustWindowRectExForDpi      *windows.LazyProc
+	attachThreadInput             *windows.LazyProc
+	animateWindow                 *windows.LazyProc
+	beginDeferWindowPos           *windows.LazyProc
+	beginPaint                    *windows.LazyProc
+	bringWindowToTop              *windows.LazyProc
+	callNextHookEx                *windows.LazyProc
+	callWindowProc                *windows.LazyProc
+	changeWindowMessageFilterEx   *windows.LazyProc
+	checkMenuItem                 *windows.LazyProc
+	checkMenuRadioItem            *windows.LazyProc
+	clientToScreen                *windows.LazyProc
+	closeClipboard                *windows.LazyProc
+	countClipboardFormats         *windows.LazyProc
+	createAcceleratorTable        *windows.LazyProc
+	createDialogIndirectParam     *windows.LazyProc
+	createDialogParam             *windows.LazyProc
+	createIconFromResourceEx      *windows.LazyProc
+	createIconIndirect            *windows.LazyProc
+	createMenu                    *windows.LazyProc
+	createPopupMenu               *windows.LazyProc
+	createWindowEx                *windows.LazyProc
+	deferWindowPos                *windows.LazyProc
+	defWindowProc                 *windows.LazyProc
+	deleteMenu                    *windows.LazyProc
+	destroyAcceleratorTable       *windows.LazyProc
+	destroyIcon                   *windows.LazyProc
+	destroyMenu                   *windows.LazyProc
+	destroyWindow                 *windows.LazyProc
+	dialogBoxIndirectParam        *windows.LazyProc
+	dialogBoxParam                *windows.LazyProc
+	dispatchMessage               *windows.LazyProc
+	drawIconEx                    *windows.LazyProc
+	drawMenuBar                   *windows.LazyProc
+	drawFocusRect                 *windows.LazyProc
+	drawTextEx                    *windows.LazyProc
+	emptyClipboard                *windows.LazyProc
+	enableMenuItem                *windows.LazyProc
+	enableNonClientDpiScaling     *windows.LazyProc
+	enableWindow                  *windows.LazyProc
+	endDeferWindowPos             *windows.LazyProc
+	endDialog                     *windows.LazyProc
+	endPaint                      *windows.LazyProc
+	enumChildWindows              *windows.LazyProc
+	enumClipboardFormats          *windows.LazyProc
+	enumDisplayMonitors           *windows.LazyProc
+	enumThreadWindows             *windows.LazyProc
+	enumWindows                   *windows.LazyProc
+	findWindow                    *windows.LazyProc
+	findWindowEx                  *windows.LazyProc
+	getActiveWindow               *windows.LazyProc
+	getAncestor                   *windows.LazyProc
+	getCaretPos                   *windows.LazyProc
+	getClassLongPtr               *windows.LazyProc
+	getClassName                  *windows.LazyProc
+	getClientRect                 *windows.LazyProc
+	getClipboardData              *windows.LazyProc
+	getClipboardFormatName        *windows.LazyProc
+	getClipboardOwner             *windows.LazyProc
+	getClipboardSequenceNumber    *windows.LazyProc
+	getCursorPos                  *windows.LazyProc
+	getDC                         *windows.LazyProc
+	getDesktopWindow              *windows.LazyProc
+	getDlgItem                    *windows.LazyProc
+	getDpiForWindow               *windows.LazyProc
+	getFocus                      *windows.LazyProc
+	getForegroundWindow           *windows.LazyProc
+	getIconInfo                   *windows.LazyProc
+	getKeyState                   *windows.LazyProc
+	getLastInputInfo              *windows.LazyProc
+	getMenuCheckMarkDimensions    *windows.LazyProc
+	getMenuInfo                   *windows.LazyProc
+	getMenuItemCount              *windows.LazyProc
+	getMenuItemID                 *windows.LazyProc
+	getMenuItemInfo               *windows.LazyProc
+	getMessage                    *windows.LazyProc
+	getMonitorInfo                *windows.LazyProc
+	getParent                     *windows.LazyProc
+	getRawInputBuffer             *windows.LazyProc
+	getRawInputData               *windows.LazyProc
+	getRawInputDeviceInfo         *windows.LazyProc
+	getRawInputDeviceList         *windows.LazyProc
+	getScrollInfo                 *windows.LazyProc
+	getSubMenu                    *windows.LazyProc
+	getSysColor                   *windows.LazyProc
+	getSysColorBrush              *windows.LazyProc
+	getSystemMenu                 *windows.LazyProc
+	getSystemMetrics              *windows.LazyProc
+	getSystemMetricsForDpi        *windows.LazyProc
+	getThreadDpiAwarenessContext  *windows.LazyProc
+	getWindow                     *windows.LazyProc
+	getWindowLong                 *windows.LazyProc
+	getWindowLongPtr              *windows.LazyProc
+	getWindowPlacement            *windows.LazyProc
+	getWindowRect                 *windows.LazyProc
+	getWindowText                 *windows.LazyProc
+	getWindowTextLength           *windows.LazyProc
+	getWindowThreadProcessId      *windows.LazyProc
+	insertMenuItem                *windows.LazyProc
+	invalidateRect                *windows.LazyProc
+	isChild                       *windows.LazyProc
+	isClipboardFormatAvailable    *windows.LazyProc
+	isDialogMessage               *windows.LazyProc
+	isIconic                      *windows.LazyProc
+	isWindow                      *windows.LazyProc
+	isWindowEnabled               *windows.LazyProc
+	isWindowVisible               *windows.LazyProc
+	isZoomed                      *windows.LazyProc
+	killTimer                     *windows.LazyProc
+	loadCursor                    *windows.LazyProc
+	loadIcon                      *windows.LazyProc
+	loadImage                     *windows.LazyProc
+	loadMenu                      *windows.LazyProc
+	loadString                    *windows.LazyProc
+	messageBeep                   *windows.LazyProc
+	messageBox                    *windows.LazyProc
+	monitorFromRect               *windows.LazyProc
+	monitorFromWindow             *windows.LazyProc
+	moveWindow                    *windows.LazyProc
+	notifyWinEvent                *windows.LazyProc
+	registerClipboardFormat       *windows.LazyProc
+	registerHotKey                *windows.LazyProc
+	removeClipboardFormatListener *windows.LazyProc
+	scrollWindowEx                *windows.LazyProc
+	setCoalescableTimer           *windows.LazyProc
+	setProcessDPIAware            *windows.LazyProc
+	setProcessDpiAwarenessContext *windows.LazyProc
+	setThreadDpiAwarenessContext  *windows.LazyProc
+	setWindowsHookEx              *windows.LazyProc
+	showScrollBar                 *windows.LazyProc
+	systemParametersInfoForDpi    *windows.LazyProc
+	translateAccelerator          *windows.LazyProc
+	unhookWindowsHookEx           *windows.LazyProc
+	unregisterClass               *windows.LazyProc
+	openClipboard                 *windows.LazyProc
+	peekMessage                   *windows.LazyProc
+	postMessage                   *windows.LazyProc
+	postQuitMessage               *windows.LazyProc
+	redrawWindow                  *windows.LazyProc
+	registerClassEx               *windows.LazyProc
+	registerRawInputDevices       *windows.LazyProc
+	registerWindowMessage         *windows.LazyProc
+	releaseCapture                *windows.LazyProc
+	releaseDC                     *windows.LazyProc
+	removeMenu                    *windows.LazyProc
+	screenToClient                *windows.LazyProc
+	sendDlgItemMessage            *windows.LazyProc
+	sendInput                     *windows.LazyProc
+	sendMessage                   *windows.LazyProc
+	setActiveWindow               *windows.LazyProc
+	setCapture                    *windows.LazyProc
+	setClipboardData              *windows.LazyProc
+	setCursor                     *windows.LazyProc
+	setCursorPos                  *windows.LazyProc
+	setFocus                      *windows.LazyProc
+	setForegroundWindow           *windows.LazyProc
+	setMenu                       *windows.LazyProc
+	setMenuDefaultItem            *windows.LazyProc
+	setMenuInfo                   *windows.LazyProc
+	setMenuItemBitmaps            *windows.LazyProc
+	setMenuItemInfo               *windows.LazyProc
+	setParent                     *windows.LazyProc
+	setRect                       *windows.LazyProc
+	setScrollInfo                 *windows.LazyProc
+	setTimer                      *windows.LazyProc
+	setWinEventHook               *windows.LazyProc
+	setWindowLong                 *windows.LazyProc
+	setWindowLongPtr              *windows.LazyProc
+	setWindowPlacement            *windows.LazyProc
+	setWindowPos                  *windows.LazyProc
+	showWindow                    *windows.LazyProc
+	systemParametersInfo          *windows.LazyProc
+	trackMouseEvent               *windows.LazyProc
+	trackPopupMenu                *windows.LazyProc
+	trackPopupMenuEx              *windows.LazyProc
+	translateMessage              *windows.LazyProc
+	unhookWinEvent                *windows.LazyProc
+	unregisterHotKey              *windows.LazyProc
+	updateWindow                  *windows.LazyProc
+	windowFromDC                  *windows.LazyProc
+	windowFromPoint               *windows.LazyProc
+	getWindowStyle                *windows.LazyProc
+	setWindowRgn                  *windows.LazyProc
 )
 
 func init() {
@@ -1910,17 +1937,25 @@ func init() {
 	// Functions
 	addClipboardFormatListener = libuser32.NewProc("AddClipboardFormatListener")
 	adjustWindowRect = libuser32.NewProc("AdjustWindowRect")
+	adjustWindowRectEx = libuser32.NewProc("AdjustWindowRectEx")
+	adjustWindowRectExForDpi = libuser32.NewProc("AdjustWindowRectExForDpi")
 	attachThreadInput = libuser32.NewProc("AttachThreadInput")
 	animateWindow = libuser32.NewProc("AnimateWindow")
 	beginDeferWindowPos = libuser32.NewProc("BeginDeferWindowPos")
 	beginPaint = libuser32.NewProc("BeginPaint")
 	bringWindowToTop = libuser32.NewProc("BringWindowToTop")
+	callNextHookEx = libuser32.NewProc("CallNextHookEx")
 	callWindowProc = libuser32.NewProc("CallWindowProcW")
 	changeWindowMessageFilterEx = libuser32.NewProc("ChangeWindowMessageFilterEx")
+	checkMenuItem = libuser32.NewProc("CheckMenuItem")
 	checkMenuRadioItem = libuser32.NewProc("CheckMenuRadioItem")
 	clientToScreen = libuser32.NewProc("ClientToScreen")
 	closeClipboard = libuser32.NewProc("CloseClipboard")
+	countClipboardFormats = libuser32.NewProc("CountClipboardFormats")
+	createAcceleratorTable = libuser32.NewProc("CreateAcceleratorTableW")
+	createDialogIndirectParam = libuser32.NewProc("CreateDialogIndirectParamW")
 	createDialogParam = libuser32.NewProc("CreateDialogParamW")
+	createIconFromResourceEx = libuser32.NewProc("CreateIconFromResourceEx")
 	createIconIndirect = libuser32.NewProc("CreateIconIndirect")
 	createMenu = libuser32.NewProc("CreateMenu")
 	createPopupMenu = libuser32.NewProc("CreatePopupMenu")
@@ -1928,9 +1963,11 @@ func init() {
 	deferWindowPos = libuser32.NewProc("DeferWindowPos")
 	defWindowProc = libuser32.NewProc("DefWindowProcW")
 	deleteMenu = libuser32.NewProc("DeleteMenu")
+	destroyAcceleratorTable = libuser32.NewProc("DestroyAcceleratorTable")
 	destroyIcon = libuser32.NewProc("DestroyIcon")
 	destroyMenu = libuser32.NewProc("DestroyMenu")
 	destroyWindow = libuser32.NewProc("DestroyWindow")
+	dialogBoxIndirectParam = libuser32.NewProc("DialogBoxIndirectParamW")
 	dialogBoxParam = libuser32.NewProc("DialogBoxParamW")
 	dispatchMessage = libuser32.NewProc("DispatchMessageW")
 	drawIconEx = libuser32.NewProc("DrawIconEx")
@@ -1939,18 +1976,33 @@ func init() {
 	drawTextEx = libuser32.NewProc("DrawTextExW")
 	emptyClipboard = libuser32.NewProc("EmptyClipboard")
 	enableMenuItem = libuser32.NewProc("EnableMenuItem")
+	enableNonClientDpiScaling = libuser32.NewProc("EnableNonClientDpiScaling")
 	enableWindow = libuser32.NewProc("EnableWindow")
 	endDeferWindowPos = libuser32.NewProc("EndDeferWindowPos")
 	endDialog = libuser32.NewProc("EndDialog")
 	endPaint = libuser32.NewProc("EndPaint")
 	enumChildWindows = libuser32.NewProc("EnumChildWindows")
+	enumClipboardFormats = libuser32.NewProc("EnumClipboardFormats")
+	enumDisplayMonitors = libuser32.NewProc("EnumDisplayMonitors")
+	enumThreadWindows = libuser32.NewProc("EnumThreadWindows")
+	enumWindows = libuser32.NewProc("EnumWindows")
 	findWindow = libuser32.NewProc("FindWindowW")
+	findWindowEx = libuser32.NewProc("FindWindowExW")
 	getActiveWindow = libuser32.NewProc("GetActiveWindow")
 	getAncestor = libuser32.NewProc("GetAncestor")
 	getCaretPos = libuser32.NewProc("GetCaretPos")
+	// On 32 bit GetClassLongPtrW is not available
+	if is64bit {
+		getClassLongPtr = libuser32.NewProc("GetClassLongPtrW")
+	} else {
+		getClassLongPtr = libuser32.NewProc("GetClassLongW")
+	}
 	getClassName = libuser32.NewProc("GetClassNameW")
 	getClientRect = libuser32.NewProc("GetClientRect")
 	getClipboardData = libuser32.NewProc("GetClipboardData")
+	getClipboardFormatName = libuser32.NewProc("GetClipboardFormatNameW")
+	getClipboardOwner = libuser32.NewProc("GetClipboardOwner")
+	getClipboardSequenceNumber = libuser32.NewProc("GetClipboardSequenceNumber")
 	getCursorPos = libuser32.NewProc("GetCursorPos")
 	getDC = libuser32.NewProc("GetDC")
 	getDesktopWindow = libuser32.NewProc("GetDesktopWindow")
@@ -1960,6 +2012,7 @@ func init() {
 	getForegroundWindow = libuser32.NewProc("GetForegroundWindow")
 	getIconInfo = libuser32.NewProc("GetIconInfo")
 	getKeyState = libuser32.NewProc("GetKeyState")
+	getLastInputInfo = libuser32.NewProc("GetLastInputInfo")
 	getMenuCheckMarkDimensions = libuser32.NewProc("GetMenuCheckMarkDimensions")
 	getMenuInfo = libuser32.NewProc("GetMenuInfo")
 	getMenuItemCount = libuser32.NewProc("GetMenuItemCount")
@@ -1968,7 +2021,10 @@ func init() {
 	getMessage = libuser32.NewProc("GetMessageW")
 	getMonitorInfo = libuser32.NewProc("GetMonitorInfoW")
 	getParent = libuser32.NewProc("GetParent")
+	getRawInputBuffer = libuser32.NewProc("GetRawInputBuffer")
 	getRawInputData = libuser32.NewProc("GetRawInputData")
+	getRawInputDeviceInfo = libuser32.NewProc("GetRawInputDeviceInfoW")
+	getRawInputDeviceList = libuser32.NewProc("GetRawInputDeviceList")
 	getScrollInfo = libuser32.NewProc("GetScrollInfo")
 	getSubMenu = libuser32.NewProc("GetSubMenu")
 	getSysColor = libuser32.NewProc("GetSysColor")
@@ -1976,6 +2032,7 @@ func init() {
 	getSystemMenu = libuser32.NewProc("GetSystemMenu")
 	getSystemMetrics = libuser32.NewProc("GetSystemMetrics")
 	getSystemMetricsForDpi = libuser32.NewProc("GetSystemMetricsForDpi")
+	getThreadDpiAwarenessContext = libuser32.NewProc("GetThreadDpiAwarenessContext")
 	getWindow = libuser32.NewProc("GetWindow")
 	getWindowLong = libuser32.NewProc("GetWindowLongW")
 	// On 32 bit GetWindowLongPtrW is not available
@@ -1986,6 +2043,8 @@ func init() {
 	}
 	getWindowPlacement = libuser32.NewProc("GetWindowPlacement")
 	getWindowRect = libuser32.NewProc("GetWindowRect")
+	getWindowText = libuser32.NewProc("GetWindowTextW")
+	getWindowTextLength = libuser32.NewProc("GetWindowTextLengthW")
 	getWindowThreadProcessId = libuser32.NewProc("GetWindowThreadProcessId")
 	insertMenuItem = libuser32.NewProc("InsertMenuItemW")
 	invalidateRect = libuser32.NewProc("InvalidateRect")
@@ -1993,6 +2052,7 @@ func init() {
 	isClipboardFormatAvailable = libuser32.NewProc("IsClipboardFormatAvailable")
 	isDialogMessage = libuser32.NewProc("IsDialogMessageW")
 	isIconic = libuser32.NewProc("IsIconic")
+	isWindow = libuser32.NewProc("IsWindow")
 	isWindowEnabled = libuser32.NewProc("IsWindowEnabled")
 	isWindowVisible = libuser32.NewProc("IsWindowVisible")
 	isZoomed = libuser32.NewProc("IsZoomed")
@@ -2004,9 +2064,23 @@ func init() {
 	loadString = libuser32.NewProc("LoadStringW")
 	messageBeep = libuser32.NewProc("MessageBeep")
 	messageBox = libuser32.NewProc("MessageBoxW")
+	monitorFromRect = libuser32.NewProc("MonitorFromRect")
 	monitorFromWindow = libuser32.NewProc("MonitorFromWindow")
 	moveWindow = libuser32.NewProc("MoveWindow")
 	notifyWinEvent = libuser32.NewProc("NotifyWinEvent")
+	registerClipboardFormat = libuser32.NewProc("RegisterClipboardFormatW")
+	registerHotKey = libuser32.NewProc("RegisterHotKey")
+	removeClipboardFormatListener = libuser32.NewProc("RemoveClipboardFormatListener")
+	scrollWindowEx = libuser32.NewProc("ScrollWindowEx")
+	setCoalescableTimer = libuser32.NewProc("SetCoalescableTimer")
+	setProcessDPIAware = libuser32.NewProc("SetProcessDPIAware")
+	setProcessDpiAwarenessContext = libuser32.NewProc("SetProcessDpiAwarenessContext")
+	setThreadDpiAwarenessContext = libuser32.NewProc("SetThreadDpiAwarenessContext")
+	setWindowsHookEx = libuser32.NewProc("SetWindowsHookExW")
+	showScrollBar = libuser32.NewProc("ShowScrollBar")
+	systemParametersInfoForDpi = libuser32.NewProc("SystemParametersInfoForDpi")
+	translateAccelerator = libuser32.NewProc("TranslateAcceleratorW")
+	unhookWindowsHookEx = libuser32.NewProc("UnhookWindowsHookEx")
 	unregisterClass = libuser32.NewProc("UnregisterClassW")
 	openClipboard = libuser32.NewProc("OpenClipboard")
 	peekMessage = libuser32.NewProc("PeekMessageW")
@@ -2056,6 +2130,7 @@ func init() {
 	trackPopupMenuEx = libuser32.NewProc("TrackPopupMenuEx")
 	translateMessage = libuser32.NewProc("TranslateMessage")
 	unhookWinEvent = libuser32.NewProc("UnhookWinEvent")
+	unregisterHotKey = libuser32.NewProc("UnregisterHotKey")
 	updateWindow = libuser32.NewProc("UpdateWindow")
 	windowFromDC = libuser32.NewProc("WindowFromDC")
 	windowFromPoint = libuser32.NewProc("WindowFromPoint")
@@ -2087,6 +2162,34 @@ func AdjustWindowRect(lpRect *RECT, dwStyle uint32, bMenu bool) bool {
 	return ret != 0
 }
 
+func AdjustWindowRectEx(lpRect *RECT, dwStyle uint32, bMenu bool, dwExStyle uint32) bool {
+	ret, _, _ := syscall.Syscall6(adjustWindowRectEx.Addr(), 4,
+		uintptr(unsafe.Pointer(lpRect)),
+		uintptr(dwStyle),
+		uintptr(BoolToBOOL(bMenu)),
+		uintptr(dwExStyle),
+		0,
+		0)
+
+	return ret != 0
+}
+
+func AdjustWindowRectExForDpi(lpRect *RECT, dwStyle uint32, bMenu bool, dwExStyle uint32, dpi uint32) bool {
+	if adjustWindowRectExForDpi.Find() != nil {
+		return AdjustWindowRectEx(lpRect, dwStyle, bMenu, dwExStyle)
+	}
+
+	ret, _, _ := syscall.Syscall6(adjustWindowRectExForDpi.Addr(), 5,
+		uintptr(unsafe.Pointer(lpRect)),
+		uintptr(dwStyle),
+		uintptr(BoolToBOOL(bMenu)),
+		uintptr(dwExStyle),
+		uintptr(dpi),
+		0)
+
+	return ret != 0
+}
+
 func AttachThreadInput(idAttach int32, idAttachTo int32, fAttach bool) bool {
 	ret, _, _ := syscall.Syscall(attachThreadInput.Addr(), 3,
 		uintptr(idAttach),
@@ -2114,6 +2217,215 @@ func BeginDeferWindowPos(nNumWindows int32) HDWP {
 	return HDWP(ret)
 }
 
+func CallNextHookEx(hhk HHOOK, nCode int32, wParam, lParam uintptr) uintptr {
+	ret, _, _ := syscall.Syscall6(callNextHookEx.Addr(), 4,
+		uintptr(hhk),
+		uintptr(nCode),
+		wParam,
+		lParam,
+		0,
+		0)
+
+	return ret
+}
+
+func CheckMenuItem(hMenu HMENU, uIDCheckItem, uCheck uint32) uint32 {
+	ret, _, _ := syscall.Syscall(checkMenuItem.Addr(), 3,
+		uintptr(hMenu),
+		uintptr(uIDCheckItem),
+		uintptr(uCheck))
+
+	return uint32(ret)
+}
+
+func CountClipboardFormats() int32 {
+	ret, _, _ := syscall.Syscall(countClipboardFormats.Addr(), 0,
+		0,
+		0,
+		0)
+
+	return int32(ret)
+}
+
+func CreateAcceleratorTable(paccel *ACCEL, cAccel int32) HACCEL {
+	ret, _, _ := syscall.Syscall(createAcceleratorTable.Addr(), 2,
+		uintptr(unsafe.Pointer(paccel)),
+		uintptr(cAccel),
+		0)
+
+	return HACCEL(ret)
+}
+
+func CreateDialogIndirectParam(instRes HINSTANCE, template unsafe.Pointer, parent HWND, proc, param uintptr) HWND {
+	ret, _, _ := syscall.Syscall6(createDialogIndirectParam.Addr(), 5,
+		uintptr(instRes),
+		uintptr(template),
+		uintptr(parent),
+		proc,
+		param,
+		0)
+
+	return HWND(ret)
+}
+
+func CreateIconFromResourceEx(presbits *byte, dwResSize uint32, fIcon bool, dwVer uint32, cxDesired, cyDesired int32, flags uint32) HICON {
+	ret, _, _ := syscall.Syscall9(createIconFromResourceEx.Addr(), 7,
+		uintptr(unsafe.Pointer(presbits)),
+		uintptr(dwResSize),
+		uintptr(BoolToBOOL(fIcon)),
+		uintptr(dwVer),
+		uintptr(cxDesired),
+		uintptr(cyDesired),
+		uintptr(flags),
+		0,
+		0)
+
+	return HICON(ret)
+}
+
+func DestroyAcceleratorTable(hAccel HACCEL) bool {
+	ret, _, _ := syscall.Syscall(destroyAcceleratorTable.Addr(), 1,
+		uintptr(hAccel),
+		0,
+		0)
+
+	return ret != 0
+}
+
+func DialogBoxIndirectParam(instRes HINSTANCE, template unsafe.Pointer, parent HWND, proc, param uintptr) int {
+	ret, _, _ := syscall.Syscall6(dialogBoxIndirectParam.Addr(), 5,
+		uintptr(instRes),
+		uintptr(template),
+		uintptr(parent),
+		proc,
+		param,
+		0)
+
+	return int(ret)
+}
+
+func EnableNonClientDpiScaling(hwnd HWND) bool {
+	if enableNonClientDpiScaling.Find() != nil {
+		return false
+	}
+
+	ret, _, _ := syscall.Syscall(enableNonClientDpiScaling.Addr(), 1,
+		uintptr(hwnd),
+		0,
+		0)
+
+	return ret != 0
+}
+
+func EnumClipboardFormats(format uint32) uint32 {
+	ret, _, _ := syscall.Syscall(enumClipboardFormats.Addr(), 1,
+		uintptr(format),
+		0,
+		0)
+
+	return uint32(ret)
+}
+
+func EnumDisplayMonitors(hdc HDC, lprcClip *RECT, lpfnEnum, dwData uintptr) bool {
+	ret, _, _ := syscall.Syscall6(enumDisplayMonitors.Addr(), 4,
+		uintptr(hdc),
+		uintptr(unsafe.Pointer(lprcClip)),
+		lpfnEnum,
+		dwData,
+		0,
+		0)
+
+	return ret != 0
+}
+
+func EnumThreadWindows(dwThreadId uint32, lpfn, lParam uintptr) bool {
+	ret, _, _ := syscall.Syscall(enumThreadWindows.Addr(), 3,
+		uintptr(dwThreadId),
+		lpfn,
+		lParam)
+
+	return ret != 0
+}
+
+func EnumWindows(lpEnumFunc, lParam uintptr) bool {
+	ret, _, _ := syscall.Syscall(enumWindows.Addr(), 2,
+		lpEnumFunc,
+		lParam,
+		0)
+
+	return ret != 0
+}
+
+func FindWindowEx(hWndParent, hWndChildAfter HWND, lpszClass, lpszWindow *uint16) HWND {
+	ret, _, _ := syscall.Syscall6(findWindowEx.Addr(), 4,
+		uintptr(hWndParent),
+		uintptr(hWndChildAfter),
+		uintptr(unsafe.Pointer(lpszClass)),
+		uintptr(unsafe.Pointer(lpszWindow)),
+		0,
+		0)
+
+	return HWND(ret)
+}
+
+func GetClipboardOwner() HWND {
+	ret, _, _ := syscall.Syscall(getClipboardOwner.Addr(), 0,
+		0,
+		0,
+		0)
+
+	return HWND(ret)
+}
+
+func GetClipboardSequenceNumber() uint32 {
+	ret, _, _ := syscall.Syscall(getClipboardSequenceNumber.Addr(), 0,
+		0,
+		0,
+		0)
+
+	return uint32(ret)
+}
+
+func GetLastInputInfo(plii *LASTINPUTINFO) bool {
+	ret, _, _ := syscall.Syscall(getLastInputInfo.Addr(), 1,
+		uintptr(unsafe.Pointer(plii)),
+		0,
+		0)
+
+	return ret != 0
+}
+
+func GetThreadDpiAwarenessContext() DPI_AWARENESS_CONTEXT {
+	if getThreadDpiAwarenessContext.Find() != nil {
+		return 0
+	}
+
+	ret, _, _ := syscall.Syscall(getThreadDpiAwarenessContext.Addr(), 0,
+		0,
+		0,
+		0)
+
+	return DPI_AWARENESS_CONTEXT(ret)
+}
+
+func GetWindowText(hWnd HWND, lpString *uint16, nMaxCount int32) int32 {
+	ret, _, _ := syscall.Syscall(getWindowText.Addr(), 3,
+		uintptr(hWnd),
+		uintptr(unsafe.Pointer(lpString)),
+		uintptr(nMaxCount))
+
+	return int32(ret)
+}
+
+func GetWindowTextLength(hWnd HWND) int32 {
+	ret, _, _ := syscall.Syscall(getWindowTextLength.Addr(), 1,
+		uintptr(hWnd),
+		0,
+		0)
+
+	return int32(ret)
+}
+
 func GetWindowThreadProcessId(hwnd HWND, processId *uint32) uint32 {
 	ret, _, _ := syscall.Syscall(getWindowThreadProcessId.Addr(), 2,
 		uintptr(hwnd),
@@ -2479,6 +2791,15 @@ func GetCaretPos(lpPoint *POINT) bool {
 	return ret != 0
 }
 
+func GetClassLongPtr(hWnd HWND, index int32) uintptr {
+	ret, _, _ := syscall.Syscall(getClassLongPtr.Addr(), 2,
+		uintptr(hWnd),
+		uintptr(index),
+		0)
+
+	return ret
+}
+
 func GetClassName(hWnd HWND, className *uint16, maxCount int) (int, error) {
 	ret, _, e := syscall.Syscall(getClassName.Addr(), 3,
 		uintptr(hWnd),
@@ -2508,6 +2829,15 @@ func GetClipboardData(uFormat uint32) HANDLE {
 	return HANDLE(ret)
 }
 
+func GetClipboardFormatName(format uint32, lpszFormatName *uint16, cchMaxCount int32) int32 {
+	ret, _, _ := syscall.Syscall(getClipboardFormatName.Addr(), 3,
+		uintptr(format),
+		uintptr(unsafe.Pointer(lpszFormatName)),
+		uintptr(cchMaxCount))
+
+	return int32(ret)
+}
+
 func GetCursorPos(lpPoint *POINT) bool {
 	ret, _, _ := syscall.Syscall(getCursorPos.Addr(), 1,
 		uintptr(unsafe.Pointer(lpPoint)),
@@ -2674,6 +3004,15 @@ func GetParent(hWnd HWND) HWND {
 	return HWND(ret)
 }
 
+func GetRawInputBuffer(pData unsafe.Pointer, pcbSize *uint32, cbSizeHeader uint32) uint32 {
+	ret, _, _ := syscall.Syscall(getRawInputBuffer.Addr(), 3,
+		uintptr(pData),
+		uintptr(unsafe.Pointer(pcbSize)),
+		uintptr(cbSizeHeader))
+
+	return uint32(ret)
+}
+
 func GetRawInputData(hRawInput HRAWINPUT, uiCommand uint32, pData unsafe.Pointer, pcbSize *uint32, cBSizeHeader uint32) uint32 {
 	ret, _, _ := syscall.Syscall6(getRawInputData.Addr(), 5,
 		uintptr(hRawInput),
@@ -2686,6 +3025,27 @@ func GetRawInputData(hRawInput HRAWINPUT, uiCommand uint32, pData unsafe.Pointer
 	return uint32(ret)
 }
 
+func GetRawInputDeviceInfo(hDevice HANDLE, uiCommand uint32, pData unsafe.Pointer, pcbSize *uint32) uint32 {
+	ret, _, _ := syscall.Syscall6(getRawInputDeviceInfo.Addr(), 4,
+		uintptr(hDevice),
+		uintptr(uiCommand),
+		uintptr(pData),
+		uintptr(unsafe.Pointer(pcbSize)),
+		0,
+		0)
+
+	return uint32(ret)
+}
+
+func GetRawInputDeviceList(pRawInputDeviceList *RAWINPUTDEVICELIST, puiNumDevices *uint32, cbSize uint32) uint32 {
+	ret, _, _ := syscall.Syscall(getRawInputDeviceList.Addr(), 3,
+		uintptr(unsafe.Pointer(pRawInputDeviceList)),
+		uintptr(unsafe.Pointer(puiNumDevices)),
+		uintptr(cbSize))
+
+	return uint32(ret)
+}
+
 func GetScrollInfo(hwnd HWND, fnBar int32, lpsi *SCROLLINFO) bool {
 	ret, _, _ := syscall.Syscall(getScrollInfo.Addr(), 3,
 		uintptr(hwnd),
@@ -2854,6 +3214,15 @@ func IsIconic(hWnd HWND) bool {
 	return ret != 0
 }
 
+func IsWindow(hWnd HWND) bool {
+	ret, _, _ := syscall.Syscall(isWindow.Addr(), 1,
+		uintptr(hWnd),
+		0,
+		0)
+
+	return ret != 0
+}
+
 func IsWindowEnabled(hWnd HWND) bool {
 	ret, _, _ := syscall.Syscall(isWindowEnabled.Addr(), 1,
 		uintptr(hWnd),
@@ -2950,7 +3319,7 @@ func LoadString(instRes HINSTANCE, id uint32, buf *uint16, length int32) int32 {
 //	MB_ICONHAND (See MB_ICONERROR)
 //	MB_ICONINFORMATION (The sounds specified as the Windows Asterisk sound)
 //	MB_ICONQUESTION (The sound specified as the Windows Question sound)
-// 	MB_ICONSTOP (See MB_ICONERROR)
+//	MB_ICONSTOP (See MB_ICONERROR)
 //	MB_ICONWARNING (The sounds specified as the Windows Exclamation sound)
 //	MB_OK (The sound specified as the Windows Default Beep sound)
 //
@@ -2976,6 +3345,15 @@ func MessageBox(hWnd HWND, lpText, lpCaption *uint16, uType uint32) int32 {
 	return int32(ret)
 }
 
+func MonitorFromRect(lprc *RECT, dwFlags uint32) HMONITOR {
+	ret, _, _ := syscall.Syscall(monitorFromRect.Addr(), 2,
+		uintptr(unsafe.Pointer(lprc)),
+		uintptr(dwFlags),
+		0)
+
+	return HMONITOR(ret)
+}
+
 func MonitorFromWindow(hwnd HWND, dwFlags uint32) HMONITOR {
 	ret, _, _ := syscall.Syscall(monitorFromWindow.Addr(), 2,
 		uintptr(hwnd),
@@ -3007,6 +3385,188 @@ func NotifyWinEvent(event uint32, hwnd HWND, idObject, idChild int32) {
 		0)
 }
 
+func RegisterClipboardFormat(lpszFormat *uint16) uint32 {
+	ret, _, _ := syscall.Syscall(registerClipboardFormat.Addr(), 1,
+		uintptr(unsafe.Pointer(lpszFormat)),
+		0,
+		0)
+
+	return uint32(ret)
+}
+
+func RegisterHotKey(hWnd HWND, id int32, fsModifiers, vk uint32) bool {
+	ret, _, _ := syscall.Syscall6(registerHotKey.Addr(), 4,
+		uintptr(hWnd),
+		uintptr(id),
+		uintptr(fsModifiers),
+		uintptr(vk),
+		0,
+		0)
+
+	return ret != 0
+}
+
+func RemoveClipboardFormatListener(hwnd HWND) bool {
+	if removeClipboardFormatListener.Find() != nil {
+		return false
+	}
+
+	ret, _, _ := syscall.Syscall(removeClipboardFormatListener.Addr(), 1,
+		uintptr(hwnd),
+		0,
+		0)
+
+	return ret != 0
+}
+
+func ScrollWindowEx(hWnd HWND, dx, dy int32, prcScroll, prcClip *RECT, hrgnUpdate HRGN, prcUpdate *RECT, flags uint32) int32 {
+	ret, _, _ := syscall.Syscall9(scrollWindowEx.Addr(), 8,
+		uintptr(hWnd),
+		uintptr(dx),
+		uintptr(dy),
+		uintptr(unsafe.Pointer(prcScroll)),
+		uintptr(unsafe.Pointer(prcClip)),
+		uintptr(hrgnUpdate),
+		uintptr(unsafe.Pointer(prcUpdate)),
+		uintptr(flags),
+		0)
+
+	return int32(ret)
+}
+
+func SetCoalescableTimer(hWnd HWND, nIDEvent uintptr, uElapse uint32, lpTimerFunc uintptr, uToleranceDelay uint32) uintptr {
+	if setCoalescableTimer.Find() != nil {
+		return SetTimer(hWnd, nIDEvent, uElapse, lpTimerFunc)
+	}
+
+	ret, _, _ := syscall.Syscall6(setCoalescableTimer.Addr(), 5,
+		uintptr(hWnd),
+		nIDEvent,
+		uintptr(uElapse),
+		lpTimerFunc,
+		uintptr(uToleranceDelay),
+		0)
+
+	return ret
+}
+
+func SetProcessDPIAware() bool {
+	ret, _, _ := syscall.Syscall(setProcessDPIAware.Addr(), 0,
+		0,
+		0,
+		0)
+
+	return ret != 0
+}
+
+func SetProcessDpiAwarenessContext(value DPI_AWARENESS_CONTEXT) bool {
+	if setProcessDpiAwarenessContext.Find() != nil {
+		// Fall back to the closest awareness of Windows 8.1 and Vista.
+		var awareness PROCESS_DPI_AWARENESS
+		switch value {
+		case DPI_AWARENESS_CONTEXT_SYSTEM_AWARE:
+			awareness = PROCESS_SYSTEM_DPI_AWARE
+
+		case DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE, DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2:
+			awareness = PROCESS_PER_MONITOR_DPI_AWARE
+
+		default:
+			return true
+		}
+
+		if setProcessDpiAwareness.Find() == nil {
+			return SUCCEEDED(SetProcessDpiAwareness(awareness))
+		}
+
+		return SetProcessDPIAware()
+	}
+
+	ret, _, _ := syscall.Syscall(setProcessDpiAwarenessContext.Addr(), 1,
+		uintptr(value),
+		0,
+		0)
+
+	return ret != 0
+}
+
+func SetThreadDpiAwarenessContext(dpiContext DPI_AWARENESS_CONTEXT) DPI_AWARENESS_CONTEXT {
+	if setThreadDpiAwarenessContext.Find() != nil {
+		return 0
+	}
+
+	ret, _, _ := syscall.Syscall(setThreadDpiAwarenessContext.Addr(), 1,
+		uintptr(dpiContext),
+		0,
+		0)
+
+	return DPI_AWARENESS_CONTEXT(ret)
+}
+
+func SetWindowsHookEx(idHook int32, lpfn uintptr, hmod HINSTANCE, dwThreadId uint32) HHOOK {
+	ret, _, _ := syscall.Syscall6(setWindowsHookEx.Addr(), 4,
+		uintptr(idHook),
+		lpfn,
+		uintptr(hmod),
+		uintptr(dwThreadId),
+		0,
+		0)
+
+	return HHOOK(ret)
+}
+
+func ShowScrollBar(hWnd HWND, wBar int32, bShow bool) bool {
+	ret, _, _ := syscall.Syscall(showScrollBar.Addr(), 3,
+		uintptr(hWnd),
+		uintptr(wBar),
+		uintptr(BoolToBOOL(bShow)))
+
+	return ret != 0
+}
+
+func SystemParametersInfoForDpi(uiAction, uiParam uint32, pvParam unsafe.Pointer, fWinIni, dpi uint32) bool {
+	if systemParametersInfoForDpi.Find() != nil {
+		// Before Windows 10 1607, the parameters are at the system DPI.
+		if !SystemParametersInfo(uiAction, uiParam, pvParam, fWinIni) {
+			return false
+		}
+
+		hdc := GetDC(0)
+		defer ReleaseDC(0, hdc)
+
+		DPI(dpi).scaleSystemParameters(uiAction, pvParam, DPI(GetDeviceCaps(hdc, LOGPIXELSY)))
+
+		return true
+	}
+
+	ret, _, _ := syscall.Syscall6(systemParametersInfoForDpi.Addr(), 5,
+		uintptr(uiAction),
+		uintptr(uiParam),
+		uintptr(pvParam),
+		uintptr(fWinIni),
+		uintptr(dpi),
+		0)
+
+	return ret != 0
+}
+
+func TranslateAccelerator(hWnd HWND, hAccTable HACCEL, lpMsg *MSG) bool {
+	ret, _, _ := syscall.Syscall(translateAccelerator.Addr(), 3,
+		uintptr(hWnd),
+		uintptr(hAccTable),
+		uintptr(unsafe.Pointer(lpMsg)))
+
+	return ret != 0
+}
+
+func UnhookWindowsHookEx(hhk HHOOK) bool {
+	ret, _, _ := syscall.Syscall(unhookWindowsHookEx.Addr(), 1,
+		uintptr(hhk),
+		0,
+		0)
+
+	return ret != 0
+}
+
 func UnregisterClass(name *uint16) bool {
 	ret, _, _ := syscall.Syscall(unregisterClass.Addr(), 1,
 		uintptr(unsafe.Pointer(name)),
@@ -3477,6 +4037,15 @@ func UnhookWinEvent(hWinHookEvent HWINEVENTHOOK) bool {
 	return ret != 0
 }
 
+func UnregisterHotKey(hWnd HWND, id int32) bool {
+	ret, _, _ := syscall.Syscall(unregisterHotKey.Addr(), 2,
+		uintptr(hWnd),
+		uintptr(id),
+		0)
+
+	return ret != 0
+}
+
 func UpdateWindow(hwnd HWND) bool {
 	ret, _, _ := syscall.Syscall(updateWindow.Addr(), 1,
 		uintptr(hwnd),
@@ -462,6 +462,31 @@ const (
 	TS_DRAW
 )
 
+type HPAINTBUFFER HANDLE
+
+type BP_BUFFERFORMAT int32
+
+const (
+	BPBF_COMPATIBLEBITMAP BP_BUFFERFORMAT = iota
+	BPBF_DIB
+	BPBF_TOPDOWNDIB
+	BPBF_TOPDOWNMONODIB
+)
+
+// BP_PAINTPARAMS flags
+const (
+	BPPF_ERASE     = 0x0001
+	BPPF_NOCLIP    = 0x0002
+	BPPF_NONCLIENT = 0x0004
+)
+
+type BP_PAINTPARAMS struct {
+	CbSize         uint32
+	DwFlags        uint32
+	PrcExclude     *RECT
+	PBlendFunction *BLENDFUNCTION
+}
+
 type DTTOPTS struct {
 	DwSize              uint32
 	DwFlags             uint32
@@ -485,15 +510,20 @@ var (
 	libuxtheme *windows.LazyDLL
 
 	// Functions
-	closeThemeData      *windows.LazyProc
-	drawThemeBackground *windows.LazyProc
-	drawThemeTextEx     *windows.LazyProc
-	getThemeColor       *windows.LazyProc
-	getThemePartSize    *windows.LazyProc
-	getThemeTextExtent  *windows.LazyProc
-	isAppThemed         *windows.LazyProc
-	openThemeData       *windows.LazyProc
-	setWindowTheme      *windows.LazyProc
+	beginBufferedPaint    *windows.LazyProc
+	bufferedPaintInit     *windows.LazyProc
+	bufferedPaintSetAlpha *windows.LazyProc
+	bufferedPaintUnInit   *windows.LazyProc
+	closeThemeData        *windows.LazyProc
+	drawThemeBackground   *windows.LazyProc
+	drawThemeTextEx       *windows.LazyProc
+	endBufferedPaint      *windows.LazyProc
+	getThemeColor         *windows.LazyProc
+	getThemePartSize      *windows.LazyProc
+	getThemeTextExtent    *windows.LazyProc
+	isAppThemed           *windows.LazyProc
+	openThemeData         *windows.LazyProc
+	setWindowTheme        *windows.LazyProc
 )
 
 func init() {
@@ -501,9 +531,14 @@ func init() {
 	libuxtheme = windows.NewLazySystemDLL("uxtheme.dll")
 
 	// Functions
+	beginBufferedPaint = libuxtheme.NewProc("BeginBufferedPaint")
+	bufferedPaintInit = libuxtheme.NewProc("BufferedPaintInit")
+	bufferedPaintSetAlpha = libuxtheme.NewProc("BufferedPaintSetAlpha")
+	bufferedPaintUnInit = libuxtheme.NewProc("BufferedPaintUnInit")
 	closeThemeData = libuxtheme.NewProc("CloseThemeData")
 	drawThemeBackground = libuxtheme.NewProc("DrawThemeBackground")
 	drawThemeTextEx = libuxtheme.NewProc("DrawThemeTextEx")
+	endBufferedPaint = libuxtheme.NewProc("EndBufferedPaint")
 	getThemeColor = libuxtheme.NewProc("GetThemeColor")
 	getThemePartSize = libuxtheme.NewProc("GetThemePartSize")
 	getThemeTextExtent = libuxtheme.NewProc("GetThemeTextExtent")
@@ -512,6 +547,45 @@ func init() {
 	setWindowTheme = libuxtheme.NewProc("SetWindowTheme")
 }
 
+func BeginBufferedPaint(hdcTarget HDC, prcTarget *RECT, dwFormat BP_BUFFERFORMAT, pPaintParams *BP_PAINTPARAMS, phdc *HDC) HPAINTBUFFER {
+	ret, _, _ := syscall.Syscall6(beginBufferedPaint.Addr(), 5,
+		uintptr(hdcTarget),
+		uintptr(unsafe.Pointer(prcTarget)),
+		uintptr(dwFormat),
+		uintptr(unsafe.Pointer(pPaintParams)),
+		uintptr(unsafe.Pointer(phdc)),
+		0)
+
+	return HPAINTBUFFER(ret)
+}
+
+func BufferedPaintInit() HRESULT {
+	ret, _, _ := syscall.Syscall(bufferedPaintInit.Addr(), 0,
+		0,
+		0,
+		0)
+
+	return HRESULT(ret)
+}
+
+func BufferedPaintSetAlpha(hBufferedPaint HPAINTBUFFER, prc *RECT, alpha byte) HRESULT {
+	ret, _, _ := syscall.Syscall(bufferedPaintSetAlpha.Addr(), 3,
+		uintptr(hBufferedPaint),
+		uintptr(unsafe.Pointer(prc)),
+		uintptr(alpha))
+
+	return HRESULT(ret)
+}
+
+func BufferedPaintUnInit() HRESULT {
+	ret, _, _ := syscall.Syscall(bufferedPaintUnInit.Addr(), 0,
+		0,
+		0,
+		0)
+
+	return HRESULT(ret)
+}
+
 func CloseThemeData(hTheme HTHEME) HRESULT {
 	ret, _, _ := syscall.Syscall(closeThemeData.Addr(), 1,
 		uintptr(hTheme),
@@ -551,6 +625,15 @@ func DrawThemeTextEx(hTheme HTHEME, hdc HDC, iPartId, iStateId int32, pszText *u
 	return HRESULT(ret)
 }
 
+func EndBufferedPaint(hBufferedPaint HPAINTBUFFER, fUpdateTarget bool) HRESULT {
+	ret, _, _ := syscall.Syscall(endBufferedPaint.Addr(), 2,
+		uintptr(hBufferedPaint),
+		uintptr(BoolToBOOL(fUpdateTarget)),
+		0)
+
+	return HRESULT(ret)
+}
+
 func GetThemeColor(hTheme HTHEME, iPartId, iStateId, iPropId int32, pColor *COLORREF) HRESULT {
 	ret, _, _ := syscall.Syscall6(getThemeColor.Addr(), 5,
 		uintptr(hTheme),
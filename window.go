@@ -0,0 +1,473 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// MessageHandler handles a window message. If handled is false, the message
+// is passed on to the next handler and finally to the default or, for
+// subclassed windows, the original window procedure.
+type MessageHandler func(w *Window, msg uint32, wParam, lParam uintptr) (result uintptr, handled bool)
+
+// Window dispatches the messages of a native window to Go handlers.
+//
+// Handlers are called on the thread that created the window, or for
+// subclassed windows, the thread that owns it. Handlers registered for the
+// same message run in registration order until one reports it handled the
+// message. Handlers added with HandleAll run before any others.
+type Window struct {
+	hwnd        HWND
+	id          uintptr
+	prevWndProc uintptr
+	handlers    map[uint32][]MessageHandler
+	allHandlers []MessageHandler
+	createErr   error
+}
+
+// WindowClass describes a window class whose windows dispatch to Window
+// handlers.
+type WindowClass struct {
+	Name       string
+	Style      uint32
+	Icon       HICON
+	IconSm     HICON
+	Cursor     HCURSOR
+	Background HBRUSH
+}
+
+// CreateWindowParams holds the arguments for Window.Create.
+type CreateWindowParams struct {
+	ClassName string
+	Title     string
+	Style     uint32
+	ExStyle   uint32
+	X, Y      int32
+	Width     int32
+	Height    int32
+	Parent    HWND
+	// Menu is the menu of a top-level window or the id of a child window.
+	Menu HMENU
+}
+
+var windowRegistry = struct {
+	sync.Mutex
+	nextID uintptr
+	byID   map[uintptr]*Window
+	byHWND map[HWND]*Window
+}{
+	byID:   make(map[uintptr]*Window),
+	byHWND: make(map[HWND]*Window),
+}
+
+var (
+	windowProcOnce     sync.Once
+	classWndProcPtr    uintptr
+	subclassWndProcPtr uintptr
+)
+
+func initWindowProcs() {
+	windowProcOnce.Do(func() {
+		classWndProcPtr = syscall.NewCallback(classWndProc)
+		subclassWndProcPtr = syscall.NewCallback(subclassWndProc)
+	})
+}
+
+// RegisterWindowClass registers wc with a window procedure that dispatches
+// to the handlers of the Window created with it.
+func RegisterWindowClass(wc *WindowClass) (ATOM, error) {
+	initWindowProcs()
+
+	className, err := syscall.UTF16PtrFromString(wc.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	cursor := wc.Cursor
+	if cursor == 0 {
+		cursor = LoadCursor(0, MAKEINTRESOURCE(IDC_ARROW))
+	}
+
+	wcx := WNDCLASSEX{
+		CbSize:        uint32(unsafe.Sizeof(WNDCLASSEX{})),
+		Style:         wc.Style,
+		LpfnWndProc:   classWndProcPtr,
+		HInstance:     GetModuleHandle(nil),
+		HIcon:         wc.Icon,
+		HCursor:       cursor,
+		HbrBackground: wc.Background,
+		LpszClassName: className,
+		HIconSm:       wc.IconSm,
+	}
+
+	atom := RegisterClassEx(&wcx)
+	if atom == 0 {
+		return 0, errors.New("RegisterClassEx failed")
+	}
+
+	return atom, nil
+}
+
+// NewWindow returns a Window without a native window. Register handlers,
+// then call Create so that they also see the creation messages.
+func NewWindow() *Window {
+	return &Window{
+		handlers: make(map[uint32][]MessageHandler),
+	}
+}
+
+// WindowFromHWND returns the Window dispatching the messages of hwnd, or
+// nil if there is none.
+func WindowFromHWND(hwnd HWND) *Window {
+	windowRegistry.Lock()
+	defer windowRegistry.Unlock()
+
+	if w, ok := windowRegistry.byHWND[hwnd]; ok {
+		return w
+	}
+
+	return nil
+}
+
+// Create creates the native window. p.ClassName must have been registered
+// with RegisterWindowClass.
+func (w *Window) Create(p *CreateWindowParams) error {
+	if w.hwnd != 0 {
+		return errors.New("window already created")
+	}
+
+	className, err := syscall.UTF16PtrFromString(p.ClassName)
+	if err != nil {
+		return err
+	}
+	title, err := syscall.UTF16PtrFromString(p.Title)
+	if err != nil {
+		return err
+	}
+
+	windowRegistry.Lock()
+	windowRegistry.nextID++
+	w.id = windowRegistry.nextID
+	windowRegistry.byID[w.id] = w
+	windowRegistry.Unlock()
+
+	hwnd := createWindowExParam(
+		p.ExStyle,
+		className,
+		title,
+		p.Style,
+		p.X,
+		p.Y,
+		p.Width,
+		p.Height,
+		p.Parent,
+		p.Menu,
+		GetModuleHandle(nil),
+		w.id)
+
+	if hwnd == 0 {
+		w.unregister()
+
+		if w.createErr != nil {
+			err, w.createErr = w.createErr, nil
+			return err
+		}
+
+		return errors.New("CreateWindowEx failed")
+	}
+
+	return nil
+}
+
+// createWindowExParam is CreateWindowEx with lpParam passed as an integer,
+// for values that are not pointers, like the id of a Window, which the
+// window procedure reads back from CREATESTRUCT.CreateParams.
+func createWindowExParam(dwExStyle uint32, lpClassName, lpWindowName *uint16, dwStyle uint32, x, y, nWidth, nHeight int32, hWndParent HWND, hMenu HMENU, hInstance HINSTANCE, lpParam uintptr) HWND {
+	ret, _, _ := syscall.Syscall12(createWindowEx.Addr(), 12,
+		uintptr(dwExStyle),
+		uintptr(unsafe.Pointer(lpClassName)),
+		uintptr(unsafe.Pointer(lpWindowName)),
+		uintptr(dwStyle),
+		uintptr(x),
+		uintptr(y),
+		uintptr(nWidth),
+		uintptr(nHeight),
+		uintptr(hWndParent),
+		uintptr(hMenu),
+		uintptr(hInstance),
+		lpParam)
+
+	return HWND(ret)
+}
+
+// SubclassWindow replaces the window procedure of hwnd, usually a control,
+// with one dispatching to the returned Window's handlers. Unhandled messages
+// are passed to the original window procedure with CallWindowProc.
+func SubclassWindow(hwnd HWND) (*Window, error) {
+	initWindowProcs()
+
+	windowRegistry.Lock()
+	if _, ok := windowRegistry.byHWND[hwnd]; ok {
+		windowRegistry.Unlock()
+		return nil, errors.New("window already dispatches to Go handlers")
+	}
+
+	w := NewWindow()
+	w.hwnd = hwnd
+	windowRegistry.byHWND[hwnd] = w
+	windowRegistry.Unlock()
+
+	w.prevWndProc = SetWindowLongPtr(hwnd, GWLP_WNDPROC, subclassWndProcPtr)
+	if w.prevWndProc == 0 {
+		w.unregister()
+		return nil, errors.New("SetWindowLongPtr failed")
+	}
+
+	return w, nil
+}
+
+// Unsubclass restores the original window procedure of a window subclassed
+// with SubclassWindow. It fails if the window has been subclassed again since.
+func (w *Window) Unsubclass() error {
+	if w.prevWndProc == 0 {
+		return errors.New("window is not subclassed")
+	}
+
+	if GetWindowLongPtr(w.hwnd, GWLP_WNDPROC) != subclassWndProcPtr {
+		return errors.New("window has been subclassed by someone else")
+	}
+
+	SetWindowLongPtr(w.hwnd, GWLP_WNDPROC, w.prevWndProc)
+	w.unregister()
+
+	return nil
+}
+
+func (w *Window) unregister() {
+	windowRegistry.Lock()
+	defer windowRegistry.Unlock()
+
+	if w.id != 0 {
+		delete(windowRegistry.byID, w.id)
+	}
+	if w.hwnd != 0 && windowRegistry.byHWND[w.hwnd] == w {
+		delete(windowRegistry.byHWND, w.hwnd)
+	}
+}
+
+func classWndProc(hwnd HWND, msg, wParam, lParam uintptr) uintptr {
+	var w *Window
+
+	if msg == WM_NCCREATE {
+		cs := CrackCreateMsg(wParam, lParam).CreateStruct
+
+		windowRegistry.Lock()
+		if w = windowRegistry.byID[cs.CreateParams]; w != nil {
+			w.hwnd = hwnd
+			windowRegistry.byHWND[hwnd] = w
+		}
+		windowRegistry.Unlock()
+
+		if w != nil {
+			SetWindowLongPtr(hwnd, GWLP_USERDATA, w.id)
+		}
+	} else if id := GetWindowLongPtr(hwnd, GWLP_USERDATA); id != 0 {
+		windowRegistry.Lock()
+		w = windowRegistry.byID[id]
+		windowRegistry.Unlock()
+	}
+
+	if w == nil {
+		return DefWindowProc(hwnd, uint32(msg), wParam, lParam)
+	}
+
+	return w.dispatch(uint32(msg), wParam, lParam)
+}
+
+func subclassWndProc(hwnd HWND, msg, wParam, lParam uintptr) uintptr {
+	windowRegistry.Lock()
+	w := windowRegistry.byHWND[hwnd]
+	windowRegistry.Unlock()
+
+	if w == nil {
+		// Should not happen, but there is no way to chain without the Window.
+		return DefWindowProc(hwnd, uint32(msg), wParam, lParam)
+	}
+
+	return w.dispatch(uint32(msg), wParam, lParam)
+}
+
+func (w *Window) dispatch(msg uint32, wParam, lParam uintptr) uintptr {
+	result, handled := w.callHandlers(msg, wParam, lParam)
+	if !handled {
+		result = w.DefaultProc(msg, wParam, lParam)
+	}
+
+	if msg == WM_NCDESTROY {
+		if w.prevWndProc != 0 && GetWindowLongPtr(w.hwnd, GWLP_WNDPROC) == subclassWndProcPtr {
+			SetWindowLongPtr(w.hwnd, GWLP_WNDPROC, w.prevWndProc)
+		}
+
+		w.unregister()
+		w.hwnd = 0
+		w.id = 0
+	}
+
+	return result
+}
+
+func (w *Window) callHandlers(msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+	for _, h := range w.allHandlers {
+		if result, handled := h(w, msg, wParam, lParam); handled {
+			return result, true
+		}
+	}
+
+	for _, h := range w.handlers[msg] {
+		if result, handled := h(w, msg, wParam, lParam); handled {
+			return result, true
+		}
+	}
+
+	return 0, false
+}
+
+// DefaultProc passes a message to DefWindowProc or, for subclassed windows,
+// to the original window procedure.
+func (w *Window) DefaultProc(msg uint32, wParam, lParam uintptr) uintptr {
+	if w.prevWndProc != 0 {
+		return CallWindowProc(w.prevWndProc, w.hwnd, msg, wParam, lParam)
+	}
+
+	return DefWindowProc(w.hwnd, msg, wParam, lParam)
+}
+
+// HWND returns the native window handle, or 0 if the window has not been
+// created yet or has been destroyed.
+func (w *Window) HWND() HWND {
+	return w.hwnd
+}
+
+// Handle registers h for msg.
+func (w *Window) Handle(msg uint32, h MessageHandler) {
+	w.handlers[msg] = append(w.handlers[msg], h)
+}
+
+// HandleAll registers h for all messages. It runs before the handlers
+// registered with Handle.
+func (w *Window) HandleAll(h MessageHandler) {
+	w.allHandlers = append(w.allHandlers, h)
+}
+
+// OnCreate registers a WM_CREATE handler. A non-nil error makes window
+// creation fail and is returned from Create.
+func (w *Window) OnCreate(f func(cs *CREATESTRUCT) error) {
+	w.Handle(WM_CREATE, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		if err := f(CrackCreateMsg(wParam, lParam).CreateStruct); err != nil {
+			w.createErr = err
+			return ^uintptr(0), true
+		}
+
+		return 0, true
+	})
+}
+
+// OnClose registers a WM_CLOSE handler. If f returns false, the window is
+// not destroyed.
+func (w *Window) OnClose(f func() bool) {
+	w.Handle(WM_CLOSE, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		if f() {
+			return 0, false
+		}
+
+		return 0, true
+	})
+}
+
+// OnDestroy registers a WM_DESTROY handler.
+func (w *Window) OnDestroy(f func()) {
+	w.Handle(WM_DESTROY, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		f()
+		return 0, true
+	})
+}
+
+// OnPaint registers a WM_PAINT handler. BeginPaint and EndPaint are called
+// around f.
+func (w *Window) OnPaint(f func(hdc HDC, ps *PAINTSTRUCT)) {
+	w.Handle(WM_PAINT, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		var ps PAINTSTRUCT
+
+		hdc := BeginPaint(w.hwnd, &ps)
+		if hdc == 0 {
+			return 0, false
+		}
+		defer EndPaint(w.hwnd, &ps)
+
+		f(hdc, &ps)
+
+		return 0, true
+	})
+}
+
+// OnSize registers a WM_SIZE handler. sizeType is one of the SIZE_* values.
+func (w *Window) OnSize(f func(sizeType uint32, width, height int32)) {
+	w.Handle(WM_SIZE, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		m := CrackSizeMsg(wParam, lParam)
+		f(m.Type, m.Width, m.Height)
+		return 0, true
+	})
+}
+
+// OnCommand registers a WM_COMMAND handler. code is the notification code,
+// 0 for menus and 1 for accelerators, and ctrl the control sending it.
+// f returns whether it handled the command.
+func (w *Window) OnCommand(f func(id, code uint16, ctrl HWND) bool) {
+	w.Handle(WM_COMMAND, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		m := CrackCommandMsg(wParam, lParam)
+		return 0, f(m.ID, m.Code, m.Control)
+	})
+}
+
+// OnNotify registers a WM_NOTIFY handler.
+func (w *Window) OnNotify(f func(nmhdr *NMHDR) (result uintptr, handled bool)) {
+	w.Handle(WM_NOTIFY, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		return f(CrackNotifyMsg(wParam, lParam).Hdr)
+	})
+}
+
+// Send sends a message to the window.
+func (w *Window) Send(msg uint32, wParam, lParam uintptr) uintptr {
+	return SendMessage(w.hwnd, msg, wParam, lParam)
+}
+
+// Show calls ShowWindow with one of the SW_* values.
+func (w *Window) Show(cmdShow int32) {
+	ShowWindow(w.hwnd, cmdShow)
+}
+
+// Invalidate marks the whole client area for repainting.
+func (w *Window) Invalidate() {
+	InvalidateRect(w.hwnd, nil, false)
+}
+
+// Destroy destroys the native window.
+func (w *Window) Destroy() error {
+	if w.hwnd == 0 {
+		return nil
+	}
+
+	if !DestroyWindow(w.hwnd) {
+		return errors.New("DestroyWindow failed")
+	}
+
+	return nil
+}
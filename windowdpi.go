@@ -0,0 +1,145 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// WindowDPI returns the DPI of hwnd, which for per-monitor aware windows is
+// the DPI of the monitor it is on.
+func WindowDPI(hwnd HWND) DPI {
+	return DPI(GetDpiForWindow(hwnd))
+}
+
+// MonitorDPI returns the effective DPI of hmonitor.
+func MonitorDPI(hmonitor HMONITOR) DPI {
+	var dpiX, dpiY uint32
+	if FAILED(GetDpiForMonitor(hmonitor, MDT_EFFECTIVE_DPI, &dpiX, &dpiY)) {
+		return USER_DEFAULT_SCREEN_DPI
+	}
+
+	return DPI(dpiY)
+}
+
+// ScaleFont returns lf, which is valid at DPI from, with its size scaled to
+// d.
+func (d DPI) ScaleFont(lf LOGFONT, from DPI) LOGFONT {
+	lf.LfHeight = d.ScaleFrom(lf.LfHeight, from)
+	lf.LfWidth = d.ScaleFrom(lf.LfWidth, from)
+
+	return lf
+}
+
+// scaleSystemParameters scales the SystemParametersInfo result of action
+// in param from DPI from to d. Actions without sizes are left alone.
+func (d DPI) scaleSystemParameters(action uint32, param unsafe.Pointer, from DPI) {
+	if d == from || d == 0 || from == 0 {
+		return
+	}
+
+	switch action {
+	case SPI_GETICONTITLELOGFONT:
+		lf := (*LOGFONT)(param)
+		*lf = d.ScaleFont(*lf, from)
+
+	case SPI_GETNONCLIENTMETRICS:
+		ncm := (*NONCLIENTMETRICS)(param)
+		for _, v := range []*int32{
+			&ncm.IBorderWidth,
+			&ncm.IScrollWidth,
+			&ncm.IScrollHeight,
+			&ncm.ICaptionWidth,
+			&ncm.ICaptionHeight,
+			&ncm.ISmCaptionWidth,
+			&ncm.ISmCaptionHeight,
+			&ncm.IMenuWidth,
+			&ncm.IMenuHeight,
+		} {
+			*v = d.ScaleFrom(*v, from)
+		}
+		for _, lf := range []*LOGFONT{
+			&ncm.LfCaptionFont,
+			&ncm.LfSmCaptionFont,
+			&ncm.LfMenuFont,
+			&ncm.LfStatusFont,
+			&ncm.LfMessageFont,
+		} {
+			*lf = d.ScaleFont(*lf, from)
+		}
+	}
+}
+
+// MessageFont returns the font of message boxes at d, which is also the
+// recommended font for application windows.
+func (d DPI) MessageFont() (LOGFONT, error) {
+	ncm := NONCLIENTMETRICS{CbSize: uint32(unsafe.Sizeof(NONCLIENTMETRICS{}))}
+
+	if !SystemParametersInfoForDpi(SPI_GETNONCLIENTMETRICS, ncm.CbSize, unsafe.Pointer(&ncm), 0, uint32(d)) {
+		return LOGFONT{}, errors.New("SystemParametersInfoForDpi failed")
+	}
+
+	return ncm.LfMessageFont, nil
+}
+
+// SystemMetrics returns the system metric index at d.
+func (d DPI) SystemMetrics(index int32) int32 {
+	return GetSystemMetricsForDpi(index, uint32(d))
+}
+
+// WindowRect returns the window rectangle for the client rectangle client
+// of a window with the given styles at d.
+func (d DPI) WindowRect(client RECT, style, exStyle uint32, menu bool) (RECT, error) {
+	if !AdjustWindowRectExForDpi(&client, style, menu, exStyle, uint32(d)) {
+		return RECT{}, errors.New("AdjustWindowRectExForDpi failed")
+	}
+
+	return client, nil
+}
+
+// OnDPIChanged makes w follow DPI changes, e.g. when it is moved to a
+// monitor with a different scale factor. On WM_DPICHANGED, w is moved to
+// the rectangle suggested by the system and f, if not nil, is called to
+// rescale fonts and layout. For per-monitor (v1) aware windows,
+// non-client scaling is enabled as well.
+func (w *Window) OnDPIChanged(f func(old, new DPI)) {
+	var cur DPI
+	if w.hwnd != 0 {
+		cur = WindowDPI(w.hwnd)
+	}
+
+	w.Handle(WM_NCCREATE, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		EnableNonClientDpiScaling(w.hwnd)
+		return 0, false
+	})
+
+	w.Handle(WM_CREATE, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		cur = WindowDPI(w.hwnd)
+		return 0, false
+	})
+
+	w.Handle(WM_DPICHANGED, func(w *Window, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		m := CrackDPIChangedMsg(wParam, lParam)
+
+		old := cur
+		cur = DPI(m.DPIY)
+		if old == 0 {
+			old = cur
+		}
+
+		if r := m.Suggested; r != nil {
+			SetWindowPos(w.hwnd, 0, r.Left, r.Top, r.Right-r.Left, r.Bottom-r.Top, SWP_NOZORDER|SWP_NOACTIVATE)
+		}
+
+		if f != nil {
+			f(old, cur)
+		}
+
+		return 0, true
+	})
+}
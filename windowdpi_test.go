@@ -0,0 +1,46 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestScaleSystemParameters(t *testing.T) {
+	font := LOGFONT{LfHeight: -12, LfWeight: 400}
+	ncm := NONCLIENTMETRICS{
+		IBorderWidth:   1,
+		ICaptionHeight: 22,
+		LfCaptionFont:  font,
+		LfMessageFont:  font,
+	}
+
+	DPI(144).scaleSystemParameters(SPI_GETNONCLIENTMETRICS, unsafe.Pointer(&ncm), 96)
+
+	if ncm.ICaptionHeight != 33 || ncm.IBorderWidth != 2 {
+		t.Errorf("metrics = %d, %d, want 33, 2", ncm.ICaptionHeight, ncm.IBorderWidth)
+	}
+	for _, lf := range []LOGFONT{ncm.LfCaptionFont, ncm.LfMessageFont} {
+		if lf.LfHeight != -18 || lf.LfWeight != 400 {
+			t.Errorf("font height = %d, weight = %d, want -18, 400", lf.LfHeight, lf.LfWeight)
+		}
+	}
+
+	lf := font
+	DPI(192).scaleSystemParameters(SPI_GETICONTITLELOGFONT, unsafe.Pointer(&lf), 96)
+	if lf.LfHeight != -24 {
+		t.Errorf("icon title font height = %d, want -24", lf.LfHeight)
+	}
+
+	// Other actions are left alone.
+	hc := HIGHCONTRAST{CbSize: 12, DwFlags: 1}
+	DPI(192).scaleSystemParameters(SPI_GETHIGHCONTRAST, unsafe.Pointer(&hc), 96)
+	if hc.CbSize != 12 || hc.DwFlags != 1 {
+		t.Errorf("HIGHCONTRAST = %+v, want unchanged", hc)
+	}
+}
@@ -0,0 +1,441 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+var (
+	ErrWindowNotFound = errors.New("window not found")
+
+	// ErrSkipChildren can be returned by the function passed to
+	// WalkWindows to skip the children of a window.
+	ErrSkipChildren = errors.New("skip children")
+)
+
+// WindowInfo is a snapshot of the properties of a window.
+type WindowInfo struct {
+	HWND  HWND
+	Class string
+	Title string
+	PID   uint32
+	TID   uint32
+
+	// ID is the control id of child windows.
+	ID int32
+
+	Style        uint32
+	ExStyle      uint32
+	StyleNames   []string
+	ExStyleNames []string
+
+	// Rect is the window rectangle in screen coordinates, ClientRect the
+	// client rectangle in client coordinates.
+	Rect       RECT
+	ClientRect RECT
+
+	// Parent is 0 for top-level windows.
+	Parent HWND
+	Owner  HWND
+
+	Visible   bool
+	Enabled   bool
+	Minimized bool
+	Maximized bool
+}
+
+// InspectWindow takes a snapshot of the properties of hwnd.
+func InspectWindow(hwnd HWND) (*WindowInfo, error) {
+	if !IsWindow(hwnd) {
+		return nil, ErrWindowNotFound
+	}
+
+	info := &WindowInfo{
+		HWND:      hwnd,
+		Class:     WindowClassName(hwnd),
+		Title:     WindowText(hwnd),
+		Style:     uint32(GetWindowLong(hwnd, GWL_STYLE)),
+		ExStyle:   uint32(GetWindowLong(hwnd, GWL_EXSTYLE)),
+		Owner:     GetWindow(hwnd, GW_OWNER),
+		Visible:   IsWindowVisible(hwnd),
+		Enabled:   IsWindowEnabled(hwnd),
+		Minimized: IsIconic(hwnd),
+		Maximized: IsZoomed(hwnd),
+	}
+
+	info.TID = GetWindowThreadProcessId(hwnd, &info.PID)
+
+	if parent := GetAncestor(hwnd, GA_PARENT); parent != GetDesktopWindow() {
+		info.Parent = parent
+	}
+
+	if info.Style&WS_CHILD != 0 {
+		info.ID = int32(GetWindowLongPtr(hwnd, GWLP_ID))
+	}
+
+	info.StyleNames = WindowStyleNames(info.Style)
+	info.ExStyleNames = WindowExStyleNames(info.ExStyle)
+
+	GetWindowRect(hwnd, &info.Rect)
+	GetClientRect(hwnd, &info.ClientRect)
+
+	// The window may have been destroyed while taking the snapshot.
+	if !IsWindow(hwnd) {
+		return nil, ErrWindowNotFound
+	}
+
+	return info, nil
+}
+
+// String returns a one-line summary of the window.
+func (info *WindowInfo) String() string {
+	return fmt.Sprintf("%08X %q %q pid=%d tid=%d (%d,%d)-(%d,%d) %s",
+		info.HWND, info.Class, info.Title, info.PID, info.TID,
+		info.Rect.Left, info.Rect.Top, info.Rect.Right, info.Rect.Bottom,
+		strings.Join(info.StyleNames, "|"))
+}
+
+// WindowText returns the title of hwnd, or the text of a control.
+//
+// For windows of other processes, GetWindowText only returns the title, not
+// the text of controls; use WM_GETTEXT for those.
+func WindowText(hwnd HWND) string {
+	n := GetWindowTextLength(hwnd)
+	if n <= 0 {
+		return ""
+	}
+
+	buf := make([]uint16, n+1)
+	n = GetWindowText(hwnd, &buf[0], int32(len(buf)))
+
+	return syscall.UTF16ToString(buf[:n])
+}
+
+type styleName struct {
+	mask uint32
+	name string
+}
+
+// windowStyleNames lists the generic window styles. Combined styles come
+// before their parts, so that they are used if all parts are set.
+var windowStyleNames = []styleName{
+	{WS_POPUP, "WS_POPUP"},
+	{WS_CHILD, "WS_CHILD"},
+	{WS_MINIMIZE, "WS_MINIMIZE"},
+	{WS_VISIBLE, "WS_VISIBLE"},
+	{WS_DISABLED, "WS_DISABLED"},
+	{WS_CLIPSIBLINGS, "WS_CLIPSIBLINGS"},
+	{WS_CLIPCHILDREN, "WS_CLIPCHILDREN"},
+	{WS_MAXIMIZE, "WS_MAXIMIZE"},
+	{WS_CAPTION, "WS_CAPTION"},
+	{WS_BORDER, "WS_BORDER"},
+	{WS_DLGFRAME, "WS_DLGFRAME"},
+	{WS_VSCROLL, "WS_VSCROLL"},
+	{WS_HSCROLL, "WS_HSCROLL"},
+	{WS_SYSMENU, "WS_SYSMENU"},
+	{WS_THICKFRAME, "WS_THICKFRAME"},
+}
+
+var windowExStyleNames = []styleName{
+	{WS_EX_DLGMODALFRAME, "WS_EX_DLGMODALFRAME"},
+	{WS_EX_NOPARENTNOTIFY, "WS_EX_NOPARENTNOTIFY"},
+	{WS_EX_TOPMOST, "WS_EX_TOPMOST"},
+	{WS_EX_ACCEPTFILES, "WS_EX_ACCEPTFILES"},
+	{WS_EX_TRANSPARENT, "WS_EX_TRANSPARENT"},
+	{WS_EX_MDICHILD, "WS_EX_MDICHILD"},
+	{WS_EX_TOOLWINDOW, "WS_EX_TOOLWINDOW"},
+	{WS_EX_WINDOWEDGE, "WS_EX_WINDOWEDGE"},
+	{WS_EX_CLIENTEDGE, "WS_EX_CLIENTEDGE"},
+	{WS_EX_CONTEXTHELP, "WS_EX_CONTEXTHELP"},
+	{WS_EX_RIGHT, "WS_EX_RIGHT"},
+	{WS_EX_RTLREADING, "WS_EX_RTLREADING"},
+	{WS_EX_LEFTSCROLLBAR, "WS_EX_LEFTSCROLLBAR"},
+	{WS_EX_CONTROLPARENT, "WS_EX_CONTROLPARENT"},
+	{WS_EX_STATICEDGE, "WS_EX_STATICEDGE"},
+	{WS_EX_APPWINDOW, "WS_EX_APPWINDOW"},
+	{WS_EX_LAYERED, "WS_EX_LAYERED"},
+	{WS_EX_NOINHERITLAYOUT, "WS_EX_NOINHERITLAYOUT"},
+	{WS_EX_LAYOUTRTL, "WS_EX_LAYOUTRTL"},
+	{WS_EX_COMPOSITED, "WS_EX_COMPOSITED"},
+	{WS_EX_NOACTIVATE, "WS_EX_NOACTIVATE"},
+}
+
+func decodeStyle(style uint32, names []styleName) ([]string, uint32) {
+	var result []string
+
+	for _, n := range names {
+		if style&n.mask == n.mask {
+			result = append(result, n.name)
+			style &^= n.mask
+		}
+	}
+
+	return result, style
+}
+
+// WindowStyleNames returns the names of the WS_* flags set in style.
+// 0x00020000 and 0x00010000 are named WS_GROUP and WS_TABSTOP for child
+// windows and WS_MINIMIZEBOX and WS_MAXIMIZEBOX otherwise. The class
+// specific low word is returned as a hex number.
+func WindowStyleNames(style uint32) []string {
+	names, rest := decodeStyle(style, windowStyleNames)
+
+	group, tabStop := "WS_MINIMIZEBOX", "WS_MAXIMIZEBOX"
+	if style&WS_CHILD != 0 {
+		group, tabStop = "WS_GROUP", "WS_TABSTOP"
+	}
+	if rest&WS_GROUP != 0 {
+		names = append(names, group)
+	}
+	if rest&WS_TABSTOP != 0 {
+		names = append(names, tabStop)
+	}
+	rest &^= WS_GROUP | WS_TABSTOP
+
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("0x%X", rest))
+	}
+
+	return names
+}
+
+// WindowExStyleNames returns the names of the WS_EX_* flags set in
+// exStyle. Unknown flags are returned as a hex number.
+func WindowExStyleNames(exStyle uint32) []string {
+	names, rest := decodeStyle(exStyle, windowExStyleNames)
+
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("0x%X", rest))
+	}
+
+	return names
+}
+
+// The enumeration callbacks are created once, since the number of
+// callbacks is limited, and find the Go function by the id in lParam.
+var enumWindowsCallbacks = struct {
+	sync.Mutex
+	once   sync.Once
+	ptr    uintptr
+	nextID uintptr
+	byID   map[uintptr]func(hwnd HWND) bool
+}{
+	byID: make(map[uintptr]func(hwnd HWND) bool),
+}
+
+func enumWindowsProc(hwnd HWND, lParam uintptr) uintptr {
+	enumWindowsCallbacks.Lock()
+	f := enumWindowsCallbacks.byID[lParam]
+	enumWindowsCallbacks.Unlock()
+
+	if f != nil && f(hwnd) {
+		return 1
+	}
+
+	return 0
+}
+
+// collectWindows calls enum with a callback that calls f and collects the
+// windows for which f returns true.
+func collectWindows(enum func(callback, lParam uintptr) bool, f func(hwnd HWND) bool) []HWND {
+	cb := &enumWindowsCallbacks
+
+	cb.once.Do(func() {
+		cb.ptr = syscall.NewCallback(enumWindowsProc)
+	})
+
+	var hwnds []HWND
+
+	cb.Lock()
+	cb.nextID++
+	id := cb.nextID
+	cb.byID[id] = func(hwnd HWND) bool {
+		if f == nil || f(hwnd) {
+			hwnds = append(hwnds, hwnd)
+		}
+		return true
+	}
+	cb.Unlock()
+
+	enum(cb.ptr, id)
+
+	cb.Lock()
+	delete(cb.byID, id)
+	cb.Unlock()
+
+	return hwnds
+}
+
+// TopLevelWindows returns the top-level windows in z-order.
+func TopLevelWindows() []HWND {
+	return collectWindows(EnumWindows, nil)
+}
+
+// ThreadWindows returns the top-level windows of the thread tid.
+func ThreadWindows(tid uint32) []HWND {
+	return collectWindows(func(callback, lParam uintptr) bool {
+		return EnumThreadWindows(tid, callback, lParam)
+	}, nil)
+}
+
+// ChildWindows returns the direct children of parent in z-order. If parent
+// is 0, it returns the top-level windows.
+func ChildWindows(parent HWND) []HWND {
+	if parent == 0 {
+		return TopLevelWindows()
+	}
+
+	// EnumChildWindows also enumerates the descendants of children.
+	return collectWindows(func(callback, lParam uintptr) bool {
+		return EnumChildWindows(parent, callback, lParam)
+	}, func(hwnd HWND) bool {
+		return GetAncestor(hwnd, GA_PARENT) == parent
+	})
+}
+
+// WalkWindows calls f for root and its descendants in depth-first order,
+// with the depth relative to root. If root is 0, f is called for all
+// top-level windows and their descendants at depth 0 and up. If f returns
+// ErrSkipChildren, the children of the window are skipped; any other error
+// stops the walk and is returned. Windows destroyed during the walk are
+// skipped.
+func WalkWindows(root HWND, f func(info *WindowInfo, depth int) error) error {
+	if root == 0 {
+		for _, hwnd := range TopLevelWindows() {
+			if err := walkWindows(hwnd, 0, f); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return walkWindows(root, 0, f)
+}
+
+func walkWindows(hwnd HWND, depth int, f func(info *WindowInfo, depth int) error) error {
+	info, err := InspectWindow(hwnd)
+	if err != nil {
+		return nil
+	}
+
+	switch err := f(info, depth); err {
+	case nil:
+
+	case ErrSkipChildren:
+		return nil
+
+	default:
+		return err
+	}
+
+	for _, child := range ChildWindows(hwnd) {
+		if err := walkWindows(child, depth+1, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WindowQuery selects windows by their properties. Zero fields match any
+// window.
+type WindowQuery struct {
+	// Root is the window whose descendants are searched. If it is 0, the
+	// top-level windows are searched.
+	Root HWND
+
+	// Recursive includes the descendants of the windows searched.
+	Recursive bool
+
+	// Class matches the class name case-insensitively.
+	Class string
+
+	// Title matches the window text.
+	Title *regexp.Regexp
+
+	// PID matches the process id.
+	PID uint32
+
+	// VisibleOnly skips hidden windows and, if Recursive is set, their
+	// descendants.
+	VisibleOnly bool
+}
+
+// Match reports whether info matches q, not considering Root and
+// Recursive.
+func (q *WindowQuery) Match(info *WindowInfo) bool {
+	if q.Class != "" && !strings.EqualFold(q.Class, info.Class) {
+		return false
+	}
+	if q.Title != nil && !q.Title.MatchString(info.Title) {
+		return false
+	}
+	if q.PID != 0 && q.PID != info.PID {
+		return false
+	}
+	if q.VisibleOnly && !info.Visible {
+		return false
+	}
+
+	return true
+}
+
+// Find returns all windows matching q.
+func (q *WindowQuery) Find() []*WindowInfo {
+	var result []*WindowInfo
+
+	q.walk(func(info *WindowInfo) bool {
+		result = append(result, info)
+		return true
+	})
+
+	return result
+}
+
+// First returns the first window matching q, or ErrWindowNotFound.
+func (q *WindowQuery) First() (*WindowInfo, error) {
+	var result *WindowInfo
+
+	q.walk(func(info *WindowInfo) bool {
+		result = info
+		return false
+	})
+
+	if result == nil {
+		return nil, ErrWindowNotFound
+	}
+
+	return result, nil
+}
+
+var errStopQuery = errors.New("stop query")
+
+func (q *WindowQuery) walk(f func(info *WindowInfo) bool) {
+	for _, hwnd := range ChildWindows(q.Root) {
+		err := WalkWindows(hwnd, func(info *WindowInfo, depth int) error {
+			if q.Match(info) && !f(info) {
+				return errStopQuery
+			}
+
+			if !q.Recursive || (q.VisibleOnly && !info.Visible) {
+				return ErrSkipChildren
+			}
+
+			return nil
+		})
+		if err == errStopQuery {
+			return
+		}
+	}
+}
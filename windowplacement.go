@@ -0,0 +1,179 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// MonitorInfo returns the description of hmonitor.
+func MonitorInfo(hmonitor HMONITOR) (*Monitor, error) {
+	var mi MONITORINFOEX
+	mi.CbSize = uint32(unsafe.Sizeof(mi))
+
+	if !GetMonitorInfo(hmonitor, &mi.MONITORINFO) {
+		return nil, errors.New("GetMonitorInfo failed")
+	}
+
+	return &Monitor{
+		Handle:   hmonitor,
+		Device:   syscall.UTF16ToString(mi.SzDevice[:]),
+		Bounds:   mi.RcMonitor,
+		WorkArea: mi.RcWork,
+		Primary:  mi.DwFlags&MONITORINFOF_PRIMARY != 0,
+		DPI:      MonitorDPI(hmonitor),
+	}, nil
+}
+
+// The enumeration callback is created once, since the number of callbacks
+// is limited. EnumDisplayMonitors calls it synchronously, so enumerations
+// are serialized by the mutex.
+var enumMonitors = struct {
+	sync.Mutex
+	once      sync.Once
+	ptr       uintptr
+	hmonitors []HMONITOR
+}{}
+
+func enumMonitorsProc(hMonitor HMONITOR, hdc HDC, lprcMonitor *RECT, dwData uintptr) uintptr {
+	enumMonitors.hmonitors = append(enumMonitors.hmonitors, hMonitor)
+	return 1
+}
+
+// Monitors returns the monitors of the desktop.
+func Monitors() ([]Monitor, error) {
+	em := &enumMonitors
+
+	em.once.Do(func() {
+		em.ptr = syscall.NewCallback(enumMonitorsProc)
+	})
+
+	em.Lock()
+	em.hmonitors = nil
+	ok := EnumDisplayMonitors(0, nil, em.ptr, 0)
+	hmonitors := em.hmonitors
+	em.hmonitors = nil
+	em.Unlock()
+
+	if !ok {
+		return nil, errors.New("EnumDisplayMonitors failed")
+	}
+
+	monitors := make([]Monitor, 0, len(hmonitors))
+	for _, h := range hmonitors {
+		m, err := MonitorInfo(h)
+		if err != nil {
+			return nil, err
+		}
+		monitors = append(monitors, *m)
+	}
+
+	return monitors, nil
+}
+
+// workspaceOffset returns the offset of workspace coordinates, which
+// WINDOWPLACEMENT uses for windows without WS_EX_TOOLWINDOW, from screen
+// coordinates. Workspace coordinates are relative to the work area of the
+// primary monitor.
+func workspaceOffset(hwnd HWND, monitors []Monitor) (dx, dy int32) {
+	if uint32(GetWindowLong(hwnd, GWL_EXSTYLE))&WS_EX_TOOLWINDOW != 0 {
+		return 0, 0
+	}
+
+	for _, m := range monitors {
+		if m.Primary {
+			return m.WorkArea.Left - m.Bounds.Left, m.WorkArea.Top - m.Bounds.Top
+		}
+	}
+
+	return 0, 0
+}
+
+// GetPlacement returns the placement of hwnd. A minimized window is saved
+// in the state it would be restored to.
+func GetPlacement(hwnd HWND) (*Placement, error) {
+	wp := WINDOWPLACEMENT{Length: uint32(unsafe.Sizeof(WINDOWPLACEMENT{}))}
+	if !GetWindowPlacement(hwnd, &wp) {
+		return nil, errors.New("GetWindowPlacement failed")
+	}
+
+	monitors, err := Monitors()
+	if err != nil {
+		return nil, err
+	}
+
+	dx, dy := workspaceOffset(hwnd, monitors)
+	r := wp.RcNormalPosition
+	r = RECT{r.Left + dx, r.Top + dy, r.Right + dx, r.Bottom + dy}
+
+	p := &Placement{
+		Normal: r,
+		Maximized: wp.ShowCmd == SW_SHOWMAXIMIZED ||
+			wp.ShowCmd == SW_SHOWMINIMIZED && wp.Flags&WPF_RESTORETOMAXIMIZED != 0,
+	}
+
+	if m, err := MonitorInfo(MonitorFromRect(&r, MONITOR_DEFAULTTONEAREST)); err == nil {
+		p.DPI = m.DPI
+		p.Monitor = m.Device
+		p.MonitorBounds = m.Bounds
+	}
+
+	return p, nil
+}
+
+// Restore shows hwnd at p, moved into the work area of the current
+// monitors with Fit.
+func (p *Placement) Restore(hwnd HWND) error {
+	monitors, err := Monitors()
+	if err != nil {
+		return err
+	}
+
+	fit := p.Fit(monitors)
+
+	dx, dy := workspaceOffset(hwnd, monitors)
+	r := fit.Normal
+
+	wp := WINDOWPLACEMENT{
+		Length:           uint32(unsafe.Sizeof(WINDOWPLACEMENT{})),
+		ShowCmd:          SW_SHOWNORMAL,
+		RcNormalPosition: RECT{r.Left - dx, r.Top - dy, r.Right - dx, r.Bottom - dy},
+	}
+	if fit.Maximized {
+		wp.ShowCmd = SW_SHOWMAXIMIZED
+	}
+
+	if !SetWindowPlacement(hwnd, &wp) {
+		return errors.New("SetWindowPlacement failed")
+	}
+
+	return nil
+}
+
+// SaveWindowPlacement returns the placement of hwnd as JSON.
+func SaveWindowPlacement(hwnd HWND) ([]byte, error) {
+	p, err := GetPlacement(hwnd)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(p)
+}
+
+// RestoreWindowPlacement restores a placement saved by SaveWindowPlacement.
+func RestoreWindowPlacement(hwnd HWND, data []byte) error {
+	var p Placement
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+
+	return p.Restore(hwnd)
+}
@@ -0,0 +1,289 @@
+// Copyright 2010 The win Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build windows
+
+package win
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// WinEventName returns the name of the accessibility event event, e.g.
+// "EVENT_OBJECT_NAMECHANGE". Events in the ranges reserved for OEMs, UI
+// Automation and other accessibility frameworks are reported relative to
+// the start of their range, everything else in hex.
+func WinEventName(event uint32) string {
+	if name, ok := eventNames[event]; ok {
+		return name
+	}
+
+	for _, r := range []struct {
+		start, end uint32
+		name       string
+	}{
+		{EVENT_OEM_DEFINED_START, EVENT_OEM_DEFINED_END, "EVENT_OEM_DEFINED_START"},
+		{EVENT_UIA_EVENTID_START, EVENT_UIA_EVENTID_END, "EVENT_UIA_EVENTID_START"},
+		{EVENT_UIA_PROPID_START, EVENT_UIA_PROPID_END, "EVENT_UIA_PROPID_START"},
+		{EVENT_AIA_START, EVENT_AIA_END, "EVENT_AIA_START"},
+	} {
+		if event >= r.start && event <= r.end {
+			return fmt.Sprintf("%s+0x%X", r.name, event-r.start)
+		}
+	}
+
+	return fmt.Sprintf("0x%04X", event)
+}
+
+// WinEvent is an accessibility event reported by a WinEventWatcher.
+type WinEvent struct {
+	Event    uint32
+	HWND     HWND
+	ObjectID int32 // OBJID_* or a custom object id
+	ChildID  int32 // CHILDID_SELF or the child element
+	ThreadID uint32
+	Time     uint32 // in milliseconds, like GetTickCount
+
+	// Window describes HWND at the time the event was received, if
+	// WinEventOptions.ResolveWindows is set. It is nil for events without
+	// a window and for windows that no longer exist, e.g. after
+	// EVENT_OBJECT_DESTROY.
+	Window *WindowInfo
+}
+
+// Name returns the name of e.Event.
+func (e *WinEvent) Name() string {
+	return WinEventName(e.Event)
+}
+
+func (e *WinEvent) String() string {
+	if e.Window != nil {
+		return fmt.Sprintf("%s %s obj=%d child=%d", e.Name(), e.Window, e.ObjectID, e.ChildID)
+	}
+
+	return fmt.Sprintf("%s hwnd=0x%X obj=%d child=%d", e.Name(), e.HWND, e.ObjectID, e.ChildID)
+}
+
+// WinEventOptions selects the events reported by a WinEventWatcher.
+type WinEventOptions struct {
+	// Events lists the events to watch. Each run of consecutive values
+	// gets its own hook. If Events is empty, all events from EVENT_MIN to
+	// EVENT_MAX are watched.
+	Events []uint32
+
+	// PID restricts the events to those raised by a process. 0 watches
+	// all processes.
+	PID uint32
+
+	// SkipOwnProcess drops events raised by the calling process.
+	SkipOwnProcess bool
+
+	// ResolveWindows makes the watcher fill in WinEvent.Window.
+	ResolveWindows bool
+
+	// Buffer is the capacity of WinEventWatcher.C. It defaults to 64.
+	Buffer int
+}
+
+// winEventRange is the event range of a single hook.
+type winEventRange struct {
+	min, max uint32
+}
+
+// winEventRanges merges events into as few ranges as possible.
+func winEventRanges(events []uint32) []winEventRange {
+	if len(events) == 0 {
+		return []winEventRange{{EVENT_MIN, EVENT_MAX}}
+	}
+
+	sorted := append([]uint32(nil), events...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var ranges []winEventRange
+	for _, e := range sorted {
+		if n := len(ranges); n > 0 && e <= ranges[n-1].max+1 {
+			if e > ranges[n-1].max {
+				ranges[n-1].max = e
+			}
+			continue
+		}
+
+		ranges = append(ranges, winEventRange{e, e})
+	}
+
+	return ranges
+}
+
+// WinEventWatcher reports accessibility events raised by SetWinEventHook.
+// The hooks are out-of-context, so they run on a thread of the watcher
+// with its own message loop, not in the processes raising the events.
+type WinEventWatcher struct {
+	dropped uint64 // first for 64-bit alignment of atomic operations
+
+	// C receives the events. Events are dropped while C is full; see
+	// Dropped. C is closed when the watcher stops.
+	C <-chan WinEvent
+
+	c         chan WinEvent
+	resolve   bool
+	loop      *MessageLoop
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// The hook procedure is shared by all watchers, since the number of
+// callbacks is limited, and finds the watcher by the hook handle.
+var winEventWatchers = struct {
+	sync.Mutex
+	byHook map[HWINEVENTHOOK]*WinEventWatcher
+}{
+	byHook: make(map[HWINEVENTHOOK]*WinEventWatcher),
+}
+
+func winEventProc(hWinEventHook HWINEVENTHOOK, event uint32, hwnd HWND, idObject int32, idChild int32, idEventThread uint32, dwmsEventTime uint32) uintptr {
+	winEventWatchers.Lock()
+	w := winEventWatchers.byHook[hWinEventHook]
+	winEventWatchers.Unlock()
+
+	if w != nil {
+		w.deliver(WinEvent{
+			Event:    event,
+			HWND:     hwnd,
+			ObjectID: idObject,
+			ChildID:  idChild,
+			ThreadID: idEventThread,
+			Time:     dwmsEventTime,
+		})
+	}
+
+	return 0
+}
+
+func (w *WinEventWatcher) deliver(e WinEvent) {
+	if w.resolve && e.HWND != 0 {
+		e.Window, _ = InspectWindow(e.HWND)
+	}
+
+	select {
+	case w.c <- e:
+	default:
+		atomic.AddUint64(&w.dropped, 1)
+	}
+}
+
+// WatchWinEvents starts watching the accessibility events selected by opts,
+// which may be nil to watch everything. The watcher stops when ctx is done
+// or Close is called.
+func WatchWinEvents(ctx context.Context, opts *WinEventOptions) (*WinEventWatcher, error) {
+	if opts == nil {
+		opts = &WinEventOptions{}
+	}
+
+	buffer := opts.Buffer
+	if buffer <= 0 {
+		buffer = 64
+	}
+
+	flags := uint32(WINEVENT_OUTOFCONTEXT)
+	if opts.SkipOwnProcess {
+		flags |= WINEVENT_SKIPOWNPROCESS
+	}
+
+	ranges := winEventRanges(opts.Events)
+	c := make(chan WinEvent, buffer)
+	started := make(chan error, 1)
+
+	w := &WinEventWatcher{
+		C:       c,
+		c:       c,
+		resolve: opts.ResolveWindows,
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(w.done)
+		defer close(c)
+
+		loop, err := NewMessageLoop()
+		if err != nil {
+			started <- err
+			return
+		}
+		defer loop.Dispose()
+
+		var hooks []HWINEVENTHOOK
+		defer func() {
+			winEventWatchers.Lock()
+			for _, h := range hooks {
+				delete(winEventWatchers.byHook, h)
+			}
+			winEventWatchers.Unlock()
+
+			for _, h := range hooks {
+				UnhookWinEvent(h)
+			}
+		}()
+
+		for _, r := range ranges {
+			// The events are only delivered while the loop runs, so
+			// the hook can be registered after it is installed.
+			h, err := SetWinEventHook(r.min, r.max, 0, winEventProc, opts.PID, 0, flags)
+			if err != nil {
+				started <- err
+				return
+			}
+			hooks = append(hooks, h)
+
+			winEventWatchers.Lock()
+			winEventWatchers.byHook[h] = w
+			winEventWatchers.Unlock()
+		}
+
+		w.loop = loop
+		started <- nil
+
+		loop.Run()
+	}()
+
+	if err := <-started; err != nil {
+		<-w.done
+		return nil, err
+	}
+
+	if ctx != nil && ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				w.Close()
+
+			case <-w.done:
+			}
+		}()
+	}
+
+	return w, nil
+}
+
+// Dropped returns the number of events dropped because C was full.
+func (w *WinEventWatcher) Dropped() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
+
+// Close removes the hooks, stops the watcher and closes C.
+func (w *WinEventWatcher) Close() error {
+	var err error
+
+	w.closeOnce.Do(func() {
+		err = w.loop.Quit(0)
+		if err == nil {
+			<-w.done
+		}
+	})
+
+	return err
+}
@@ -24,6 +24,12 @@ const (
 	PRINTER_ENUM_NETWORK     = 0x00000040
 )
 
+type PRINTER_DEFAULTS struct {
+	PDatatype     *uint16
+	PDevMode      *DEVMODE
+	DesiredAccess uint32
+}
+
 type PRINTER_INFO_4 struct {
 	PPrinterName *uint16
 	PServerName  *uint16
@@ -35,10 +41,12 @@ var (
 	libwinspool *windows.LazyDLL
 
 	// Functions
+	closePrinter       *windows.LazyProc
 	deviceCapabilities *windows.LazyProc
 	documentProperties *windows.LazyProc
 	enumPrinters       *windows.LazyProc
 	getDefaultPrinter  *windows.LazyProc
+	openPrinter        *windows.LazyProc
 )
 
 func init() {
@@ -46,10 +54,21 @@ func init() {
 	libwinspool = windows.NewLazySystemDLL("winspool.drv")
 
 	// Functions
+	closePrinter = libwinspool.NewProc("ClosePrinter")
 	deviceCapabilities = libwinspool.NewProc("DeviceCapabilitiesW")
 	documentProperties = libwinspool.NewProc("DocumentPropertiesW")
 	enumPrinters = libwinspool.NewProc("EnumPrintersW")
 	getDefaultPrinter = libwinspool.NewProc("GetDefaultPrinterW")
+	openPrinter = libwinspool.NewProc("OpenPrinterW")
+}
+
+func ClosePrinter(hPrinter HANDLE) bool {
+	ret, _, _ := syscall.Syscall(closePrinter.Addr(), 1,
+		uintptr(hPrinter),
+		0,
+		0)
+
+	return ret != 0
 }
 
 func DeviceCapabilities(pDevice, pPort *uint16, fwCapability uint16, pOutput *uint16, pDevMode *DEVMODE) uint32 {
@@ -99,3 +118,12 @@ func GetDefaultPrinter(pszBuffer *uint16, pcchBuffer *uint32) bool {
 
 	return ret != 0
 }
+
+func OpenPrinter(pPrinterName *uint16, phPrinter *HANDLE, pDefault *PRINTER_DEFAULTS) bool {
+	ret, _, _ := syscall.Syscall(openPrinter.Addr(), 3,
+		uintptr(unsafe.Pointer(pPrinterName)),
+		uintptr(unsafe.Pointer(phPrinter)),
+		uintptr(unsafe.Pointer(pDefault)))
+
+	return ret != 0
+}
@@ -0,0 +1,1001 @@
+// Code generated by mkmsgnames.go; DO NOT EDIT.
+
+// +build windows
+
+package win
+
+var messageNames = map[uint32]string{
+	0x0000: "WM_NULL",
+	0x0001: "WM_CREATE",
+	0x0002: "WM_DESTROY",
+	0x0003: "WM_MOVE",
+	0x0005: "WM_SIZE",
+	0x0006: "WM_ACTIVATE",
+	0x0007: "WM_SETFOCUS",
+	0x0008: "WM_KILLFOCUS",
+	0x000A: "WM_ENABLE",
+	0x000B: "WM_SETREDRAW",
+	0x000C: "WM_SETTEXT",
+	0x000D: "WM_GETTEXT",
+	0x000E: "WM_GETTEXTLENGTH",
+	0x000F: "WM_PAINT",
+	0x0010: "WM_CLOSE",
+	0x0011: "WM_QUERYENDSESSION",
+	0x0012: "WM_QUIT",
+	0x0013: "WM_QUERYOPEN",
+	0x0014: "WM_ERASEBKGND",
+	0x0015: "WM_SYSCOLORCHANGE",
+	0x0016: "WM_ENDSESSION",
+	0x0018: "WM_SHOWWINDOW",
+	0x001A: "WM_SETTINGCHANGE",
+	0x001B: "WM_DEVMODECHANGE",
+	0x001C: "WM_ACTIVATEAPP",
+	0x001D: "WM_FONTCHANGE",
+	0x001E: "WM_TIMECHANGE",
+	0x001F: "WM_CANCELMODE",
+	0x0020: "WM_SETCURSOR",
+	0x0021: "WM_MOUSEACTIVATE",
+	0x0022: "WM_CHILDACTIVATE",
+	0x0023: "WM_QUEUESYNC",
+	0x0024: "WM_GETMINMAXINFO",
+	0x0026: "WM_PAINTICON",
+	0x0027: "WM_ICONERASEBKGND",
+	0x0028: "WM_NEXTDLGCTL",
+	0x002A: "WM_SPOOLERSTATUS",
+	0x002B: "WM_DRAWITEM",
+	0x002C: "WM_MEASUREITEM",
+	0x002D: "WM_DELETEITEM",
+	0x002E: "WM_VKEYTOITEM",
+	0x002F: "WM_CHARTOITEM",
+	0x0030: "WM_SETFONT",
+	0x0031: "WM_GETFONT",
+	0x0032: "WM_SETHOTKEY",
+	0x0033: "WM_GETHOTKEY",
+	0x0037: "WM_QUERYDRAGICON",
+	0x0039: "WM_COMPAREITEM",
+	0x003D: "WM_GETOBJECT",
+	0x0041: "WM_COMPACTING",
+	0x0044: "WM_COMMNOTIFY",
+	0x0046: "WM_WINDOWPOSCHANGING",
+	0x0047: "WM_WINDOWPOSCHANGED",
+	0x0048: "WM_POWER",
+	0x004A: "WM_COPYDATA",
+	0x004B: "WM_CANCELJOURNAL",
+	0x004E: "WM_NOTIFY",
+	0x0050: "WM_INPUTLANGCHANGEREQUEST",
+	0x0051: "WM_INPUTLANGCHANGE",
+	0x0052: "WM_TCARD",
+	0x0053: "WM_HELP",
+	0x0054: "WM_USERCHANGED",
+	0x0055: "WM_NOTIFYFORMAT",
+	0x007B: "WM_CONTEXTMENU",
+	0x007C: "WM_STYLECHANGING",
+	0x007D: "WM_STYLECHANGED",
+	0x007E: "WM_DISPLAYCHANGE",
+	0x007F: "WM_GETICON",
+	0x0080: "WM_SETICON",
+	0x0081: "WM_NCCREATE",
+	0x0082: "WM_NCDESTROY",
+	0x0083: "WM_NCCALCSIZE",
+	0x0084: "WM_NCHITTEST",
+	0x0085: "WM_NCPAINT",
+	0x0086: "WM_NCACTIVATE",
+	0x0087: "WM_GETDLGCODE",
+	0x0088: "WM_SYNCPAINT",
+	0x00A0: "WM_NCMOUSEMOVE",
+	0x00A1: "WM_NCLBUTTONDOWN",
+	0x00A2: "WM_NCLBUTTONUP",
+	0x00A3: "WM_NCLBUTTONDBLCLK",
+	0x00A4: "WM_NCRBUTTONDOWN",
+	0x00A5: "WM_NCRBUTTONUP",
+	0x00A6: "WM_NCRBUTTONDBLCLK",
+	0x00A7: "WM_NCMBUTTONDOWN",
+	0x00A8: "WM_NCMBUTTONUP",
+	0x00A9: "WM_NCMBUTTONDBLCLK",
+	0x00AB: "WM_NCXBUTTONDOWN",
+	0x00AC: "WM_NCXBUTTONUP",
+	0x00AD: "WM_NCXBUTTONDBLCLK",
+	0x00FE: "WM_INPUT_DEVICE_CHANGE",
+	0x00FF: "WM_INPUT",
+	0x0100: "WM_KEYDOWN",
+	0x0101: "WM_KEYUP",
+	0x0102: "WM_CHAR",
+	0x0103: "WM_DEADCHAR",
+	0x0104: "WM_SYSKEYDOWN",
+	0x0105: "WM_SYSKEYUP",
+	0x0106: "WM_SYSCHAR",
+	0x0107: "WM_SYSDEADCHAR",
+	0x0109: "WM_UNICHAR",
+	0x0110: "WM_INITDIALOG",
+	0x0111: "WM_COMMAND",
+	0x0112: "WM_SYSCOMMAND",
+	0x0113: "WM_TIMER",
+	0x0114: "WM_HSCROLL",
+	0x0115: "WM_VSCROLL",
+	0x0116: "WM_INITMENU",
+	0x0117: "WM_INITMENUPOPUP",
+	0x011F: "WM_MENUSELECT",
+	0x0120: "WM_MENUCHAR",
+	0x0121: "WM_ENTERIDLE",
+	0x0122: "WM_MENURBUTTONUP",
+	0x0123: "WM_MENUDRAG",
+	0x0124: "WM_MENUGETOBJECT",
+	0x0125: "WM_UNINITMENUPOPUP",
+	0x0126: "WM_MENUCOMMAND",
+	0x0127: "WM_CHANGEUISTATE",
+	0x0128: "WM_UPDATEUISTATE",
+	0x0129: "WM_QUERYUISTATE",
+	0x0132: "WM_CTLCOLORMSGBOX",
+	0x0133: "WM_CTLCOLOREDIT",
+	0x0134: "WM_CTLCOLORLISTBOX",
+	0x0135: "WM_CTLCOLORBTN",
+	0x0136: "WM_CTLCOLORDLG",
+	0x0137: "WM_CTLCOLORSCROLLBAR",
+	0x0138: "WM_CTLCOLORSTATIC",
+	0x0200: "WM_MOUSEMOVE",
+	0x0201: "WM_LBUTTONDOWN",
+	0x0202: "WM_LBUTTONUP",
+	0x0203: "WM_LBUTTONDBLCLK",
+	0x0204: "WM_RBUTTONDOWN",
+	0x0205: "WM_RBUTTONUP",
+	0x0206: "WM_RBUTTONDBLCLK",
+	0x0207: "WM_MBUTTONDOWN",
+	0x0208: "WM_MBUTTONUP",
+	0x0209: "WM_MBUTTONDBLCLK",
+	0x020A: "WM_MOUSEWHEEL",
+	0x020B: "WM_XBUTTONDOWN",
+	0x020C: "WM_XBUTTONUP",
+	0x020D: "WM_XBUTTONDBLCLK",
+	0x020E: "WM_MOUSEHWHEEL",
+	0x0210: "WM_PARENTNOTIFY",
+	0x0211: "WM_ENTERMENULOOP",
+	0x0212: "WM_EXITMENULOOP",
+	0x0213: "WM_NEXTMENU",
+	0x0214: "WM_SIZING",
+	0x0215: "WM_CAPTURECHANGED",
+	0x0216: "WM_MOVING",
+	0x0218: "WM_POWERBROADCAST",
+	0x0219: "WM_DEVICECHANGE",
+	0x0220: "WM_MDICREATE",
+	0x0221: "WM_MDIDESTROY",
+	0x0222: "WM_MDIACTIVATE",
+	0x0223: "WM_MDIRESTORE",
+	0x0224: "WM_MDINEXT",
+	0x0225: "WM_MDIMAXIMIZE",
+	0x0226: "WM_MDITILE",
+	0x0227: "WM_MDICASCADE",
+	0x0228: "WM_MDIICONARRANGE",
+	0x0229: "WM_MDIGETACTIVE",
+	0x0230: "WM_MDISETMENU",
+	0x0231: "WM_ENTERSIZEMOVE",
+	0x0232: "WM_EXITSIZEMOVE",
+	0x0233: "WM_DROPFILES",
+	0x0234: "WM_MDIREFRESHMENU",
+	0x02A0: "WM_NCMOUSEHOVER",
+	0x02A1: "WM_MOUSEHOVER",
+	0x02A2: "WM_NCMOUSELEAVE",
+	0x02A3: "WM_MOUSELEAVE",
+	0x02E0: "WM_DPICHANGED",
+	0x0300: "WM_CUT",
+	0x0301: "WM_COPY",
+	0x0302: "WM_PASTE",
+	0x0303: "WM_CLEAR",
+	0x0304: "WM_UNDO",
+	0x0305: "WM_RENDERFORMAT",
+	0x0306: "WM_RENDERALLFORMATS",
+	0x0307: "WM_DESTROYCLIPBOARD",
+	0x0308: "WM_DRAWCLIPBOARD",
+	0x0309: "WM_PAINTCLIPBOARD",
+	0x030A: "WM_VSCROLLCLIPBOARD",
+	0x030B: "WM_SIZECLIPBOARD",
+	0x030C: "WM_ASKCBFORMATNAME",
+	0x030D: "WM_CHANGECBCHAIN",
+	0x030E: "WM_HSCROLLCLIPBOARD",
+	0x030F: "WM_QUERYNEWPALETTE",
+	0x0310: "WM_PALETTEISCHANGING",
+	0x0311: "WM_PALETTECHANGED",
+	0x0312: "WM_HOTKEY",
+	0x0317: "WM_PRINT",
+	0x0318: "WM_PRINTCLIENT",
+	0x0319: "WM_APPCOMMAND",
+	0x031A: "WM_THEMECHANGED",
+	0x031D: "WM_CLIPBOARDUPDATE",
+	0x0400: "WM_USER",
+	0x8000: "WM_APP",
+}
+
+var notificationNames = map[uint32]string{
+	0xFFFFFC90: "SBN_SIMPLEMODECHANGE",
+	0xFFFFFD09: "DTN_DATETIMECHANGE",
+	0xFFFFFD0E: "DTN_DROPDOWN",
+	0xFFFFFD0F: "DTN_CLOSEUP",
+	0xFFFFFD17: "DTN_USERSTRING",
+	0xFFFFFD18: "DTN_WMKEYDOWN",
+	0xFFFFFD19: "DTN_FORMAT",
+	0xFFFFFD1A: "DTN_FORMATQUERY",
+	0xFFFFFD2E: "UDN_DELTAPOS",
+	0xFFFFFD3A: "TBN_DROPDOWN",
+	0xFFFFFDD6: "TCN_FOCUSCHANGE",
+	0xFFFFFDD7: "TCN_GETOBJECT",
+	0xFFFFFDD8: "TCN_SELCHANGING",
+	0xFFFFFDD9: "TCN_SELCHANGE",
+	0xFFFFFDDA: "TCN_KEYDOWN",
+	0xFFFFFE34: "TVN_ENDLABELEDIT",
+	0xFFFFFE35: "TVN_BEGINLABELEDIT",
+	0xFFFFFE36: "TVN_DELETEITEM",
+	0xFFFFFE37: "TVN_BEGINRDRAG",
+	0xFFFFFE38: "TVN_BEGINDRAG",
+	0xFFFFFE39: "TVN_ITEMEXPANDED",
+	0xFFFFFE3A: "TVN_ITEMEXPANDING",
+	0xFFFFFE3C: "TVN_GETDISPINFO",
+	0xFFFFFE3D: "TVN_SELCHANGED",
+	0xFFFFFE3E: "TVN_SELCHANGING",
+	0xFFFFFE5C: "TVN_ASYNCDRAW",
+	0xFFFFFE5D: "TVN_ITEMCHANGED",
+	0xFFFFFE5F: "TVN_ITEMCHANGING",
+	0xFFFFFE61: "TVN_SINGLEEXPAND",
+	0xFFFFFE62: "TVN_GETINFOTIP",
+	0xFFFFFE64: "TVN_KEYDOWN",
+	0xFFFFFEB6: "HDN_GETDISPINFO",
+	0xFFFFFEB7: "HDN_TRACK",
+	0xFFFFFEB8: "HDN_ENDTRACK",
+	0xFFFFFEB9: "HDN_BEGINTRACK",
+	0xFFFFFEBA: "HDN_DIVIDERDBLCLICK",
+	0xFFFFFEBC: "HDN_ITEMDBLCLICK",
+	0xFFFFFEBD: "HDN_ITEMCLICK",
+	0xFFFFFEBE: "HDN_ITEMCHANGED",
+	0xFFFFFEBF: "HDN_ITEMCHANGING",
+	0xFFFFFEC0: "HDN_OVERFLOWCLICK",
+	0xFFFFFEC1: "HDN_DROPDOWN",
+	0xFFFFFEC2: "HDN_ITEMKEYDOWN",
+	0xFFFFFEC3: "HDN_ITEMSTATEICONCLICK",
+	0xFFFFFEC4: "HDN_ENDFILTEREDIT",
+	0xFFFFFEC5: "HDN_BEGINFILTEREDIT",
+	0xFFFFFEC6: "HDN_FILTERBTNCLICK",
+	0xFFFFFEC7: "HDN_FILTERCHANGE",
+	0xFFFFFEC8: "HDN_ENDDRAG",
+	0xFFFFFEC9: "HDN_BEGINDRAG",
+	0xFFFFFF4B: "LVN_ENDSCROLL",
+	0xFFFFFF4C: "LVN_BEGINSCROLL",
+	0xFFFFFF4D: "LVN_ODFINDITEM",
+	0xFFFFFF4E: "LVN_SETDISPINFO",
+	0xFFFFFF4F: "LVN_GETDISPINFO",
+	0xFFFFFF50: "LVN_ENDLABELEDIT",
+	0xFFFFFF51: "LVN_BEGINLABELEDIT",
+	0xFFFFFF5D: "LVN_INCREMENTALSEARCH",
+	0xFFFFFF62: "LVN_GETINFOTIP",
+	0xFFFFFF64: "LVN_MARQUEEBEGIN",
+	0xFFFFFF65: "LVN_KEYDOWN",
+	0xFFFFFF87: "LVN_HOTTRACK",
+	0xFFFFFF8D: "LVN_ODSTATECHANGED",
+	0xFFFFFF8E: "LVN_ITEMACTIVATE",
+	0xFFFFFF8F: "LVN_ODCACHEHINT",
+	0xFFFFFF91: "LVN_BEGINRDRAG",
+	0xFFFFFF93: "LVN_BEGINDRAG",
+	0xFFFFFF94: "LVN_COLUMNCLICK",
+	0xFFFFFF98: "LVN_DELETEALLITEMS",
+	0xFFFFFF99: "LVN_DELETEITEM",
+	0xFFFFFF9A: "LVN_INSERTITEM",
+	0xFFFFFF9B: "LVN_ITEMCHANGED",
+	0xFFFFFF9C: "LVN_ITEMCHANGING",
+	0xFFFFFFED: "NM_TOOLTIPSCREATED",
+	0xFFFFFFEE: "NM_CHAR",
+	0xFFFFFFEF: "NM_SETCURSOR",
+	0xFFFFFFF0: "NM_RELEASEDCAPTURE",
+	0xFFFFFFF1: "NM_KEYDOWN",
+	0xFFFFFFF2: "NM_NCHITTEST",
+	0xFFFFFFF3: "NM_HOVER",
+	0xFFFFFFF4: "NM_CUSTOMDRAW",
+	0xFFFFFFF8: "NM_KILLFOCUS",
+	0xFFFFFFF9: "NM_SETFOCUS",
+	0xFFFFFFFA: "NM_RDBLCLK",
+	0xFFFFFFFB: "NM_RCLICK",
+	0xFFFFFFFC: "NM_RETURN",
+	0xFFFFFFFD: "NM_DBLCLK",
+	0xFFFFFFFE: "NM_CLICK",
+	0xFFFFFFFF: "NM_OUTOFMEMORY",
+}
+
+var eventNames = map[uint32]string{
+	0x0001: "EVENT_SYSTEM_SOUND",
+	0x0002: "EVENT_SYSTEM_ALERT",
+	0x0003: "EVENT_SYSTEM_FOREGROUND",
+	0x0004: "EVENT_SYSTEM_MENUSTART",
+	0x0005: "EVENT_SYSTEM_MENUEND",
+	0x0006: "EVENT_SYSTEM_MENUPOPUPSTART",
+	0x0007: "EVENT_SYSTEM_MENUPOPUPEND",
+	0x0008: "EVENT_SYSTEM_CAPTURESTART",
+	0x0009: "EVENT_SYSTEM_CAPTUREEND",
+	0x000A: "EVENT_SYSTEM_MOVESIZESTART",
+	0x000B: "EVENT_SYSTEM_MOVESIZEEND",
+	0x000C: "EVENT_SYSTEM_CONTEXTHELPSTART",
+	0x000D: "EVENT_SYSTEM_CONTEXTHELPEND",
+	0x000E: "EVENT_SYSTEM_DRAGDROPSTART",
+	0x000F: "EVENT_SYSTEM_DRAGDROPEND",
+	0x0010: "EVENT_SYSTEM_DIALOGSTART",
+	0x0011: "EVENT_SYSTEM_DIALOGEND",
+	0x0012: "EVENT_SYSTEM_SCROLLINGSTART",
+	0x0013: "EVENT_SYSTEM_SCROLLINGEND",
+	0x0014: "EVENT_SYSTEM_SWITCHSTART",
+	0x0015: "EVENT_SYSTEM_SWITCHEND",
+	0x0016: "EVENT_SYSTEM_MINIMIZESTART",
+	0x0017: "EVENT_SYSTEM_MINIMIZEEND",
+	0x0020: "EVENT_SYSTEM_DESKTOPSWITCH",
+	0x0024: "EVENT_SYSTEM_SWITCHER_APPGRABBED",
+	0x0025: "EVENT_SYSTEM_SWITCHER_APPOVERTARGET",
+	0x0026: "EVENT_SYSTEM_SWITCHER_APPDROPPED",
+	0x0027: "EVENT_SYSTEM_SWITCHER_CANCELLED",
+	0x0029: "EVENT_SYSTEM_IME_KEY_NOTIFICATION",
+	0x4001: "EVENT_CONSOLE_CARET",
+	0x4002: "EVENT_CONSOLE_UPDATE_REGION",
+	0x4003: "EVENT_CONSOLE_UPDATE_SIMPLE",
+	0x4004: "EVENT_CONSOLE_UPDATE_SCROLL",
+	0x4005: "EVENT_CONSOLE_LAYOUT",
+	0x4006: "EVENT_CONSOLE_START_APPLICATION",
+	0x4007: "EVENT_CONSOLE_END_APPLICATION",
+	0x8000: "EVENT_OBJECT_CREATE",
+	0x8001: "EVENT_OBJECT_DESTROY",
+	0x8002: "EVENT_OBJECT_SHOW",
+	0x8003: "EVENT_OBJECT_HIDE",
+	0x8004: "EVENT_OBJECT_REORDER",
+	0x8005: "EVENT_OBJECT_FOCUS",
+	0x8006: "EVENT_OBJECT_SELECTION",
+	0x8007: "EVENT_OBJECT_SELECTIONADD",
+	0x8008: "EVENT_OBJECT_SELECTIONREMOVE",
+	0x8009: "EVENT_OBJECT_SELECTIONWITHIN",
+	0x800A: "EVENT_OBJECT_STATECHANGE",
+	0x800B: "EVENT_OBJECT_LOCATIONCHANGE",
+	0x800C: "EVENT_OBJECT_NAMECHANGE",
+	0x800D: "EVENT_OBJECT_DESCRIPTIONCHANGE",
+	0x800E: "EVENT_OBJECT_VALUECHANGE",
+	0x800F: "EVENT_OBJECT_PARENTCHANGE",
+	0x8010: "EVENT_OBJECT_HELPCHANGE",
+	0x8011: "EVENT_OBJECT_DEFACTIONCHANGE",
+	0x8012: "EVENT_OBJECT_ACCELERATORCHANGE",
+	0x8013: "EVENT_OBJECT_INVOKED",
+	0x8014: "EVENT_OBJECT_TEXTSELECTIONCHANGED",
+	0x8015: "EVENT_OBJECT_CONTENTSCROLLED",
+	0x8016: "EVENT_SYSTEM_ARRANGMENTPREVIEW",
+	0x8017: "EVENT_OBJECT_CLOAKED",
+	0x8018: "EVENT_OBJECT_UNCLOAKED",
+	0x8019: "EVENT_OBJECT_LIVEREGIONCHANGED",
+	0x8020: "EVENT_OBJECT_HOSTEDOBJECTSINVALIDATED",
+	0x8021: "EVENT_OBJECT_DRAGSTART",
+	0x8022: "EVENT_OBJECT_DRAGCANCEL",
+	0x8023: "EVENT_OBJECT_DRAGCOMPLETE",
+	0x8024: "EVENT_OBJECT_DRAGENTER",
+	0x8025: "EVENT_OBJECT_DRAGLEAVE",
+	0x8026: "EVENT_OBJECT_DRAGDROPPED",
+	0x8027: "EVENT_OBJECT_IME_SHOW",
+	0x8028: "EVENT_OBJECT_IME_HIDE",
+	0x8029: "EVENT_OBJECT_IME_CHANGE",
+	0x8030: "EVENT_OBJECT_TEXTEDIT_CONVERSIONTARGETCHANGED",
+}
+
+var controlMessageNames = map[string]map[uint32]string{
+	"CB": {
+		0x0140: "CB_GETEDITSEL",
+		0x0141: "CB_LIMITTEXT",
+		0x0142: "CB_SETEDITSEL",
+		0x0143: "CB_ADDSTRING",
+		0x0144: "CB_DELETESTRING",
+		0x0145: "CB_DIR",
+		0x0146: "CB_GETCOUNT",
+		0x0147: "CB_GETCURSEL",
+		0x0148: "CB_GETLBTEXT",
+		0x0149: "CB_GETLBTEXTLEN",
+		0x014A: "CB_INSERTSTRING",
+		0x014B: "CB_RESETCONTENT",
+		0x014C: "CB_FINDSTRING",
+		0x014D: "CB_SELECTSTRING",
+		0x014E: "CB_SETCURSEL",
+		0x014F: "CB_SHOWDROPDOWN",
+		0x0150: "CB_GETITEMDATA",
+		0x0151: "CB_SETITEMDATA",
+		0x0152: "CB_GETDROPPEDCONTROLRECT",
+		0x0153: "CB_SETITEMHEIGHT",
+		0x0154: "CB_GETITEMHEIGHT",
+		0x0155: "CB_SETEXTENDEDUI",
+		0x0156: "CB_GETEXTENDEDUI",
+		0x0157: "CB_GETDROPPEDSTATE",
+		0x0158: "CB_FINDSTRINGEXACT",
+		0x0159: "CB_SETLOCALE",
+		0x015A: "CB_GETLOCALE",
+		0x015B: "CB_GETTOPINDEX",
+		0x015C: "CB_SETTOPINDEX",
+		0x015D: "CB_GETHORIZONTALEXTENT",
+		0x015E: "CB_SETHORIZONTALEXTENT",
+		0x015F: "CB_GETDROPPEDWIDTH",
+		0x0160: "CB_SETDROPPEDWIDTH",
+		0x0161: "CB_INITSTORAGE",
+		0x0163: "CB_MULTIPLEADDSTRING",
+		0x0164: "CB_GETCOMBOBOXINFO",
+	},
+	"DTM": {
+		0x1001: "DTM_GETSYSTEMTIME",
+		0x1002: "DTM_SETSYSTEMTIME",
+		0x1003: "DTM_GETRANGE",
+		0x1004: "DTM_SETRANGE",
+		0x1006: "DTM_SETMCCOLOR",
+		0x1007: "DTM_GETMCCOLOR",
+		0x1008: "DTM_GETMONTHCAL",
+		0x1009: "DTM_SETMCFONT",
+		0x100A: "DTM_GETMCFONT",
+		0x1032: "DTM_SETFORMAT",
+	},
+	"EM": {
+		0x00B0: "EM_GETSEL",
+		0x00B1: "EM_SETSEL",
+		0x00B2: "EM_GETRECT",
+		0x00B3: "EM_SETRECT",
+		0x00B4: "EM_SETRECTNP",
+		0x00B5: "EM_SCROLL",
+		0x00B6: "EM_LINESCROLL",
+		0x00B7: "EM_SCROLLCARET",
+		0x00B8: "EM_GETMODIFY",
+		0x00B9: "EM_SETMODIFY",
+		0x00BA: "EM_GETLINECOUNT",
+		0x00BB: "EM_LINEINDEX",
+		0x00BC: "EM_SETHANDLE",
+		0x00BD: "EM_GETHANDLE",
+		0x00BE: "EM_GETTHUMB",
+		0x00C1: "EM_LINELENGTH",
+		0x00C2: "EM_REPLACESEL",
+		0x00C4: "EM_GETLINE",
+		0x00C5: "EM_LIMITTEXT",
+		0x00C6: "EM_CANUNDO",
+		0x00C7: "EM_UNDO",
+		0x00C8: "EM_FMTLINES",
+		0x00C9: "EM_LINEFROMCHAR",
+		0x00CB: "EM_SETTABSTOPS",
+		0x00CC: "EM_SETPASSWORDCHAR",
+		0x00CD: "EM_EMPTYUNDOBUFFER",
+		0x00CE: "EM_GETFIRSTVISIBLELINE",
+		0x00CF: "EM_SETREADONLY",
+		0x00D0: "EM_SETWORDBREAKPROC",
+		0x00D1: "EM_GETWORDBREAKPROC",
+		0x00D2: "EM_GETPASSWORDCHAR",
+		0x00D3: "EM_SETMARGINS",
+		0x00D4: "EM_GETMARGINS",
+		0x00D5: "EM_GETLIMITTEXT",
+		0x00D6: "EM_POSFROMCHAR",
+		0x00D7: "EM_CHARFROMPOS",
+		0x00D8: "EM_SETIMESTATUS",
+		0x00D9: "EM_GETIMESTATUS",
+		0x0432: "EM_CANPASTE",
+		0x0433: "EM_DISPLAYBAND",
+		0x0434: "EM_EXGETSEL",
+		0x0435: "EM_EXLIMITTEXT",
+		0x0436: "EM_EXLINEFROMCHAR",
+		0x0437: "EM_EXSETSEL",
+		0x0438: "EM_FINDTEXT",
+		0x0439: "EM_FORMATRANGE",
+		0x043A: "EM_GETCHARFORMAT",
+		0x043B: "EM_GETEVENTMASK",
+		0x043C: "EM_GETOLEINTERFACE",
+		0x043D: "EM_GETPARAFORMAT",
+		0x043E: "EM_GETSELTEXT",
+		0x043F: "EM_HIDESELECTION",
+		0x0440: "EM_PASTESPECIAL",
+		0x0441: "EM_REQUESTRESIZE",
+		0x0442: "EM_SELECTIONTYPE",
+		0x0443: "EM_SETBKGNDCOLOR",
+		0x0444: "EM_SETCHARFORMAT",
+		0x0445: "EM_SETEVENTMASK",
+		0x0446: "EM_SETOLECALLBACK",
+		0x0447: "EM_SETPARAFORMAT",
+		0x0448: "EM_SETTARGETDEVICE",
+		0x0449: "EM_STREAMIN",
+		0x044A: "EM_STREAMOUT",
+		0x044B: "EM_GETTEXTRANGE",
+		0x044C: "EM_FINDWORDBREAK",
+		0x044D: "EM_SETOPTIONS",
+		0x044E: "EM_GETOPTIONS",
+		0x044F: "EM_FINDTEXTEX",
+		0x0450: "EM_GETWORDBREAKPROCEX",
+		0x0451: "EM_SETWORDBREAKPROCEX",
+		0x0452: "EM_SETUNDOLIMIT",
+		0x0454: "EM_REDO",
+		0x0455: "EM_CANREDO",
+		0x0456: "EM_GETUNDONAME",
+		0x0457: "EM_GETREDONAME",
+		0x0458: "EM_STOPGROUPTYPING",
+		0x0459: "EM_SETTEXTMODE",
+		0x045A: "EM_GETTEXTMODE",
+		0x045B: "EM_AUTOURLDETECT",
+		0x045C: "EM_GETAUTOURLDETECT",
+		0x045D: "EM_SETPALETTE",
+		0x045E: "EM_GETTEXTEX",
+		0x045F: "EM_GETTEXTLENGTHEX",
+		0x0460: "EM_SHOWSCROLLBAR",
+		0x0461: "EM_SETTEXTEX",
+		0x0464: "EM_SETPUNCTUATION",
+		0x0465: "EM_GETPUNCTUATION",
+		0x0466: "EM_SETWORDWRAPMODE",
+		0x0467: "EM_GETWORDWRAPMODE",
+		0x0468: "EM_SETIMECOLOR",
+		0x0469: "EM_GETIMECOLOR",
+		0x046A: "EM_SETIMEOPTIONS",
+		0x046B: "EM_GETIMEOPTIONS",
+		0x046C: "EM_CONVPOSITION",
+		0x0478: "EM_SETLANGOPTIONS",
+		0x0479: "EM_GETLANGOPTIONS",
+		0x047A: "EM_GETIMECOMPMODE",
+		0x047B: "EM_FINDTEXTW",
+		0x047C: "EM_FINDTEXTEXW",
+		0x047D: "EM_RECONVERSION",
+		0x047E: "EM_SETIMEMODEBIAS",
+		0x047F: "EM_GETIMEMODEBIAS",
+		0x04C8: "EM_SETBIDIOPTIONS",
+		0x04C9: "EM_GETBIDIOPTIONS",
+		0x04CA: "EM_SETTYPOGRAPHYOPTIONS",
+		0x04CB: "EM_GETTYPOGRAPHYOPTIONS",
+		0x04CC: "EM_SETEDITSTYLE",
+		0x04CD: "EM_GETEDITSTYLE",
+		0x04DC: "EM_OUTLINE",
+		0x04DD: "EM_GETSCROLLPOS",
+		0x04DE: "EM_SETSCROLLPOS",
+		0x04DF: "EM_SETFONTSIZE",
+		0x04E0: "EM_GETZOOM",
+		0x04E1: "EM_SETZOOM",
+		0x04E2: "EM_GETVIEWKIND",
+		0x04E3: "EM_SETVIEWKIND",
+		0x04E4: "EM_GETPAGE",
+		0x04E5: "EM_SETPAGE",
+		0x04E6: "EM_GETHYPHENATEINFO",
+		0x04E7: "EM_SETHYPHENATEINFO",
+		0x04E8: "EM_INSERTTABLE",
+		0x04E9: "EM_GETAUTOCORRECTPROC",
+		0x04EA: "EM_SETAUTOCORRECTPROC",
+		0x04EB: "EM_GETPAGEROTATE",
+		0x04EC: "EM_SETPAGEROTATE",
+		0x04ED: "EM_GETCTFMODEBIAS",
+		0x04EE: "EM_SETCTFMODEBIAS",
+		0x04F0: "EM_GETCTFOPENSTATUS",
+		0x04F1: "EM_SETCTFOPENSTATUS",
+		0x04F2: "EM_GETIMECOMPTEXT",
+		0x04F3: "EM_ISIME",
+		0x04F4: "EM_GETIMEPROPERTY",
+		0x04FF: "EM_CALLAUTOCORRECTPROC",
+		0x0509: "EM_GETTABLEPARMS",
+		0x050D: "EM_GETQUERYRTFOBJ",
+		0x050E: "EM_SETQUERYRTFOBJ",
+		0x0513: "EM_SETEDITSTYLEEX",
+		0x0514: "EM_GETEDITSTYLEEX",
+		0x0522: "EM_GETSTORYTYPE",
+		0x0523: "EM_SETSTORYTYPE",
+		0x0531: "EM_GETELLIPSISMODE",
+		0x0532: "EM_SETELLIPSISMODE",
+		0x0533: "EM_SETTABLEPARMS",
+		0x0536: "EM_GETTOUCHOPTIONS",
+		0x0537: "EM_SETTOUCHOPTIONS",
+		0x053A: "EM_INSERTIMAGE",
+		0x0540: "EM_SETUIANAME",
+		0x0542: "EM_GETELLIPSISSTATE",
+		0x1501: "EM_SETCUEBANNER",
+		0x1502: "EM_GETCUEBANNER",
+		0x1511: "EM_SETCARETINDEX",
+		0x1512: "EM_GETCARETINDEX",
+	},
+	"HDM": {
+		0x1200: "HDM_GETITEMCOUNT",
+		0x1202: "HDM_DELETEITEM",
+		0x1205: "HDM_LAYOUT",
+		0x1206: "HDM_HITTEST",
+		0x1207: "HDM_GETITEMRECT",
+		0x1208: "HDM_SETIMAGELIST",
+		0x1209: "HDM_GETIMAGELIST",
+		0x120A: "HDM_INSERTITEM",
+		0x120B: "HDM_GETITEM",
+		0x120C: "HDM_SETITEM",
+		0x120F: "HDM_ORDERTOINDEX",
+		0x1210: "HDM_CREATEDRAGIMAGE",
+		0x1211: "HDM_GETORDERARRAY",
+		0x1212: "HDM_SETORDERARRAY",
+		0x1213: "HDM_SETHOTDIVIDER",
+		0x1214: "HDM_SETBITMAPMARGIN",
+		0x1215: "HDM_GETBITMAPMARGIN",
+		0x1216: "HDM_SETFILTERCHANGETIMEOUT",
+		0x1217: "HDM_EDITFILTER",
+		0x1218: "HDM_CLEARFILTER",
+		0x1219: "HDM_GETITEMDROPDOWNRECT",
+		0x121A: "HDM_GETOVERFLOWRECT",
+		0x121B: "HDM_GETFOCUSEDITEM",
+		0x121C: "HDM_SETFOCUSEDITEM",
+		0x2005: "HDM_SETUNICODEFORMAT",
+		0x2006: "HDM_GETUNICODEFORMAT",
+	},
+	"LB": {
+		0x0180: "LB_ADDSTRING",
+		0x0181: "LB_INSERTSTRING",
+		0x0182: "LB_DELETESTRING",
+		0x0183: "LB_SELITEMRANGEEX",
+		0x0184: "LB_RESETCONTENT",
+		0x0185: "LB_SETSEL",
+		0x0186: "LB_SETCURSEL",
+		0x0187: "LB_GETSEL",
+		0x0188: "LB_GETCURSEL",
+		0x0189: "LB_GETTEXT",
+		0x018A: "LB_GETTEXTLEN",
+		0x018B: "LB_GETCOUNT",
+		0x018C: "LB_SELECTSTRING",
+		0x018D: "LB_DIR",
+		0x018E: "LB_GETTOPINDEX",
+		0x018F: "LB_FINDSTRING",
+		0x0190: "LB_GETSELCOUNT",
+		0x0191: "LB_GETSELITEMS",
+		0x0192: "LB_SETTABSTOPS",
+		0x0193: "LB_GETHORIZONTALEXTENT",
+		0x0194: "LB_SETHORIZONTALEXTENT",
+		0x0195: "LB_SETCOLUMNWIDTH",
+		0x0196: "LB_ADDFILE",
+		0x0197: "LB_SETTOPINDEX",
+		0x0198: "LB_GETITEMRECT",
+		0x0199: "LB_GETITEMDATA",
+		0x019A: "LB_SETITEMDATA",
+		0x019B: "LB_SELITEMRANGE",
+		0x019C: "LB_SETANCHORINDEX",
+		0x019D: "LB_GETANCHORINDEX",
+		0x019E: "LB_SETCARETINDEX",
+		0x019F: "LB_GETCARETINDEX",
+		0x01A0: "LB_SETITEMHEIGHT",
+		0x01A1: "LB_GETITEMHEIGHT",
+		0x01A2: "LB_FINDSTRINGEXACT",
+		0x01A5: "LB_SETLOCALE",
+		0x01A6: "LB_GETLOCALE",
+		0x01A7: "LB_SETCOUNT",
+		0x01A8: "LB_INITSTORAGE",
+		0x01A9: "LB_ITEMFROMPOINT",
+		0x01B1: "LB_MULTIPLEADDSTRING",
+	},
+	"LVM": {
+		0x1001: "LVM_SETBKCOLOR",
+		0x1003: "LVM_SETIMAGELIST",
+		0x1008: "LVM_DELETEITEM",
+		0x1009: "LVM_DELETEALLITEMS",
+		0x100A: "LVM_GETCALLBACKMASK",
+		0x100B: "LVM_SETCALLBACKMASK",
+		0x100C: "LVM_GETNEXTITEM",
+		0x100E: "LVM_GETITEMRECT",
+		0x1012: "LVM_HITTEST",
+		0x1013: "LVM_ENSUREVISIBLE",
+		0x1014: "LVM_SCROLL",
+		0x1015: "LVM_REDRAWITEMS",
+		0x1016: "LVM_ARRANGE",
+		0x1018: "LVM_GETEDITCONTROL",
+		0x101C: "LVM_DELETECOLUMN",
+		0x101D: "LVM_GETCOLUMNWIDTH",
+		0x101E: "LVM_SETCOLUMNWIDTH",
+		0x101F: "LVM_GETHEADER",
+		0x1021: "LVM_CREATEDRAGIMAGE",
+		0x1022: "LVM_GETVIEWRECT",
+		0x1023: "LVM_GETTEXTCOLOR",
+		0x1024: "LVM_SETTEXTCOLOR",
+		0x1025: "LVM_GETTEXTBKCOLOR",
+		0x1026: "LVM_SETTEXTBKCOLOR",
+		0x1027: "LVM_GETTOPINDEX",
+		0x1028: "LVM_GETCOUNTPERPAGE",
+		0x1029: "LVM_GETORIGIN",
+		0x102A: "LVM_UPDATE",
+		0x102B: "LVM_SETITEMSTATE",
+		0x102C: "LVM_GETITEMSTATE",
+		0x102F: "LVM_SETITEMCOUNT",
+		0x1030: "LVM_SORTITEMS",
+		0x1031: "LVM_SETITEMPOSITION32",
+		0x1032: "LVM_GETSELECTEDCOUNT",
+		0x1033: "LVM_GETITEMSPACING",
+		0x1035: "LVM_SETICONSPACING",
+		0x1036: "LVM_SETEXTENDEDLISTVIEWSTYLE",
+		0x1037: "LVM_GETEXTENDEDLISTVIEWSTYLE",
+		0x1038: "LVM_GETSUBITEMRECT",
+		0x1039: "LVM_SUBITEMHITTEST",
+		0x103A: "LVM_SETCOLUMNORDERARRAY",
+		0x103B: "LVM_GETCOLUMNORDERARRAY",
+		0x103C: "LVM_SETHOTITEM",
+		0x103D: "LVM_GETHOTITEM",
+		0x103E: "LVM_SETHOTCURSOR",
+		0x103F: "LVM_GETHOTCURSOR",
+		0x1040: "LVM_APPROXIMATEVIEWRECT",
+		0x1041: "LVM_SETWORKAREAS",
+		0x1042: "LVM_GETSELECTIONMARK",
+		0x1043: "LVM_SETSELECTIONMARK",
+		0x1046: "LVM_GETWORKAREAS",
+		0x1047: "LVM_SETHOVERTIME",
+		0x1048: "LVM_GETHOVERTIME",
+		0x1049: "LVM_GETNUMBEROFWORKAREAS",
+		0x104A: "LVM_SETTOOLTIPS",
+		0x104B: "LVM_GETITEM",
+		0x104C: "LVM_SETITEM",
+		0x104D: "LVM_INSERTITEM",
+		0x104E: "LVM_GETTOOLTIPS",
+		0x1051: "LVM_SORTITEMSEX",
+		0x1053: "LVM_FINDITEM",
+		0x1057: "LVM_GETSTRINGWIDTH",
+		0x105C: "LVM_GETGROUPSTATE",
+		0x105D: "LVM_GETFOCUSEDGROUP",
+		0x105F: "LVM_GETCOLUMN",
+		0x1060: "LVM_SETCOLUMN",
+		0x1061: "LVM_INSERTCOLUMN",
+		0x1062: "LVM_GETGROUPRECT",
+		0x1073: "LVM_GETITEMTEXT",
+		0x1074: "LVM_SETITEMTEXT",
+		0x1075: "LVM_GETISEARCHSTRING",
+		0x1076: "LVM_EDITLABEL",
+		0x108A: "LVM_SETBKIMAGE",
+		0x108B: "LVM_GETBKIMAGE",
+		0x108C: "LVM_SETSELECTEDCOLUMN",
+		0x108E: "LVM_SETVIEW",
+		0x108F: "LVM_GETVIEW",
+		0x1091: "LVM_INSERTGROUP",
+		0x1093: "LVM_SETGROUPINFO",
+		0x1095: "LVM_GETGROUPINFO",
+		0x1096: "LVM_REMOVEGROUP",
+		0x1097: "LVM_MOVEGROUP",
+		0x1098: "LVM_GETGROUPCOUNT",
+		0x1099: "LVM_GETGROUPINFOBYINDEX",
+		0x109A: "LVM_MOVEITEMTOGROUP",
+		0x109B: "LVM_SETGROUPMETRICS",
+		0x109C: "LVM_GETGROUPMETRICS",
+		0x109D: "LVM_ENABLEGROUPVIEW",
+		0x109E: "LVM_SORTGROUPS",
+		0x109F: "LVM_INSERTGROUPSORTED",
+		0x10A0: "LVM_REMOVEALLGROUPS",
+		0x10A1: "LVM_HASGROUP",
+		0x10A2: "LVM_SETTILEVIEWINFO",
+		0x10A3: "LVM_GETTILEVIEWINFO",
+		0x10A4: "LVM_SETTILEINFO",
+		0x10A5: "LVM_GETTILEINFO",
+		0x10A6: "LVM_SETINSERTMARK",
+		0x10A7: "LVM_GETINSERTMARK",
+		0x10A8: "LVM_INSERTMARKHITTEST",
+		0x10A9: "LVM_GETINSERTMARKRECT",
+		0x10AA: "LVM_SETINSERTMARKCOLOR",
+		0x10AB: "LVM_GETINSERTMARKCOLOR",
+		0x10AD: "LVM_SETINFOTIP",
+		0x10AE: "LVM_GETSELECTEDCOLUMN",
+		0x10AF: "LVM_ISGROUPVIEWENABLED",
+		0x10B0: "LVM_GETOUTLINECOLOR",
+		0x10B1: "LVM_SETOUTLINECOLOR",
+		0x10B3: "LVM_CANCELEDITLABEL",
+		0x10B4: "LVM_MAPINDEXTOID",
+		0x10B5: "LVM_MAPIDTOINDEX",
+		0x10B6: "LVM_ISITEMVISIBLE",
+		0x10D3: "LVM_GETNEXTITEMINDEX",
+	},
+	"SB": {
+		0x0404: "SB_SETPARTS",
+		0x0406: "SB_GETPARTS",
+		0x0407: "SB_GETBORDERS",
+		0x0408: "SB_SETMINHEIGHT",
+		0x0409: "SB_SIMPLE",
+		0x040A: "SB_GETRECT",
+		0x040B: "SB_SETTEXT",
+		0x040C: "SB_GETTEXTLENGTH",
+		0x040D: "SB_GETTEXT",
+		0x040E: "SB_ISSIMPLE",
+		0x040F: "SB_SETICON",
+		0x0411: "SB_SETTIPTEXT",
+		0x0413: "SB_GETTIPTEXT",
+		0x0414: "SB_GETICON",
+		0x2001: "SB_SETBKCOLOR",
+		0x2005: "SB_SETUNICODEFORMAT",
+		0x2006: "SB_GETUNICODEFORMAT",
+	},
+	"TB": {
+		0x0401: "TB_ENABLEBUTTON",
+		0x0402: "TB_CHECKBUTTON",
+		0x0403: "TB_PRESSBUTTON",
+		0x0404: "TB_HIDEBUTTON",
+		0x0405: "TB_INDETERMINATE",
+		0x0406: "TB_MARKBUTTON",
+		0x0409: "TB_ISBUTTONENABLED",
+		0x040A: "TB_ISBUTTONCHECKED",
+		0x040B: "TB_ISBUTTONPRESSED",
+		0x040C: "TB_ISBUTTONHIDDEN",
+		0x040D: "TB_ISBUTTONINDETERMINATE",
+		0x040E: "TB_ISBUTTONHIGHLIGHTED",
+		0x0411: "TB_SETSTATE",
+		0x0412: "TB_GETSTATE",
+		0x0413: "TB_ADDBITMAP",
+		0x0416: "TB_DELETEBUTTON",
+		0x0417: "TB_GETBUTTON",
+		0x0418: "TB_BUTTONCOUNT",
+		0x0419: "TB_COMMANDTOINDEX",
+		0x041B: "TB_CUSTOMIZE",
+		0x041D: "TB_GETITEMRECT",
+		0x041E: "TB_BUTTONSTRUCTSIZE",
+		0x041F: "TB_SETBUTTONSIZE",
+		0x0420: "TB_SETBITMAPSIZE",
+		0x0421: "TB_AUTOSIZE",
+		0x0423: "TB_GETTOOLTIPS",
+		0x0424: "TB_SETTOOLTIPS",
+		0x0425: "TB_SETPARENT",
+		0x0427: "TB_SETROWS",
+		0x0428: "TB_GETROWS",
+		0x0429: "TB_GETBITMAPFLAGS",
+		0x042A: "TB_SETCMDID",
+		0x042B: "TB_CHANGEBITMAP",
+		0x042C: "TB_GETBITMAP",
+		0x042E: "TB_REPLACEBITMAP",
+		0x042F: "TB_SETINDENT",
+		0x0430: "TB_SETIMAGELIST",
+		0x0431: "TB_GETIMAGELIST",
+		0x0432: "TB_LOADIMAGES",
+		0x0433: "TB_GETRECT",
+		0x0434: "TB_SETHOTIMAGELIST",
+		0x0435: "TB_GETHOTIMAGELIST",
+		0x0436: "TB_SETDISABLEDIMAGELIST",
+		0x0437: "TB_GETDISABLEDIMAGELIST",
+		0x0438: "TB_SETSTYLE",
+		0x0439: "TB_GETSTYLE",
+		0x043A: "TB_GETBUTTONSIZE",
+		0x043B: "TB_SETBUTTONWIDTH",
+		0x043C: "TB_SETMAXTEXTROWS",
+		0x043D: "TB_GETTEXTROWS",
+		0x043E: "TB_GETOBJECT",
+		0x043F: "TB_GETBUTTONINFO",
+		0x0440: "TB_SETBUTTONINFO",
+		0x0443: "TB_INSERTBUTTON",
+		0x0444: "TB_ADDBUTTONS",
+		0x0445: "TB_HITTEST",
+		0x0446: "TB_SETDRAWTEXTFLAGS",
+		0x0447: "TB_GETHOTITEM",
+		0x0448: "TB_SETHOTITEM",
+		0x0449: "TB_SETANCHORHIGHLIGHT",
+		0x044A: "TB_GETANCHORHIGHLIGHT",
+		0x044B: "TB_GETBUTTONTEXT",
+		0x044C: "TB_SAVERESTORE",
+		0x044D: "TB_ADDSTRING",
+		0x044F: "TB_GETINSERTMARK",
+		0x0450: "TB_SETINSERTMARK",
+		0x0451: "TB_INSERTMARKHITTEST",
+		0x0452: "TB_MOVEBUTTON",
+		0x0453: "TB_GETMAXSIZE",
+		0x0454: "TB_SETEXTENDEDSTYLE",
+		0x0455: "TB_GETEXTENDEDSTYLE",
+		0x0456: "TB_GETPADDING",
+		0x0457: "TB_SETPADDING",
+		0x0458: "TB_SETINSERTMARKCOLOR",
+		0x0459: "TB_GETINSERTMARKCOLOR",
+		0x045A: "TB_MAPACCELERATOR",
+		0x045B: "TB_GETSTRING",
+		0x0463: "TB_GETIDEALSIZE",
+		0x0465: "TB_GETMETRICS",
+		0x2002: "TB_SETCOLORSCHEME",
+		0x2003: "TB_GETCOLORSCHEME",
+		0x2005: "TB_SETUNICODEFORMAT",
+		0x2006: "TB_GETUNICODEFORMAT",
+	},
+	"TCM": {
+		0x1302: "TCM_GETIMAGELIST",
+		0x1303: "TCM_SETIMAGELIST",
+		0x1304: "TCM_GETITEMCOUNT",
+		0x1308: "TCM_DELETEITEM",
+		0x1309: "TCM_DELETEALLITEMS",
+		0x130A: "TCM_GETITEMRECT",
+		0x130B: "TCM_GETCURSEL",
+		0x130C: "TCM_SETCURSEL",
+		0x130D: "TCM_HITTEST",
+		0x130E: "TCM_SETITEMEXTRA",
+		0x1328: "TCM_ADJUSTRECT",
+		0x1329: "TCM_SETITEMSIZE",
+		0x132A: "TCM_REMOVEIMAGE",
+		0x132B: "TCM_SETPADDING",
+		0x132C: "TCM_GETROWCOUNT",
+		0x132D: "TCM_GETTOOLTIPS",
+		0x132E: "TCM_SETTOOLTIPS",
+		0x132F: "TCM_GETCURFOCUS",
+		0x1330: "TCM_SETCURFOCUS",
+		0x1331: "TCM_SETMINTABWIDTH",
+		0x1332: "TCM_DESELECTALL",
+		0x1333: "TCM_HIGHLIGHTITEM",
+		0x1334: "TCM_SETEXTENDEDSTYLE",
+		0x1335: "TCM_GETEXTENDEDSTYLE",
+		0x133C: "TCM_GETITEM",
+		0x133D: "TCM_SETITEM",
+		0x133E: "TCM_INSERTITEM",
+		0x2005: "TCM_SETUNICODEFORMAT",
+		0x2006: "TCM_GETUNICODEFORMAT",
+	},
+	"TTM": {
+		0x0401: "TTM_ACTIVATE",
+		0x0403: "TTM_SETDELAYTIME",
+		0x0407: "TTM_RELAYEVENT",
+		0x040D: "TTM_GETTOOLCOUNT",
+		0x0410: "TTM_WINDOWFROMPOINT",
+		0x0411: "TTM_TRACKACTIVATE",
+		0x0412: "TTM_TRACKPOSITION",
+		0x0413: "TTM_SETTIPBKCOLOR",
+		0x0414: "TTM_SETTIPTEXTCOLOR",
+		0x0415: "TTM_GETDELAYTIME",
+		0x0416: "TTM_GETTIPBKCOLOR",
+		0x0417: "TTM_GETTIPTEXTCOLOR",
+		0x0418: "TTM_SETMAXTIPWIDTH",
+		0x0419: "TTM_GETMAXTIPWIDTH",
+		0x041A: "TTM_SETMARGIN",
+		0x041B: "TTM_GETMARGIN",
+		0x041C: "TTM_POP",
+		0x041D: "TTM_UPDATE",
+		0x041E: "TTM_GETBUBBLESIZE",
+		0x041F: "TTM_ADJUSTRECT",
+		0x0421: "TTM_SETTITLE",
+		0x0422: "TTM_POPUP",
+		0x0423: "TTM_GETTITLE",
+		0x0432: "TTM_ADDTOOL",
+		0x0433: "TTM_DELTOOL",
+		0x0434: "TTM_NEWTOOLRECT",
+		0x0435: "TTM_GETTOOLINFO",
+		0x0436: "TTM_SETTOOLINFO",
+		0x0437: "TTM_HITTEST",
+		0x0438: "TTM_GETTEXT",
+		0x0439: "TTM_UPDATETIPTEXT",
+		0x043A: "TTM_ENUMTOOLS",
+		0x043B: "TTM_GETCURRENTTOOL",
+	},
+	"TVM": {
+		0x1101: "TVM_DELETEITEM",
+		0x1102: "TVM_EXPAND",
+		0x1104: "TVM_GETITEMRECT",
+		0x1105: "TVM_GETCOUNT",
+		0x1106: "TVM_GETINDENT",
+		0x1107: "TVM_SETINDENT",
+		0x1108: "TVM_GETIMAGELIST",
+		0x1109: "TVM_SETIMAGELIST",
+		0x110A: "TVM_GETNEXTITEM",
+		0x110B: "TVM_SELECTITEM",
+		0x110F: "TVM_GETEDITCONTROL",
+		0x1110: "TVM_GETVISIBLECOUNT",
+		0x1111: "TVM_HITTEST",
+		0x1112: "TVM_CREATEDRAGIMAGE",
+		0x1113: "TVM_SORTCHILDREN",
+		0x1114: "TVM_ENSUREVISIBLE",
+		0x1115: "TVM_SORTCHILDRENCB",
+		0x1116: "TVM_ENDEDITLABELNOW",
+		0x1118: "TVM_SETTOOLTIPS",
+		0x1119: "TVM_GETTOOLTIPS",
+		0x111A: "TVM_SETINSERTMARK",
+		0x111B: "TVM_SETITEMHEIGHT",
+		0x111C: "TVM_GETITEMHEIGHT",
+		0x111D: "TVM_SETBKCOLOR",
+		0x111E: "TVM_SETTEXTCOLOR",
+		0x111F: "TVM_GETBKCOLOR",
+		0x1120: "TVM_GETTEXTCOLOR",
+		0x1121: "TVM_SETSCROLLTIME",
+		0x1122: "TVM_GETSCROLLTIME",
+		0x1125: "TVM_SETINSERTMARKCOLOR",
+		0x1126: "TVM_GETINSERTMARKCOLOR",
+		0x1127: "TVM_GETITEMSTATE",
+		0x1128: "TVM_SETLINECOLOR",
+		0x1129: "TVM_GETLINECOLOR",
+		0x112A: "TVM_MAPACCIDTOHTREEITEM",
+		0x112B: "TVM_MAPHTREEITEMTOACCID",
+		0x112C: "TVM_SETEXTENDEDSTYLE",
+		0x112D: "TVM_GETEXTENDEDSTYLE",
+		0x1132: "TVM_INSERTITEM",
+		0x113B: "TVM_SETAUTOSCROLLINFO",
+		0x113E: "TVM_GETITEM",
+		0x113F: "TVM_SETITEM",
+		0x1140: "TVM_GETISEARCHSTRING",
+		0x1141: "TVM_EDITLABEL",
+		0x2005: "TVM_SETUNICODEFORMAT",
+		0x2006: "TVM_GETUNICODEFORMAT",
+	},
+	"UDM": {
+		0x0465: "UDM_SETRANGE",
+		0x0466: "UDM_GETRANGE",
+		0x0467: "UDM_SETPOS",
+		0x0468: "UDM_GETPOS",
+		0x0469: "UDM_SETBUDDY",
+		0x046A: "UDM_GETBUDDY",
+		0x046B: "UDM_SETACCEL",
+		0x046C: "UDM_GETACCEL",
+		0x046D: "UDM_SETBASE",
+		0x046E: "UDM_GETBASE",
+		0x046F: "UDM_SETRANGE32",
+		0x0470: "UDM_GETRANGE32",
+		0x0471: "UDM_SETPOS32",
+		0x0472: "UDM_GETPOS32",
+		0x2005: "UDM_SETUNICODEFORMAT",
+		0x2006: "UDM_GETUNICODEFORMAT",
+	},
+}